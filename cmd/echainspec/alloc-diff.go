@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var allocDiffCommand = cli.Command{
+	Name:  "alloc-diff",
+	Usage: "List genesis accounts added, removed, or with changed balances compared to another configuration",
+	Description: `Lines are formatted as:
+  +<address> <balance> ETH           (added)
+  -<address>                         (removed)
+  ~<address> <balance> -> <balance> ETH  (balance changed)`,
+	Flags:  []cli.Flag{diffOtherFlag, diffOtherFormatFlag},
+	Action: allocDiff,
+}
+
+// allocChange is a difference in a genesis account between two configurations.
+// A nil balance means the account does not exist on that side.
+type allocChange struct {
+	Address common.Address
+	A, B    *big.Int
+}
+
+func (c allocChange) String() string {
+	switch {
+	case c.A == nil:
+		return fmt.Sprintf("+%s %s ETH", c.Address.Hex(), formatEther(c.B))
+	case c.B == nil:
+		return fmt.Sprintf("-%s", c.Address.Hex())
+	}
+	return fmt.Sprintf("~%s %s -> %s ETH", c.Address.Hex(), formatEther(c.A), formatEther(c.B))
+}
+
+// allocChanges compares the genesis account balances of a and b.
+// Only the balances of a are held in memory; b's accounts are compared as they are iterated.
+func allocChanges(a, b ctypes.GenesisBlocker) ([]allocChange, error) {
+	balances := make(map[common.Address]*big.Int)
+	err := a.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		if commandContext.Err() != nil {
+			return errTimeout
+		}
+		balances[address] = balanceOrZero(bal)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	changes := []allocChange{}
+	err = b.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		if commandContext.Err() != nil {
+			return errTimeout
+		}
+		bal = balanceOrZero(bal)
+		abal, ok := balances[address]
+		if !ok {
+			changes = append(changes, allocChange{Address: address, B: bal})
+			return nil
+		}
+		delete(balances, address)
+		if abal.Cmp(bal) != 0 {
+			changes = append(changes, allocChange{Address: address, A: abal, B: bal})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for address, abal := range balances {
+		changes = append(changes, allocChange{Address: address, A: abal})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Address.Hex() < changes[j].Address.Hex()
+	})
+	return changes, nil
+}
+
+func balanceOrZero(bal *big.Int) *big.Int {
+	if bal == nil {
+		return new(big.Int)
+	}
+	return bal
+}
+
+// formatEther formats a wei value as an exact decimal ether value.
+func formatEther(wei *big.Int) string {
+	q, r := new(big.Int).QuoRem(wei, big.NewInt(vars.Ether), new(big.Int))
+	if r.Sign() == 0 {
+		return q.String()
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%018s", new(big.Int).Abs(r).String()), "0")
+	if q.Sign() == 0 && wei.Sign() < 0 {
+		return "-0." + frac
+	}
+	return q.String() + "." + frac
+}
+
+func allocDiff(ctx *cli.Context) error {
+	other, err := readDiffOther(ctx)
+	if err != nil {
+		return err
+	}
+	changes, err := allocChanges(globalChainspecValue, other)
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+	return nil
+}
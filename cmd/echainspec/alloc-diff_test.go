@@ -0,0 +1,43 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestAllocChanges(t *testing.T) {
+	ether := func(n int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(n), big.NewInt(vars.Ether))
+	}
+	a := &genesisT.Genesis{Alloc: genesisT.GenesisAlloc{
+		common.Address{1}: {Balance: ether(50)},
+		common.Address{2}: {Balance: ether(1)},
+		common.Address{3}: {Balance: ether(7)},
+	}}
+	b := &genesisT.Genesis{Alloc: genesisT.GenesisAlloc{
+		common.Address{1}: {Balance: ether(75)},
+		common.Address{3}: {Balance: ether(7)},
+		common.Address{4}: {Balance: big.NewInt(1)},
+	}}
+	changes, err := allocChanges(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"~" + common.Address{1}.Hex() + " 50 -> 75 ETH",
+		"-" + common.Address{2}.Hex(),
+		"+" + common.Address{4}.Hex() + " 0.000000000000000001 ETH",
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("want %d changes, got: %v", len(want), changes)
+	}
+	for i, w := range want {
+		if got := changes[i].String(); got != w {
+			t.Errorf("change %d: want: %s, got: %s", i, w, got)
+		}
+	}
+}
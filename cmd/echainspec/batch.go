@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	batchOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Directory to write the converted configurations to, creating it if needed",
+	}
+	batchFailFastFlag = cli.BoolFlag{
+		Name:  "fail-fast",
+		Usage: "Stop at the first configuration which fails to convert",
+	}
+)
+
+var batchCommand = cli.Command{
+	Name:      "batch",
+	Usage:     "Convert each JSON configuration file of a directory",
+	ArgsUsage: "<dir>",
+	Description: `Each .json file of the directory is read (with --inputf, or its detected format), converted as
+the configuration is without a command (so --outputf and the other output flags apply), and written
+to the --out directory with the same basename. Existing files are only overwritten with --force.
+
+With --concurrency N, up to N files are converted in parallel; failures are still reported in file order.
+A file which fails to convert is reported on stderr, and the batch continues, unless --fail-fast is given
+(with --concurrency, files after the failure may already have been converted).
+A summary of the numbers of converted and failed files is printed at the end.
+Exits 0 if all files are converted, otherwise 1.`,
+	Flags:  []cli.Flag{batchOutFlag, batchFailFastFlag},
+	Action: batch,
+}
+
+var (
+	errMissingBatchDir = errors.New("missing directory argument")
+	errMissingBatchOut = errors.New("missing --out directory")
+	errBatchFailed     = errors.New("configurations failed to convert")
+)
+
+// batchResult counts the files of a batch conversion.
+type batchResult struct {
+	Converted, Failed int
+}
+
+// batchConvert converts each .json file of inDir with convert, on up to concurrency goroutines,
+// writing the results to outDir with the same basenames. Files which fail are logged, in file order,
+// and skipped unless failFast, in which case the first failure is returned.
+func batchConvert(inDir, outDir string, convert func(data []byte) ([]byte, error), force, failFast bool, concurrency int) (batchResult, error) {
+	var res batchResult
+	paths, err := filepath.Glob(filepath.Join(inDir, "*.json"))
+	if err != nil {
+		return res, err
+	}
+	sort.Strings(paths)
+	next := func() (func() interface{}, error) {
+		if len(paths) == 0 {
+			return nil, nil
+		}
+		if commandContext.Err() != nil {
+			return nil, errTimeout
+		}
+		p := paths[0]
+		paths = paths[1:]
+		return func() interface{} {
+			if err := batchConvertFile(p, filepath.Join(outDir, filepath.Base(p)), convert, force); err != nil {
+				return fmt.Errorf("%s: %w", p, err)
+			}
+			return nil
+		}, nil
+	}
+	var failed error
+	err = runOrdered(concurrency, next, func(v interface{}) bool {
+		if v == nil {
+			res.Converted++
+			return true
+		}
+		res.Failed++
+		if failFast {
+			failed = v.(error)
+			return false
+		}
+		log.Println(v)
+		return true
+	})
+	if failed != nil {
+		return res, failed
+	}
+	return res, err
+}
+
+func batchConvertFile(path, outPath string, convert func(data []byte) ([]byte, error), force bool) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	b, err := convert(data)
+	if err != nil {
+		return err
+	}
+	return writeOutFile(outPath, b, force)
+}
+
+func batch(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errMissingBatchDir
+	}
+	if !ctx.IsSet(batchOutFlag.Name) {
+		return errMissingBatchOut
+	}
+	if f := ctx.GlobalString(outputFormatFlag.Name); f != "" {
+		if _, err := echainspec.New(f); err != nil {
+			return errInvalidOutputFlag
+		}
+	}
+	convert := func(data []byte) ([]byte, error) {
+		conf, err := readChainspec(ctx.GlobalString(formatInFlag.Name), data, ctx.GlobalBool(strictFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		out, err := convertOutput(ctx, conf)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.GlobalBool(validateOnConvertFlag.Name) {
+			if err := validateOutputConfig(out); err != nil {
+				return nil, err
+			}
+		}
+		return marshalOutput(ctx, out)
+	}
+	n, err := concurrency(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := batchConvert(ctx.Args().First(), ctx.String(batchOutFlag.Name), convert, ctx.GlobalBool(outFileForceFlag.Name), ctx.Bool(batchFailFastFlag.Name), n)
+	fmt.Printf("%d converted, %d failed\n", res.Converted, res.Failed)
+	if err != nil {
+		return err
+	}
+	if res.Failed > 0 {
+		return fmt.Errorf("%d of %d %w", res.Failed, res.Converted+res.Failed, errBatchFailed)
+	}
+	return nil
+}
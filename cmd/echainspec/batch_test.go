@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestBatchConvert(t *testing.T) {
+	in, err := ioutil.TempDir("", "echainspec-batch-in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(in)
+	out, err := ioutil.TempDir("", "echainspec-batch-out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	for _, name := range []string{"foundation", "goerli"} {
+		conf, err := echainspec.Convert(defaultChainspecValues[name], "parity")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := echainspec.Write(conf, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(in, name+".json"), buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, data := range map[string]string{"broken.json": "{bad", "notes.txt": "not a configuration"} {
+		if err := ioutil.WriteFile(filepath.Join(in, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	convert := func(data []byte) ([]byte, error) {
+		conf, err := readChainspec("", data, false)
+		if err != nil {
+			return nil, err
+		}
+		if conf, err = echainspec.Convert(conf, "multigeth"); err != nil {
+			return nil, err
+		}
+		return marshalOutputJSON(conf, false)
+	}
+
+	res, err := batchConvert(in, out, convert, false, false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (batchResult{Converted: 2, Failed: 1}); res != want {
+		t.Errorf("got %+v, want %+v", res, want)
+	}
+	for _, name := range []string{"foundation.json", "goerli.json"} {
+		data, err := ioutil.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := echainspec.ReadStrict("multigeth", bytes.NewReader(data)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "broken.json")); !os.IsNotExist(err) {
+		t.Errorf("broken.json: want not written, got: %v", err)
+	}
+
+	// Existing files are not overwritten without force; failing fast stops at the first failure.
+	res, err = batchConvert(in, out, convert, false, true, 1)
+	if err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("fail fast: got %v, want broken.json error", err)
+	}
+	if want := (batchResult{Failed: 1}); res != want {
+		t.Errorf("fail fast: got %+v, want %+v", res, want)
+	}
+	res, err = batchConvert(in, out, convert, true, false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (batchResult{Converted: 2, Failed: 1}); res != want {
+		t.Errorf("force: got %+v, want %+v", res, want)
+	}
+	res, err = batchConvert(in, out, convert, true, false, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (batchResult{Converted: 2, Failed: 1}); res != want {
+		t.Errorf("concurrency: got %+v, want %+v", res, want)
+	}
+}
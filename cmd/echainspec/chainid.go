@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var chainIDNetworkFlag = cli.BoolFlag{
+	Name:  "network",
+	Usage: "Also print the network ID, on a second line",
+}
+
+var chainIDCommand = cli.Command{
+	Name:        "chainid",
+	Usage:       "Print the chain ID of the configuration",
+	Description: `Undefined values are printed as "-".`,
+	Flags:       []cli.Flag{chainIDNetworkFlag},
+	Action:      chainID,
+}
+
+var errNoChainID = errors.New("configuration defines neither a chain ID nor a network ID")
+
+// writeChainID writes the chain ID, and optionally the network ID, of the configuration.
+// A zero network ID is undefined, since some data types cannot otherwise represent an unset value.
+func writeChainID(w io.Writer, conf ctypes.ChainConfigurator, network bool) error {
+	chainID, networkID := conf.GetChainID(), conf.GetNetworkID()
+	if networkID != nil && *networkID == 0 {
+		networkID = nil
+	}
+	if chainID == nil && networkID == nil {
+		return errNoChainID
+	}
+	if chainID != nil {
+		fmt.Fprintln(w, chainID)
+	} else {
+		fmt.Fprintln(w, "-")
+	}
+	if !network {
+		return nil
+	}
+	if networkID != nil {
+		fmt.Fprintln(w, *networkID)
+	} else {
+		fmt.Fprintln(w, "-")
+	}
+	return nil
+}
+
+func chainID(ctx *cli.Context) error {
+	return writeChainID(os.Stdout, globalChainspecValue, ctx.Bool(chainIDNetworkFlag.Name))
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+func TestWriteChainID(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		network bool
+		want    string
+	}{
+		{"classic", false, "61\n"},
+		{"classic", true, "61\n1\n"},
+		{"mordor", true, "63\n7\n"},
+	} {
+		buf := new(bytes.Buffer)
+		if err := writeChainID(buf, defaultChainspecValues[c.name], c.network); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+	empty := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := writeChainID(new(bytes.Buffer), empty, true); err != errNoChainID {
+		t.Errorf("want %v, got %v", errNoChainID, err)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigethv0"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// outputCompatFormats maps legacy output schema names to constructors for their data types,
+// and to the (current) output format they are legacy versions of.
+var outputCompatFormats = map[string]struct {
+	Format string
+	New    func() ctypes.Configurator
+}{
+	// The multigeth schema before the 'networkId' and 'requireBlockHashes' fields
+	// (and the changes to fork field names) were introduced.
+	"multigeth-v1": {"multigeth", func() ctypes.Configurator {
+		return &genesisT.Genesis{
+			Config: &multigethv0.ChainConfig{},
+		}
+	}},
+}
+
+var outputCompatFlag = cli.StringFlag{
+	Name:  "output-compat",
+	Usage: "Write the output using a legacy schema of its format [multigeth-v1]",
+}
+
+var errInvalidOutputCompat = errors.New("invalid output compat schema")
+
+// convertCompat converts a configuration to a legacy schema's data type.
+// Fields which the legacy schema cannot represent are dropped, and each
+// dropped field is returned as a warning.
+func convertCompat(conf ctypes.Configurator, newCompat func() ctypes.Configurator) (ctypes.Configurator, []string, error) {
+	// Work on a copy, since dropped fields are cleared from it.
+	working, err := echainspec.Convert(conf, "multigeth")
+	if err != nil {
+		return nil, nil, err
+	}
+	var warnings []string
+	for {
+		out := newCompat()
+		err := confp.Convert(working, out)
+		uerr, ok := err.(ctypes.ErrUnsupportedConfig)
+		if !ok || !ctypes.IsFatalUnsupportedErr(uerr.Err) {
+			return out, warnings, err
+		}
+		setter := reflect.ValueOf(working).MethodByName("Set" + uerr.Method)
+		if !setter.IsValid() || setter.Type().NumIn() != 1 {
+			return nil, warnings, err
+		}
+		res := setter.Call([]reflect.Value{reflect.Zero(setter.Type().In(0))})
+		if !res[0].IsNil() {
+			return nil, warnings, err
+		}
+		warnings = append(warnings, fmt.Sprintf("dropped field %s (value: %v), not supported by the legacy schema", uerr.Method, uerr.Value))
+	}
+}
+
+// applyOutputCompat applies the --output-compat flag (if set) to the given output configuration.
+func applyOutputCompat(ctx *cli.Context, conf ctypes.Configurator) (ctypes.Configurator, error) {
+	if !ctx.GlobalIsSet(outputCompatFlag.Name) {
+		return conf, nil
+	}
+	name := ctx.GlobalString(outputCompatFlag.Name)
+	compat, ok := outputCompatFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errInvalidOutputCompat, name)
+	}
+	if f := ctx.GlobalString(outputFormatFlag.Name); f != compat.Format {
+		return nil, fmt.Errorf("%w: %s requires --outputf %s", errInvalidOutputCompat, name, compat.Format)
+	}
+	out, warnings, err := convertCompat(conf, compat.New)
+	for _, w := range warnings {
+		log.Println("warning:", w)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
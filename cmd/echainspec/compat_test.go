@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigethv0"
+)
+
+func TestConvertCompatMultigethV1(t *testing.T) {
+	out, warnings, err := convertCompat(params.DefaultClassicGenesisBlock(), outputCompatFormats["multigeth-v1"].New)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) == 0 {
+		t.Error("want warnings for fields the legacy schema does not have")
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The output must be read as the legacy schema.
+	var g genesisT.Genesis
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.Config.(*multigethv0.ChainConfig); !ok {
+		t.Errorf("want legacy multigeth config, got: %T", g.Config)
+	}
+	if *g.GetEIP155Transition() != 3000000 {
+		t.Errorf("want EIP155 at 3000000, got: %v", *g.GetEIP155Transition())
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var concurrencyFlag = cli.IntFlag{
+	Name:  "concurrency",
+	Usage: "Number of configurations to process in parallel (batch and validate --ndjson); results are still reported in input order",
+	Value: 1,
+}
+
+var errInvalidConcurrency = errors.New("invalid --concurrency")
+
+// concurrency returns the --concurrency value, which must be positive.
+func concurrency(ctx *cli.Context) (int, error) {
+	n := ctx.GlobalInt(concurrencyFlag.Name)
+	if n < 1 {
+		return 0, fmt.Errorf("%w: %d (want a positive number)", errInvalidConcurrency, n)
+	}
+	return n, nil
+}
+
+// runOrdered runs the jobs returned by next on up to n goroutines, and calls emit with the result of each,
+// in the order the jobs were returned. At most n jobs are running or have results waiting to be emitted
+// at once, so a slow job holds back those after it. next returns a nil job when there are no more,
+// or an error, which is returned once the started jobs are emitted.
+// If emit returns false, no further jobs are started, and the results of those already started are discarded.
+func runOrdered(n int, next func() (func() interface{}, error), emit func(interface{}) bool) error {
+	var (
+		slots   = make(chan struct{}, n)
+		pending = make(chan chan interface{}, n)
+		stop    = make(chan struct{})
+		done    = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		stopped := false
+		for res := range pending {
+			r := <-res
+			if !stopped && !emit(r) {
+				stopped = true
+				close(stop)
+			}
+			<-slots
+		}
+	}()
+	var err error
+loop:
+	for {
+		select {
+		case <-stop:
+			break loop
+		case slots <- struct{}{}:
+		}
+		// The slot may have been released by the emit which stopped.
+		select {
+		case <-stop:
+			<-slots
+			break loop
+		default:
+		}
+		var job func() interface{}
+		if job, err = next(); err != nil || job == nil {
+			<-slots
+			break loop
+		}
+		res := make(chan interface{}, 1)
+		pending <- res
+		go func() {
+			res <- job()
+		}()
+	}
+	close(pending)
+	<-done
+	return err
+}
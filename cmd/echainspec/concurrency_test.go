@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestRunOrdered(t *testing.T) {
+	const jobs, n = 50, 4
+	var running, maxRunning int32
+	i := 0
+	next := func() (func() interface{}, error) {
+		if i == jobs {
+			return nil, nil
+		}
+		k := i
+		i++
+		return func() interface{} {
+			r := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if r <= m || atomic.CompareAndSwapInt32(&maxRunning, m, r) {
+					break
+				}
+			}
+			// Earlier jobs take longer, so finish out of order.
+			time.Sleep(time.Duration(jobs-k) * 50 * time.Microsecond)
+			atomic.AddInt32(&running, -1)
+			return k
+		}, nil
+	}
+	got := []int{}
+	err := runOrdered(n, next, func(v interface{}) bool {
+		got = append(got, v.(int))
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range got {
+		if k != v {
+			t.Fatalf("results out of order: %v", got)
+		}
+	}
+	if len(got) != jobs {
+		t.Errorf("got %d results, want %d", len(got), jobs)
+	}
+	if maxRunning > n {
+		t.Errorf("got %d jobs running at once, want at most %d", maxRunning, n)
+	}
+
+	// Jobs are not started after emit stops, nor after next fails.
+	i, got = 0, []int{}
+	runOrdered(n, next, func(v interface{}) bool {
+		got = append(got, v.(int))
+		return v.(int) < 2
+	})
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) || i > 3+n {
+		t.Errorf("stop: got results %v after %d jobs started", got, i)
+	}
+	errNext := errors.New("next failed")
+	i = 0
+	err = runOrdered(n, func() (func() interface{}, error) {
+		if i == 3 {
+			return nil, errNext
+		}
+		return next()
+	}, func(interface{}) bool { return true })
+	if err != errNext || i != 3 {
+		t.Errorf("next error: got %v after %d jobs, want %v after 3", err, i, errNext)
+	}
+}
+
+func TestValidateNDJSONConcurrency(t *testing.T) {
+	valid, err := json.Marshal(params.DefaultClassicGenesisBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	regression := `{"config":{"chainId":1,"networkId":1,"eip140FBlock":100,"eip1344FBlock":10,"ethash":{}},"difficulty":"0x1","gasLimit":"0x1388","alloc":{}}`
+	lines := []string{}
+	for i := 0; i < 20; i++ {
+		if i%3 == 0 {
+			lines = append(lines, regression)
+		} else {
+			lines = append(lines, string(valid))
+		}
+	}
+	input := strings.Join(lines, "\n")
+
+	want := new(bytes.Buffer)
+	if _, err := validateNDJSON(strings.NewReader(input), want, "multigeth", false, nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	got := new(bytes.Buffer)
+	ok, err := validateNDJSON(strings.NewReader(input), got, "multigeth", false, nil, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("want invalid result")
+	}
+	if got.String() != want.String() {
+		t.Errorf("concurrent results differ:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var consensusCommand = cli.Command{
+	Name:  "consensus",
+	Usage: "Print the consensus engine and its parameters",
+	Description: `Lines are formatted as '<key> <value>'. The engine is one of:
+  ethash           (ethash)
+  ethash-ecip1017  (ethash with the ECIP-1017 monetary policy, eg. Ethereum Classic)
+  clique           (proof-of-authority)
+or the configuration's own name for an engine which is not supported.
+An ethash engine which does not verify the proof of work (eg. Aleth's NoProof) has the line 'noProof true'.
+The engine's parameters are followed by the block validity parameters of any engine:
+networkId, maximumExtraDataSize, minGasLimit, and gasLimitBoundDivisor.`,
+	Action: consensus,
+}
+
+// consensusParam is a consensus engine parameter.
+type consensusParam struct {
+	Key   string
+	Value string
+}
+
+// unknownEngineKeys are the names of unsupported engines which configurations may define.
+var unknownEngineKeys = []string{"authorityRound", "aura", "ibft2", "qbft", "instantSeal", "null"}
+
+// rawEngineName returns the name of the engine which a configuration defines,
+// as named in the configuration, or "unknown" if none is found.
+func rawEngineName(conf ctypes.Configurator) string {
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return ctypes.ConsensusEngineT(ctypes.ConsensusEngineT_Unknown).String()
+	}
+	var v struct {
+		SealEngine string                     `json:"sealEngine"`
+		Engine     map[string]json.RawMessage `json:"engine"`
+		Config     map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(b, &v); err == nil {
+		if v.SealEngine != "" {
+			return v.SealEngine
+		}
+		for _, m := range []map[string]json.RawMessage{v.Engine, v.Config} {
+			for _, k := range unknownEngineKeys {
+				if raw, ok := m[k]; ok && string(raw) != "null" {
+					return k
+				}
+			}
+		}
+	}
+	return ctypes.ConsensusEngineT(ctypes.ConsensusEngineT_Unknown).String()
+}
+
+// formatUint64BigMap formats a block-keyed map as comma-separated 'block:value' pairs, sorted by block.
+func formatUint64BigMap(m ctypes.Uint64BigMapEncodesHex) string {
+	if len(m) == 0 {
+		return "-"
+	}
+	blocks := make([]uint64, 0, len(m))
+	for k := range m {
+		blocks = append(blocks, k)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	pairs := make([]string, len(blocks))
+	for i, k := range blocks {
+		pairs[i] = fmt.Sprintf("%d:%v", k, m[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// consensusParams returns the consensus engine of a configuration, and its parameters,
+// followed by the engine-independent block validity parameters.
+func consensusParams(conf ctypes.Configurator) []consensusParam {
+	return append(engineParams(conf),
+		consensusParam{"networkId", formatDiffValue(conf.GetNetworkID())},
+		consensusParam{"maximumExtraDataSize", formatDiffValue(conf.GetMaximumExtraDataSize())},
+		consensusParam{"minGasLimit", formatDiffValue(conf.GetMinGasLimit())},
+		consensusParam{"gasLimitBoundDivisor", formatDiffValue(conf.GetGasLimitBoundDivisor())},
+	)
+}
+
+// engineParams returns the consensus engine of a configuration, and its parameters.
+func engineParams(conf ctypes.Configurator) []consensusParam {
+	switch engine := conf.GetConsensusEngineType(); {
+	case engine.IsClique():
+		return []consensusParam{
+			{"engine", engine.String()},
+			{"period", fmt.Sprint(conf.GetCliquePeriod())},
+			{"epoch", fmt.Sprint(conf.GetCliqueEpoch())},
+		}
+	case engine.IsEthash():
+		name := engine.String()
+		if conf.GetEthashECIP1017Transition() != nil {
+			name += "-ecip1017"
+		}
+		params := []consensusParam{
+			{"engine", name},
+			{"minimumDifficulty", formatDiffValue(conf.GetEthashMinimumDifficulty())},
+			{"difficultyBoundDivisor", formatDiffValue(conf.GetEthashDifficultyBoundDivisor())},
+			{"durationLimit", formatDiffValue(conf.GetEthashDurationLimit())},
+			{"difficultyBombDelays", formatUint64BigMap(conf.GetEthashDifficultyBombDelaySchedule())},
+			{"blockRewards", formatUint64BigMap(conf.GetEthashBlockRewardSchedule())},
+		}
+		if conf.GetEthashECIP1017Transition() != nil {
+			params = append(params,
+				consensusParam{"ecip1017Transition", formatDiffValue(conf.GetEthashECIP1017Transition())},
+				consensusParam{"ecip1017EraRounds", formatDiffValue(conf.GetEthashECIP1017EraRounds())},
+			)
+		}
+		if conf.GetEthashNoProof() {
+			params = append(params, consensusParam{"noProof", "true"})
+		}
+		for _, p := range []struct {
+			key string
+			fn  func() *uint64
+		}{
+			{"eip649Transition", conf.GetEthashEIP649Transition},
+			{"eip1234Transition", conf.GetEthashEIP1234Transition},
+			{"eip2384Transition", conf.GetEthashEIP2384Transition},
+			{"eip3554Transition", conf.GetEthashEIP3554Transition},
+			{"ecip1010PauseTransition", conf.GetEthashECIP1010PauseTransition},
+			{"ecip1010ContinueTransition", conf.GetEthashECIP1010ContinueTransition},
+			{"ecip1041Transition", conf.GetEthashECIP1041Transition},
+		} {
+			if v := p.fn(); v != nil {
+				params = append(params, consensusParam{p.key, formatDiffValue(v)})
+			}
+		}
+		return params
+	}
+	return []consensusParam{{"engine", rawEngineName(conf)}}
+}
+
+func consensus(ctx *cli.Context) error {
+	for _, p := range consensusParams(globalChainspecValue) {
+		fmt.Println(p.Key, p.Value)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+func TestConsensusParams(t *testing.T) {
+	get := func(params []consensusParam, key string) string {
+		for _, p := range params {
+			if p.Key == key {
+				return p.Value
+			}
+		}
+		return ""
+	}
+	for _, c := range []struct {
+		name, key, want string
+	}{
+		{"goerli", "engine", "clique"},
+		{"goerli", "period", "15"},
+		{"goerli", "epoch", "30000"},
+		{"foundation", "engine", "ethash"},
+		{"foundation", "eip2384Transition", "9200000"},
+		{"foundation", "networkId", "1"},
+		{"foundation", "gasLimitBoundDivisor", "1024"},
+		{"classic", "engine", "ethash-ecip1017"},
+		{"classic", "ecip1017EraRounds", "5000000"},
+		{"classic", "ecip1041Transition", "5900000"},
+	} {
+		if got := get(consensusParams(defaultChainspecValues[c.name]), c.key); got != c.want {
+			t.Errorf("%s %s: got %q, want %q", c.name, c.key, got, c.want)
+		}
+	}
+
+	ibft := &genesisT.Genesis{Config: &besu.BesuChainConfig{IBFT2: []byte(`{"blockperiodseconds":2}`)}}
+	if got := get(consensusParams(ibft), "engine"); got != "ibft2" {
+		t.Errorf("ibft2: got engine %q, want %q", got, "ibft2")
+	}
+}
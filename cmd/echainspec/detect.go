@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var detectProbeFlag = cli.BoolFlag{
+	Name:  "stdin-format-probe",
+	Usage: "Decide the format from the structure of the leading input, without reading all of it (falls back to full parsing if ambiguous)",
+}
+
+var detectCommand = cli.Command{
+	Name:        "detect",
+	Usage:       "Report which format(s) an input configuration parses as",
+	Description: "Exits 0 if any format matches, 1 if not. No conversion is performed.",
+	Flags:       []cli.Flag{detectProbeFlag},
+	Action:      detect,
+}
+
+var errNoFormatDetected = errors.New("input does not parse as any known format")
+
+// autoFormat is the input format name requesting format detection.
+const autoFormat = "auto"
+
+// multigethConfigKeyRe matches genesis config keys which only the multigeth format uses.
+var multigethConfigKeyRe = regexp.MustCompile(`^(e?c?ip\d+\w*FBlock|ecip.*|networkId|blockReward|difficultyBombDelays|disposalBlock|socialBlock|ethersocialBlock|requireBlockHashes)$`)
+
+// besuConfigKeyRe matches genesis config keys which only the Besu format uses.
+// Besu reads keys case-insensitively.
+var besuConfigKeyRe = regexp.MustCompile(`(?i)^(ibft2|contractSizeLimit|evmStackSize|classicForkBlock|constantinopleFixBlock|ecip1015Block|dieHardBlock|gothamBlock|ecip1041Block|atlantisBlock|aghartaBlock|phoenixBlock|thanosBlock)$`)
+
+// nethermindParamsKeyRe matches chain spec params keys which only the Nethermind format uses.
+var nethermindParamsKeyRe = regexp.MustCompile(`^eip(1706Transition|2200Transition|\d+TransitionTimestamp)$`)
+
+// probeFormat decides the format of a configuration from its structure,
+// reading only as much of the input as is needed to decide.
+// Parity and Nethermind specs have a top-level 'engine' and 'params', in which
+// Nethermind-only keys may appear, while geth and multigeth genesis
+// configurations have a nested 'config', in which multigeth- or Besu-only keys may appear.
+// An empty format is returned if the structure is ambiguous.
+func probeFormat(r io.Reader) (string, error) {
+	dec := json.NewDecoder(r)
+	if t, err := dec.Token(); err != nil {
+		return "", err
+	} else if t != json.Delim('{') {
+		return "", nil
+	}
+	var (
+		engine bool
+		spec   string // format decided by the params object, if read
+	)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t {
+		case "engine":
+			if spec != "" {
+				return spec, nil
+			}
+			engine = true
+			if err := skipJSONValue(dec); err != nil {
+				return "", err
+			}
+			continue
+		case "params":
+			if spec, err = probeParams(dec); err != nil || spec == "" {
+				return "", err
+			}
+			if engine {
+				return spec, nil
+			}
+			continue
+		case "config":
+			if t, err := dec.Token(); err != nil {
+				return "", err
+			} else if t != json.Delim('{') {
+				return "", nil
+			}
+			for dec.More() {
+				k, err := dec.Token()
+				if err != nil {
+					return "", err
+				}
+				key, _ := k.(string)
+				if besuConfigKeyRe.MatchString(key) {
+					return "besu", nil
+				}
+				if multigethConfigKeyRe.MatchString(key) {
+					return "multigeth", nil
+				}
+				if err := skipJSONValue(dec); err != nil {
+					return "", err
+				}
+			}
+			return "geth", nil
+		}
+		if err := skipJSONValue(dec); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// probeParams reads a chain spec params object, returning "nethermind" if it has
+// Nethermind-only keys, or "parity" if not; Parity's is the format preferred for
+// specs which both read. An empty format is returned if params is not an object.
+func probeParams(dec *json.Decoder) (string, error) {
+	if t, err := dec.Token(); err != nil {
+		return "", err
+	} else if t != json.Delim('{') {
+		return "", nil
+	}
+	format := "parity"
+	for dec.More() {
+		k, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		if key, _ := k.(string); nethermindParamsKeyRe.MatchString(key) {
+			format = "nethermind"
+		}
+		if err := skipJSONValue(dec); err != nil {
+			return "", err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// skipJSONValue consumes the next value from the decoder without retaining it.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// detectProbe runs the structural probe on the input, falling back
+// to full-parse detection if the probe is ambiguous.
+func detectProbe(ctx *cli.Context) error {
+	r, err := openInput(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	// Keep what the probe reads, in case a full parse is needed after all.
+	var read bytes.Buffer
+	format, err := probeFormat(io.TeeReader(r, &read))
+	if err == nil && format != "" {
+		fmt.Println("detected:", format)
+		fmt.Println("confidence: structural probe")
+		return nil
+	}
+	rest, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return detectData(append(read.Bytes(), rest...))
+}
+
+func detect(ctx *cli.Context) error {
+	if ctx.Bool(detectProbeFlag.Name) {
+		return detectProbe(ctx)
+	}
+	data, err := readInputData(ctx)
+	if err != nil {
+		return err
+	}
+	return detectData(data)
+}
+
+func detectData(data []byte) error {
+	candidates := echainspec.Detect(data)
+	if len(candidates) == 0 {
+		return errNoFormatDetected
+	}
+	best := candidates[0]
+	line := fmt.Sprintf("detected: %s", best.Format)
+	if len(candidates) > 1 {
+		others := []string{}
+		for _, c := range candidates[1:] {
+			others = append(others, c.Format)
+		}
+		line += fmt.Sprintf(" (also parses as: %s)", strings.Join(others, ", "))
+	}
+	fmt.Println(line)
+
+	note := fmt.Sprintf("confidence: %.0f%% of input fields recognized", best.Score*100)
+	if len(candidates) > 1 {
+		note += fmt.Sprintf(" (next best: %s, %.0f%%)", candidates[1].Format, candidates[1].Score*100)
+	}
+	fmt.Println(note)
+	return nil
+}
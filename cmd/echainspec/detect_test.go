@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProbeFormat(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{`{"name": "x", "engine": {"Ethash": {}}, "params": {}}`, "parity"},
+		{`{"name": "x", "engine": {"Ethash": {}}, "params": {"eip150Transition": "0x0", "eip1706Transition": "0x0"}}`, "nethermind"},
+		{`{"params": {"eip3855TransitionTimestamp": "0x0"}, "engine": {"Ethash": {}}}`, "nethermind"},
+		{`{"params": {"eip150Transition": "0x0"}, "engine": {"Ethash": {}}}`, "parity"},
+		{`{"name": "x", "engine": {"Ethash": {}}}`, ""},
+		{`{"alloc": {"0x01": {"balance": "1"}}, "config": {"chainId": 1, "eip2FBlock": 0}}`, "multigeth"},
+		{`{"config": {"chainId": 1, "homesteadBlock": 0, "ethash": {}}, "alloc": {}}`, "geth"},
+		{`{"config": {"chainId": 61, "atlantisBlock": 0, "ecip1017EraRounds": 5000000}, "alloc": {}}`, "besu"},
+		{`{"sealEngine": "Ethash", "params": {}}`, ""},
+		{`[]`, ""},
+	}
+	for i, c := range cases {
+		got, err := probeFormat(strings.NewReader(c.input))
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if got != c.want {
+			t.Errorf("case %d: want: %q, got: %q", i, c.want, got)
+		}
+	}
+}
+
+func TestProbeFormatPartialInput(t *testing.T) {
+	// The format is decided before the (truncated) remainder is read.
+	got, err := probeFormat(strings.NewReader(`{"config": {"networkId": 1}, "alloc": {"0x01": {"bal`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "multigeth" {
+		t.Errorf("want: multigeth, got: %q", got)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var diffAgainstFlag = cli.StringFlag{
+	Name:  "diff-against",
+	Usage: "Instead of the converted configuration, print as JSON only its fields which differ from the named default configuration",
+}
+
+// diffAgainst returns the values of the fields of conf which differ semantically from those of def,
+// by their confp.EqualConfigs paths. Unset values, and accounts which conf does not have, are nil.
+// An account's value is its summary, as compared by the diff command.
+func diffAgainst(conf, def ctypes.Configurator) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	for _, d := range confp.ConfigDifferences(conf, def) {
+		v := reflect.ValueOf(d.A)
+		if !v.IsValid() || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil() {
+			out[d.Field] = nil
+			continue
+		}
+		if s, ok := d.A.(ctypes.ConsensusEngineT); ok {
+			out[d.Field] = s.String()
+			continue
+		}
+		out[d.Field] = d.A
+	}
+	return out, nil
+}
+
+// printDiffAgainst prints the fields of conf which differ from the default named by --diff-against.
+func printDiffAgainst(ctx *cli.Context, conf ctypes.Configurator) error {
+	def, err := lookupDefault(ctx.GlobalString(diffAgainstFlag.Name), userDefaultsDir(), ctx.GlobalBool(overrideDefaultsFlag.Name))
+	if err != nil {
+		return err
+	}
+	diffs, err := diffAgainst(conf, def)
+	if err != nil {
+		return err
+	}
+	if ctx.GlobalIsSet(selectFlag.Name) {
+		return printSelected(ctx, diffs)
+	}
+	return printJSON(ctx, diffs)
+}
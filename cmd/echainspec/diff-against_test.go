@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+func TestDiffAgainst(t *testing.T) {
+	def := defaultChainspecValues["foundation"]
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(def, spec); err != nil {
+		t.Fatal(err)
+	}
+	diffs, err := diffAgainst(spec, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("converted default: got %v, want no differences", diffs)
+	}
+
+	n := uint64(10)
+	if err := spec.SetEIP155Transition(&n); err != nil {
+		t.Fatal(err)
+	}
+	diffs, err = diffAgainst(spec, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"EIP155Transition": &n}; !reflect.DeepEqual(diffs, want) {
+		t.Errorf("got %v, want %v", diffs, want)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	diffOtherFlag = cli.StringFlag{
+		Name:  "other",
+		Usage: "Path to JSON chain configuration file to compare against",
+	}
+	diffOtherFormatFlag = cli.StringFlag{
+		Name:  "otherf",
+		Usage: fmt.Sprintf("Format type of the --other configuration [%s] (default: detected)", strings.Join(chainspecFormats, "|")),
+	}
+	diffOnlyForksFlag = cli.BoolFlag{
+		Name:  "only-forks",
+		Usage: "Only compare fork (transition) values, by block or timestamp",
+	}
+)
+
+var diffCommand = cli.Command{
+	Name:  "diff",
+	Usage: "Compare the configuration with another",
+	Description: `Exits 0 if the configurations are identical,
+3 if only non-consensus fields (network id, fork canon hashes, alloc) differ,
+4 if consensus fields (forks, engine, genesis header) differ.
+Exit statuses 1 and 2 are those of other commands (a failure, or invalid flags or arguments).`,
+	Flags:  []cli.Flag{diffOtherFlag, diffOtherFormatFlag, diffOnlyForksFlag},
+	Action: diff,
+}
+
+var errMissingDiffOther = errors.New("missing --other configuration")
+
+// Diff exit codes. They do not overlap the exit codes of failed commands.
+const (
+	diffIdentical    = 0
+	diffNonConsensus = 3
+	diffConsensus    = 4
+)
+
+var (
+	errDiffNonConsensus = errors.New("configurations differ in non-consensus fields")
+	errDiffConsensus    = errors.New("configurations differ in consensus fields")
+)
+
+// nonConsensusFields are the configurator fields which do not affect
+// block validity. They are named by their Get method suffixes.
+var nonConsensusFields = map[string]bool{
+	"NetworkID":       true,
+	"Bootnodes":       true,
+	"ForkCanonHashes": true,
+}
+
+// fieldDiff is a single field which differs between two configurations.
+type fieldDiff struct {
+	Name      string
+	A, B      string
+	Consensus bool
+}
+
+func (d fieldDiff) String() string {
+	category := "non-consensus"
+	if d.Consensus {
+		category = "consensus"
+	}
+	return fmt.Sprintf("[%s] %s: %s -> %s", category, d.Name, d.A, d.B)
+}
+
+// diffConfigs compares the values of all configurator getters of a and b, and their genesis accounts,
+// as confp.EqualConfigs compares them, so that differences of representation (eg. a block reward
+// schedule which one format infers from its transitions) are not reported.
+// If onlyForks is true, only transition values (by block or timestamp) are compared.
+func diffConfigs(a, b ctypes.Configurator, onlyForks bool) ([]fieldDiff, error) {
+	diffs := []fieldDiff{}
+	for _, d := range confp.Differences(a, b, func(field string) bool {
+		return !onlyForks || strings.HasSuffix(field, "Transition") || strings.HasSuffix(field, "TransitionTime")
+	}) {
+		diffs = append(diffs, fieldDiff{
+			Name:      d.Field,
+			A:         formatDiffValue(d.A),
+			B:         formatDiffValue(d.B),
+			Consensus: !nonConsensusFields[d.Field] && !strings.HasPrefix(d.Field, "GenesisAlloc."),
+		})
+	}
+	if commandContext.Err() != nil {
+		return nil, errTimeout
+	}
+	return diffs, nil
+}
+
+// formatDiffValue returns a comparable string representation of a configurator value.
+func formatDiffValue(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return "nil"
+	}
+	switch t := v.(type) {
+	case *uint64:
+		return fmt.Sprintf("%d", *t)
+	case []byte:
+		return fmt.Sprintf("0x%x", t)
+	case common.Hash:
+		return t.Hex()
+	}
+	if rv.Kind() == reflect.Map {
+		// Sort entries for a deterministic representation.
+		entries := []string{}
+		for _, key := range rv.MapKeys() {
+			entries = append(entries, formatDiffValue(key.Interface())+":"+formatDiffValue(rv.MapIndex(key).Interface()))
+		}
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, " ") + "}"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func readDiffOther(ctx *cli.Context) (ctypes.Configurator, error) {
+	if !ctx.IsSet(diffOtherFlag.Name) {
+		return nil, errMissingDiffOther
+	}
+	data, err := ioutil.ReadFile(ctx.String(diffOtherFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	if ctx.IsSet(diffOtherFormatFlag.Name) {
+		return readFormat(ctx.String(diffOtherFormatFlag.Name), bytes.NewReader(data), ctx.GlobalBool(strictFlag.Name))
+	}
+	candidates := echainspec.Detect(data)
+	if len(candidates) == 0 {
+		return nil, errNoFormatDetected
+	}
+	if ctx.GlobalBool(strictFlag.Name) {
+		return readFormat(candidates[0].Format, bytes.NewReader(data), true)
+	}
+	return candidates[0].Conf, nil
+}
+
+func diff(ctx *cli.Context) error {
+	other, err := readDiffOther(ctx)
+	if err != nil {
+		return err
+	}
+	diffs, err := diffConfigs(globalChainspecValue, other, ctx.Bool(diffOnlyForksFlag.Name))
+	if err != nil {
+		return err
+	}
+	var result error
+	for _, d := range diffs {
+		fmt.Println(d)
+		if d.Consensus {
+			result = resultError{errDiffConsensus, diffConsensus}
+		} else if result == nil {
+			result = resultError{errDiffNonConsensus, diffNonConsensus}
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	diffCode := func(a, b ctypes.Configurator, onlyForks bool) int {
+		diffs, err := diffConfigs(a, b, onlyForks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		code := diffIdentical
+		for _, d := range diffs {
+			if d.Consensus {
+				return diffConsensus
+			}
+			code = diffNonConsensus
+		}
+		return code
+	}
+
+	a, b := params.DefaultClassicGenesisBlock(), params.DefaultClassicGenesisBlock()
+	if got := diffCode(a, b, false); got != diffIdentical {
+		t.Errorf("identical: want: %d, got: %d", diffIdentical, got)
+	}
+
+	b.Alloc[common.Address{0x42}] = genesisT.GenesisAccount{Balance: big.NewInt(1)}
+	if got := diffCode(a, b, false); got != diffNonConsensus {
+		t.Errorf("alloc: want: %d, got: %d", diffNonConsensus, got)
+	}
+	if got := diffCode(a, b, true); got != diffIdentical {
+		t.Errorf("alloc, only forks: want: %d, got: %d", diffIdentical, got)
+	}
+
+	// Timestamp forks are forks. The default's chain config is shared, so is copied.
+	b = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(params.DefaultClassicGenesisBlock(), b); err != nil {
+		t.Fatal(err)
+	}
+	shanghai := uint64(1681338455)
+	if err := b.SetEIP3855TransitionTime(&shanghai); err != nil {
+		t.Fatal(err)
+	}
+	if got := diffCode(a, b, true); got != diffConsensus {
+		t.Errorf("timestamp fork, only forks: want: %d, got: %d", diffConsensus, got)
+	}
+
+	b = params.DefaultMordorGenesisBlock()
+	if got := diffCode(a, b, false); got != diffConsensus {
+		t.Errorf("forks: want: %d, got: %d", diffConsensus, got)
+	}
+	if got := diffCode(a, b, true); got != diffConsensus {
+		t.Errorf("forks, only forks: want: %d, got: %d", diffConsensus, got)
+	}
+
+	// A nil balance is a zero balance.
+	b = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(params.DefaultClassicGenesisBlock(), b); err != nil {
+		t.Fatal(err)
+	}
+	a.Alloc[common.Address{0x42}] = genesisT.GenesisAccount{Balance: new(big.Int)}
+	b.Alloc[common.Address{0x42}] = genesisT.GenesisAccount{}
+	if got := diffCode(a, b, false); got != diffIdentical {
+		t.Errorf("nil balance: want: %d, got: %d", diffIdentical, got)
+	}
+	delete(a.Alloc, common.Address{0x42})
+
+	// Schedules which a format infers from its transitions are not differences.
+	foundation := params.DefaultGenesisBlock()
+	for _, format := range []string{"multigeth", "parity"} {
+		conf, err := echainspec.Convert(foundation, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diffs, _ := diffConfigs(foundation, conf, false); len(diffs) != 0 {
+			t.Errorf("foundation %s: want no differences, got: %v", format, diffs)
+		}
+	}
+}
+
+func TestFormatDiffValue(t *testing.T) {
+	hashes := map[uint64]common.Hash{1920000: common.HexToHash("0x94365e3a8c0b35089c1d1195081fe7489b528a84b22199c916180db8b28ade7f")}
+	if got, want := formatDiffValue(hashes), "{1920000:0x94365e3a8c0b35089c1d1195081fe7489b528a84b22199c916180db8b28ade7f}"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got := formatDiffValue(map[uint64]common.Hash(nil)); got != "nil" {
+		t.Errorf("nil map: got %s, want nil", got)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	difficultyBlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Number of the block to calculate the difficulty of (decimal or 0x-prefixed hex)",
+	}
+	difficultyTimestampFlag = cli.Uint64Flag{
+		Name:  "timestamp",
+		Usage: "Timestamp of the block",
+	}
+	parentDifficultyFlag = cli.StringFlag{
+		Name:  "parent-difficulty",
+		Usage: "Difficulty of the parent block (decimal or 0x-prefixed hex)",
+	}
+	parentTimestampFlag = cli.Uint64Flag{
+		Name:  "parent-timestamp",
+		Usage: "Timestamp of the parent block",
+	}
+	parentUnclesFlag = cli.BoolFlag{
+		Name:  "parent-uncles",
+		Usage: "The parent block includes uncles (which raises the difficulty since EIP-100)",
+	}
+)
+
+var difficultyCommand = cli.Command{
+	Name:  "difficulty",
+	Usage: "Calculate the difficulty of a block from its parent, by the configured ethash rules",
+	Description: `The difficulty is calculated as ethash calculates it for the --block, given the block's --timestamp,
+and its parent's --parent-difficulty, --parent-timestamp, and (if it has any) --parent-uncles.
+The adjustment formula (Frontier, EIP-2 Homestead, or EIP-100 Byzantium) and the difficulty bomb
+(and its delays, pause, or removal) are those which the configuration activates at the block.
+The difficulty is printed in decimal. Non-ethash configurations have no difficulty calculation.`,
+	Flags:  []cli.Flag{difficultyBlockFlag, difficultyTimestampFlag, parentDifficultyFlag, parentTimestampFlag, parentUnclesFlag},
+	Action: difficulty,
+}
+
+var (
+	errMissingDifficultyFlag = errors.New("missing required flag")
+	errInvalidDifficulty     = errors.New("invalid parent difficulty")
+	errGenesisDifficulty     = errors.New("the genesis block has no parent (its difficulty is configured)")
+	errTimestampNotAfter     = errors.New("block timestamp is not after its parent's")
+	errNotEthash             = errors.New("configuration does not use ethash")
+)
+
+// parseDifficulty parses a hex (0x-prefixed) or decimal difficulty.
+func parseDifficulty(s string) (*big.Int, error) {
+	var d math.HexOrDecimal256
+	if err := d.UnmarshalText([]byte(s)); err != nil || s == "" || (*big.Int)(&d).Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q (want a positive decimal or 0x-prefixed hex number)", errInvalidDifficulty, s)
+	}
+	return (*big.Int)(&d), nil
+}
+
+// calcDifficulty returns the difficulty of block n, with the given timestamp,
+// by the ethash rules the configuration activates at n.
+func calcDifficulty(conf ctypes.ChainConfigurator, n, timestamp uint64, parentDifficulty *big.Int, parentTimestamp uint64, parentUncles bool) (*big.Int, error) {
+	if !conf.GetConsensusEngineType().IsEthash() {
+		return nil, errNotEthash
+	}
+	if n == 0 {
+		return nil, errGenesisDifficulty
+	}
+	if timestamp <= parentTimestamp {
+		return nil, fmt.Errorf("%w: %d <= %d", errTimestampNotAfter, timestamp, parentTimestamp)
+	}
+	parent := &types.Header{
+		Number:     new(big.Int).SetUint64(n - 1),
+		Time:       parentTimestamp,
+		Difficulty: parentDifficulty,
+		UncleHash:  types.EmptyUncleHash,
+	}
+	if parentUncles {
+		// Any other hash is that of a non-empty uncle list.
+		parent.UncleHash = common.Hash{}
+	}
+	return ethash.CalcDifficulty(conf, timestamp, parent), nil
+}
+
+func difficulty(ctx *cli.Context) error {
+	for _, f := range []string{difficultyBlockFlag.Name, difficultyTimestampFlag.Name, parentDifficultyFlag.Name, parentTimestampFlag.Name} {
+		if !ctx.IsSet(f) {
+			return fmt.Errorf("%w: --%s", errMissingDifficultyFlag, f)
+		}
+	}
+	n, err := parseBlockNumber(ctx.String(difficultyBlockFlag.Name))
+	if err != nil {
+		return err
+	}
+	parentDifficulty, err := parseDifficulty(ctx.String(parentDifficultyFlag.Name))
+	if err != nil {
+		return err
+	}
+	d, err := calcDifficulty(globalChainspecValue, n, ctx.Uint64(difficultyTimestampFlag.Name),
+		parentDifficulty, ctx.Uint64(parentTimestampFlag.Name), ctx.Bool(parentUnclesFlag.Name))
+	if err != nil {
+		return err
+	}
+	fmt.Println(d)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestCalcDifficulty(t *testing.T) {
+	pow2 := func(n int64) *big.Int {
+		return new(big.Int).Exp(big.NewInt(2), big.NewInt(n), nil)
+	}
+	for _, c := range []struct {
+		name             string
+		n, delta         uint64
+		parentDifficulty *big.Int
+		parentUncles     bool
+		want             *big.Int
+	}{
+		// Frontier: the parent was quick, so difficulty rises by 1/2048; the bomb is not yet felt.
+		{"foundation", 1000, 5, pow2(40), false, new(big.Int).Add(pow2(40), pow2(29))},
+		// Byzantium (EIP-100): no adjustment for a 14s block without uncles; the bomb is delayed by 3M blocks.
+		{"foundation", 5000000, 14, big.NewInt(2e15), false, big.NewInt(2e15 + 1<<18)},
+		{"foundation", 5000000, 14, big.NewInt(2e15), true, big.NewInt(2e15 + 2e15/2048 + 1<<18)},
+		// Homestead (EIP-2) adjustment, and the bomb removed by ECIP-1041.
+		{"classic", 6000000, 14, big.NewInt(2e15), false, big.NewInt(2e15)},
+	} {
+		got, err := calcDifficulty(defaultChainspecValues[c.name], c.n, 1000+c.delta, c.parentDifficulty, 1000, c.parentUncles)
+		if err != nil {
+			t.Fatalf("%s %d: %v", c.name, c.n, err)
+		}
+		if got.Cmp(c.want) != 0 {
+			t.Errorf("%s %d (uncles: %v): got %v, want %v", c.name, c.n, c.parentUncles, got, c.want)
+		}
+	}
+
+	foundation := defaultChainspecValues["foundation"]
+	if _, err := calcDifficulty(foundation, 0, 2, big.NewInt(1), 1, false); !errors.Is(err, errGenesisDifficulty) {
+		t.Errorf("genesis: want %v, got %v", errGenesisDifficulty, err)
+	}
+	if _, err := calcDifficulty(foundation, 1, 1, big.NewInt(1), 1, false); !errors.Is(err, errTimestampNotAfter) {
+		t.Errorf("timestamp: want %v, got %v", errTimestampNotAfter, err)
+	}
+	if _, err := calcDifficulty(defaultChainspecValues["goerli"], 1, 2, big.NewInt(1), 1, false); !errors.Is(err, errNotEthash) {
+		t.Errorf("clique: want %v, got %v", errNotEthash, err)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	for _, s := range []string{"131072", "0x20000"} {
+		if d, err := parseDifficulty(s); err != nil || d.Cmp(big.NewInt(131072)) != 0 {
+			t.Errorf("%s: got %v, %v", s, d, err)
+		}
+	}
+	for _, s := range []string{"", "0", "-1", "0xg"} {
+		if _, err := parseDifficulty(s); !errors.Is(err, errInvalidDifficulty) {
+			t.Errorf("%q: want %v, got %v", s, errInvalidDifficulty, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	merge161Flag = cli.BoolFlag{
+		Name:  "merge-161",
+		Usage: "Write the EIP-161 sub-parts (EIP161abc, EIP161d) as a single group, activating at the earlier of their blocks",
+	}
+	split161Flag = cli.BoolFlag{
+		Name:  "split-161",
+		Usage: "Keep the EIP-161 sub-parts at their own blocks, failing if the output format cannot configure them separately",
+	}
+)
+
+var (
+	errConflicting161  = errors.New("--merge-161 and --split-161 cannot both be given")
+	errSplit161Dropped = errors.New("output format cannot configure the EIP-161 sub-parts at different blocks")
+)
+
+// merge161 sets the EIP-161 sub-parts of a configuration to activate together,
+// at the earlier of their blocks. A configuration with neither is unchanged.
+func merge161(conf ctypes.ChainConfigurator) error {
+	abc, d := conf.GetEIP161abcTransition(), conf.GetEIP161dTransition()
+	n := abc
+	if n == nil || d != nil && *d < *n {
+		n = d
+	}
+	if n == nil {
+		return nil
+	}
+	if err := conf.SetEIP161abcTransition(n); err != nil {
+		return err
+	}
+	return conf.SetEIP161dTransition(n)
+}
+
+// checkSplit161 returns an error if the EIP-161 sub-parts of a converted configuration
+// do not activate at the blocks of the configuration it was converted from.
+func checkSplit161(from, to ctypes.ChainConfigurator) error {
+	for _, p := range []struct {
+		name     string
+		from, to *uint64
+	}{
+		{"EIP161abc", from.GetEIP161abcTransition(), to.GetEIP161abcTransition()},
+		{"EIP161d", from.GetEIP161dTransition(), to.GetEIP161dTransition()},
+	} {
+		if (p.from == nil) != (p.to == nil) || p.from != nil && *p.from != *p.to {
+			return fmt.Errorf("%w: %s: want %s, got %s", errSplit161Dropped, p.name, formatDiffValue(p.from), formatDiffValue(p.to))
+		}
+	}
+	return nil
+}
+
+// apply161Flags applies the --merge-161 flag (if set) to a configuration before its conversion.
+func apply161Flags(ctx *cli.Context, conf ctypes.ChainConfigurator) error {
+	if ctx.GlobalBool(merge161Flag.Name) && ctx.GlobalBool(split161Flag.Name) {
+		return errConflicting161
+	}
+	if ctx.GlobalBool(merge161Flag.Name) {
+		return merge161(conf)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+func TestEIP161Group(t *testing.T) {
+	u64 := func(n uint64) *uint64 { return &n }
+	newSpec := func(abc, d uint64) *parity.ParityChainSpec {
+		conf, err := echainspec.Convert(defaultChainspecValues["foundation"], "parity")
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := conf.(*parity.ParityChainSpec)
+		spec.SetEIP161abcTransition(u64(abc))
+		spec.SetEIP161dTransition(u64(d))
+		return spec
+	}
+
+	// Consistent sub-parts are valid, and convert to a format which groups them.
+	spec := newSpec(2675000, 2675000)
+	if err := confp.Validate(spec, nil); err != nil {
+		t.Errorf("consistent: %v", err)
+	}
+	out, err := echainspec.Convert(spec, "geth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSplit161(spec, out); err != nil {
+		t.Errorf("consistent: %v", err)
+	}
+
+	if split := confp.EIP161Split(spec); split != nil {
+		t.Errorf("consistent: got split %v", split)
+	}
+
+	// Split sub-parts are valid but flagged, and cannot be kept by a format which groups them.
+	spec = newSpec(2675000, 2700000)
+	if err := confp.Validate(spec, nil); err != nil {
+		t.Errorf("split: %v", err)
+	}
+	if confp.EIP161Split(spec) == nil {
+		t.Error("split: want split flagged")
+	}
+	out, err = echainspec.Convert(spec, "geth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSplit161(spec, out); !errors.Is(err, errSplit161Dropped) {
+		t.Errorf("split to geth: want %v, got %v", errSplit161Dropped, err)
+	}
+	out, err = echainspec.Convert(spec, "parity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSplit161(spec, out); err != nil {
+		t.Errorf("split to parity: %v", err)
+	}
+
+	// Merging groups them at the earlier block.
+	if err := merge161(spec); err != nil {
+		t.Fatal(err)
+	}
+	if abc, d := spec.GetEIP161abcTransition(), spec.GetEIP161dTransition(); *abc != 2675000 || *d != 2675000 {
+		t.Errorf("merged: got %d/%d, want 2675000", *abc, *d)
+	}
+	if err := confp.Validate(spec, nil); err != nil {
+		t.Errorf("merged: %v", err)
+	}
+
+	// A split in which EIP161d precedes EIP161abc is not valid.
+	spec = newSpec(2700000, 2675000)
+	if err := confp.Validate(spec, nil); err == nil {
+		t.Error("EIP161d before EIP161abc: want validation error")
+	}
+}
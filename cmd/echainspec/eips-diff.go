@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	eipsDiffAFlag = cli.StringFlag{
+		Name:  "a",
+		Usage: "Name of the default configuration to compare as A (default: the configuration, eg. from --file)",
+	}
+	eipsDiffBFlag = cli.StringFlag{
+		Name:  "b",
+		Usage: "Name of the default configuration to compare as B",
+	}
+)
+
+var eipsDiffCommand = cli.Command{
+	Name:  "eips-diff",
+	Usage: "List the IPs whose activations differ between two configurations",
+	Description: `The configurations are the defaults named by --a and --b (builtin or user defaults, see ls-defaults);
+if --a is not given, A is the configuration (eg. from --file or --default).
+Lines are formatted as '<category> <ip> <A> <B>', where the category is one of:
+  only-in-A        (A activates the IP, B never does)
+  only-in-B        (B activates the IP, A never does)
+  different-block  (both activate the IP, at different blocks)
+Lines are grouped by category, in that order, then listed by IP number.
+Unset values are printed as '-', and timestamp activations as 't=<timestamp>'.
+With --json, differences are printed as a list of objects.`,
+	Flags:  []cli.Flag{eipsDiffAFlag, eipsDiffBFlag},
+	Action: eipsDiff,
+}
+
+var errMissingEIPsDiffB = errors.New("missing --b configuration")
+
+// EIP difference categories, in the order they are listed.
+const (
+	eipOnlyInA        = "only-in-A"
+	eipOnlyInB        = "only-in-B"
+	eipDifferentBlock = "different-block"
+)
+
+var eipDiffCategoryOrder = map[string]int{
+	eipOnlyInA:        0,
+	eipOnlyInB:        1,
+	eipDifferentBlock: 2,
+}
+
+// eipDiff is an IP whose activation differs between configurations A and B.
+// A nil value means the IP is not activated by that configuration.
+type eipDiff struct {
+	Category string  `json:"category"`
+	Name     string  `json:"ip"`
+	A        *uint64 `json:"a"`
+	B        *uint64 `json:"b"`
+	Time     bool    `json:"time,omitempty"`
+}
+
+// diffEIPs returns the IPs whose activations differ between a and b, grouped by category,
+// then in IP number order.
+func diffEIPs(a, b ctypes.ChainConfigurator) []eipDiff {
+	bValues := make(map[string]*uint64)
+	for _, tr := range sortedTransitions(b, false) {
+		bValues[tr.Name] = tr.Value
+	}
+	diffs := []eipDiff{}
+	for _, tr := range sortedTransitions(a, false) {
+		av, bv := tr.Value, bValues[tr.Name]
+		var category string
+		switch {
+		case av == nil && bv == nil:
+			continue
+		case bv == nil:
+			category = eipOnlyInA
+		case av == nil:
+			category = eipOnlyInB
+		case *av != *bv:
+			category = eipDifferentBlock
+		default:
+			continue
+		}
+		diffs = append(diffs, eipDiff{Category: category, Name: tr.Name, A: av, B: bv, Time: tr.Time})
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return eipDiffCategoryOrder[diffs[i].Category] < eipDiffCategoryOrder[diffs[j].Category]
+	})
+	return diffs
+}
+
+// eipDiffValue formats an IP activation value for printing.
+func eipDiffValue(v *uint64, time bool) string {
+	switch {
+	case v == nil:
+		return "-"
+	case time:
+		return fmt.Sprintf("t=%d", *v)
+	}
+	return fmt.Sprint(*v)
+}
+
+// eipsDiffConfigs returns the A and B configurations of the eips-diff command.
+func eipsDiffConfigs(ctx *cli.Context) (ctypes.Configurator, ctypes.Configurator, error) {
+	if !ctx.IsSet(eipsDiffBFlag.Name) {
+		return nil, nil, errMissingEIPsDiffB
+	}
+	b, err := lookupDefault(ctx.String(eipsDiffBFlag.Name), userDefaultsDir(), ctx.GlobalBool(overrideDefaultsFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx.IsSet(eipsDiffAFlag.Name) {
+		a, err := lookupDefault(ctx.String(eipsDiffAFlag.Name), userDefaultsDir(), ctx.GlobalBool(overrideDefaultsFlag.Name))
+		return a, b, err
+	}
+	a, err := readChainspecValue(ctx)
+	return a, b, err
+}
+
+func eipsDiff(ctx *cli.Context) error {
+	a, b, err := eipsDiffConfigs(ctx)
+	if err != nil {
+		return err
+	}
+	diffs := diffEIPs(a, b)
+	if ctx.GlobalBool(jsonFlag.Name) {
+		return printJSON(ctx, diffs)
+	}
+	p := newStdoutPrinter(ctx)
+	for _, d := range diffs {
+		p.Print(d.Category, d.Name, eipDiffValue(d.A, d.Time), eipDiffValue(d.B, d.Time))
+	}
+	return p.Flush()
+}
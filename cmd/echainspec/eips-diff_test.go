@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffEIPs(t *testing.T) {
+	diffs := diffEIPs(defaultChainspecValues["foundation"], defaultChainspecValues["classic"])
+	byName := make(map[string]eipDiff)
+	order := make([]int, len(diffs))
+	for i, d := range diffs {
+		byName[d.Name] = d
+		order[i] = eipDiffCategoryOrder[d.Category]
+	}
+	for i := 1; i < len(order); i++ {
+		if order[i] < order[i-1] {
+			t.Fatalf("differences not grouped by category: %v", diffs)
+		}
+	}
+	cases := []struct {
+		name     string
+		category string
+		a, b     string
+	}{
+		{"EthashEIP779", eipOnlyInA, "1920000", "-"},
+		{"EthashECIP1017", eipOnlyInB, "-", "5000000"},
+		{"EIP155", eipDifferentBlock, "2675000", "3000000"},
+	}
+	for _, c := range cases {
+		d, ok := byName[c.name]
+		if !ok {
+			t.Errorf("%s: missing", c.name)
+			continue
+		}
+		if d.Category != c.category || eipDiffValue(d.A, d.Time) != c.a || eipDiffValue(d.B, d.Time) != c.b {
+			t.Errorf("%s: got %s %s %s, want %s %s %s", c.name, d.Category, eipDiffValue(d.A, d.Time), eipDiffValue(d.B, d.Time), c.category, c.a, c.b)
+		}
+	}
+	// IPs activated at the same block are not listed.
+	if d, ok := byName["EIP7"]; ok {
+		t.Errorf("EIP7: want not listed, got %+v", d)
+	}
+
+	if diffs := diffEIPs(defaultChainspecValues["classic"], defaultChainspecValues["classic"]); len(diffs) != 0 {
+		t.Errorf("identical: want no differences, got %v", diffs)
+	}
+}
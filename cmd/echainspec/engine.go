@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	outputEngineFlag = cli.StringFlag{
+		Name:  "output-engine",
+		Usage: "Override the consensus engine of the output configuration [ethash|clique]",
+	}
+	cliquePeriodFlag = cli.Uint64Flag{
+		Name:  "clique-period",
+		Usage: "Clique block period (seconds) to use with --output-engine clique",
+		Value: 15,
+	}
+	cliqueEpochFlag = cli.Uint64Flag{
+		Name:  "clique-epoch",
+		Usage: "Clique epoch length (blocks) to use with --output-engine clique",
+		Value: 30000,
+	}
+	cliqueSignersFlag = cli.StringFlag{
+		Name:  "clique-signers",
+		Usage: "Comma-separated clique signer addresses written to the genesis extra data with --output-engine clique (default: a placeholder zero address)",
+	}
+)
+
+var errInvalidOutputEngine = errors.New("invalid output consensus engine")
+var errCliqueFlagsWithoutClique = errors.New("clique flags require --output-engine clique")
+var errInvalidCliqueSigner = errors.New("invalid clique signer address")
+
+// ethashPoWFields are the PoW-specific (difficulty bomb and block reward) fields
+// which have no meaning for a non-ethash engine.
+// They are named by their GetEthash/SetEthash method suffixes.
+// ECIP1010Continue must precede ECIP1010Pause because it is derived from it.
+var ethashPoWFields = []string{
+	"EIP100BTransition",
+	"EIP649Transition",
+	"EIP1234Transition",
+	"EIP2384Transition",
+	"EIP3554Transition",
+	"ECIP1010ContinueTransition",
+	"ECIP1010PauseTransition",
+	"ECIP1017Transition",
+	"ECIP1017EraRounds",
+	"ECIP1041Transition",
+}
+
+func parseConsensusEngine(s string) (ctypes.ConsensusEngineT, error) {
+	switch strings.ToLower(s) {
+	case "ethash":
+		return ctypes.ConsensusEngineT_Ethash, nil
+	case "clique":
+		return ctypes.ConsensusEngineT_Clique, nil
+	}
+	return ctypes.ConsensusEngineT_Unknown, fmt.Errorf("%w: %s", errInvalidOutputEngine, s)
+}
+
+// parseCliqueSigners parses a comma-separated list of signer addresses.
+func parseCliqueSigners(s string) ([]common.Address, error) {
+	var signers []common.Address
+	for _, a := range strings.Split(s, ",") {
+		a = strings.TrimSpace(a)
+		if !common.IsHexAddress(a) {
+			return nil, fmt.Errorf("%w: %q", errInvalidCliqueSigner, a)
+		}
+		signers = append(signers, common.HexToAddress(a))
+	}
+	return signers, nil
+}
+
+// cliqueExtraData returns clique genesis extra data: the vanity (truncated or zero-padded to 32 bytes),
+// the signers, or a placeholder zero address if there are none, and an empty seal.
+func cliqueExtraData(vanity []byte, signers []common.Address) []byte {
+	if len(signers) == 0 {
+		signers = []common.Address{{}}
+	}
+	extra := make([]byte, confp.CliqueExtraVanity, confp.CliqueExtraVanity+len(signers)*common.AddressLength+confp.CliqueExtraSeal)
+	copy(extra, vanity)
+	for _, s := range signers {
+		extra = append(extra, s.Bytes()...)
+	}
+	return append(extra, make([]byte, confp.CliqueExtraSeal)...)
+}
+
+// setConsensusEngine switches the consensus engine of a configuration.
+// When switching away from ethash, PoW-specific fields are cleared, and each
+// cleared field is returned as a warning.
+// Period, epoch, and signers are only used for clique. When switching to clique (or given signers),
+// the genesis extra data is replaced with the signer list, and the genesis difficulty set to 1.
+func setConsensusEngine(conf ctypes.ChainConfigurator, engine ctypes.ConsensusEngineT, period, epoch uint64, signers []common.Address) (warnings []string, err error) {
+	current := conf.GetConsensusEngineType()
+	if current != engine {
+		warnings = append(warnings, fmt.Sprintf("consensus engine changed: %s -> %s", current, engine))
+	}
+	if current.IsEthash() && !engine.IsEthash() {
+		for _, name := range ethashPoWFields {
+			v := reflectGet(conf, "GetEthash"+name)
+			if v == nil {
+				continue
+			}
+			// Some formats derive these fields from fork blocks which also
+			// govern other protocol transitions (eg. geth's byzantiumBlock).
+			// These fields are kept, since clearing them would disable the others.
+			before := protocolTransitions(conf)
+			if err := reflectSet(conf, "SetEthash"+name, nil); ctypes.IsFatalUnsupportedErr(err) {
+				return warnings, ctypes.UnsupportedConfigError(err, name, *v)
+			}
+			if !reflect.DeepEqual(before, protocolTransitions(conf)) {
+				if err := reflectSet(conf, "SetEthash"+name, v); ctypes.IsFatalUnsupportedErr(err) {
+					return warnings, ctypes.UnsupportedConfigError(err, name, *v)
+				}
+				warnings = append(warnings, fmt.Sprintf("kept ethash field %s (value: %d), it is shared with other transitions in this format", name, *v))
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("cleared ethash field %s (was: %d)", name, *v))
+		}
+		if len(conf.GetEthashDifficultyBombDelaySchedule()) > 0 {
+			warnings = append(warnings, "cleared ethash difficulty bomb delay schedule")
+			if err := conf.SetEthashDifficultyBombDelaySchedule(nil); ctypes.IsFatalUnsupportedErr(err) {
+				return warnings, err
+			}
+		}
+		if len(conf.GetEthashBlockRewardSchedule()) > 0 {
+			warnings = append(warnings, "cleared ethash block reward schedule")
+			if err := conf.SetEthashBlockRewardSchedule(nil); ctypes.IsFatalUnsupportedErr(err) {
+				return warnings, err
+			}
+		}
+	}
+	if current.IsClique() && !engine.IsClique() {
+		warnings = append(warnings, fmt.Sprintf("dropped clique parameters (period: %d, epoch: %d)", conf.GetCliquePeriod(), conf.GetCliqueEpoch()))
+	}
+	if err := conf.MustSetConsensusEngineType(engine); err != nil {
+		return warnings, ctypes.UnsupportedConfigError(err, "consensus engine", engine)
+	}
+	if engine.IsClique() {
+		if err := conf.SetCliquePeriod(period); err != nil {
+			return warnings, ctypes.UnsupportedConfigError(err, "clique period", period)
+		}
+		if err := conf.SetCliqueEpoch(epoch); err != nil {
+			return warnings, ctypes.UnsupportedConfigError(err, "clique epoch", epoch)
+		}
+		gen, ok := conf.(ctypes.GenesisBlocker)
+		if ok && (!current.IsClique() || len(signers) > 0) {
+			extra := gen.GetGenesisExtraData()
+			if err := gen.SetGenesisExtraData(cliqueExtraData(extra, signers)); err != nil {
+				return warnings, err
+			}
+			warnings = append(warnings, fmt.Sprintf("replaced genesis extra data (was: %#x) with the clique signer list", extra))
+			if len(signers) == 0 {
+				warnings = append(warnings, "replace the placeholder signer (zero address) in extraData, or use --clique-signers")
+			}
+			if d := gen.GetGenesisDifficulty(); d == nil || d.Cmp(big.NewInt(1)) != 0 {
+				if err := gen.SetGenesisDifficulty(big.NewInt(1)); err != nil {
+					return warnings, err
+				}
+				warnings = append(warnings, fmt.Sprintf("genesis difficulty changed: %v -> 1", d))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// protocolTransitions returns the values of all non-engine-specific transitions.
+func protocolTransitions(conf ctypes.ChainConfigurator) map[string]*uint64 {
+	m := make(map[string]*uint64)
+	fns, names := confp.Transitions(conf)
+	for i, fn := range fns {
+		if strings.HasPrefix(names[i], "GetEthash") {
+			continue
+		}
+		m[names[i]] = fn()
+	}
+	return m
+}
+
+// overrideConsensusEngine applies the --output-engine flag (if set) to the given configuration.
+func overrideConsensusEngine(ctx *cli.Context, conf ctypes.ChainConfigurator) error {
+	cliqueFlags := ctx.GlobalIsSet(cliquePeriodFlag.Name) || ctx.GlobalIsSet(cliqueEpochFlag.Name) || ctx.GlobalIsSet(cliqueSignersFlag.Name)
+	if !ctx.GlobalIsSet(outputEngineFlag.Name) {
+		if cliqueFlags {
+			return errCliqueFlagsWithoutClique
+		}
+		return nil
+	}
+	engine, err := parseConsensusEngine(ctx.GlobalString(outputEngineFlag.Name))
+	if err != nil {
+		return err
+	}
+	if !engine.IsClique() && cliqueFlags {
+		return errCliqueFlagsWithoutClique
+	}
+	var signers []common.Address
+	if ctx.GlobalIsSet(cliqueSignersFlag.Name) {
+		if signers, err = parseCliqueSigners(ctx.GlobalString(cliqueSignersFlag.Name)); err != nil {
+			return err
+		}
+	}
+	warnings, err := setConsensusEngine(conf, engine, ctx.GlobalUint64(cliquePeriodFlag.Name), ctx.GlobalUint64(cliqueEpochFlag.Name), signers)
+	for _, w := range warnings {
+		log.Println("warning:", w)
+	}
+	return err
+}
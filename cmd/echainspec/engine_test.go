@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+// TestSetConsensusEngineEthashToClique tests deriving a clique dev chain
+// configuration from the (ethash) Ethereum mainnet configuration.
+func TestSetConsensusEngineEthashToClique(t *testing.T) {
+	for _, conf := range []ctypes.Configurator{
+		&genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}},
+		&genesisT.Genesis{Config: &goethereum.ChainConfig{}},
+	} {
+		if err := confp.Convert(params.DefaultGenesisBlock(), conf); err != nil {
+			t.Fatal(err)
+		}
+		if got := conf.GetConsensusEngineType(); !got.IsEthash() {
+			t.Fatalf("want ethash, got: %s", got)
+		}
+		transitions := protocolTransitions(conf)
+
+		signer := common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+		warnings, err := setConsensusEngine(conf, ctypes.ConsensusEngineT_Clique, 0, 30000, []common.Address{signer})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(warnings) == 0 {
+			t.Error("want warnings for engine change")
+		}
+		if got := conf.GetConsensusEngineType(); !got.IsClique() {
+			t.Errorf("want clique, got: %s", got)
+		}
+		if conf.GetCliquePeriod() != 0 || conf.GetCliqueEpoch() != 30000 {
+			t.Errorf("wrong clique params, period: %d, epoch: %d", conf.GetCliquePeriod(), conf.GetCliqueEpoch())
+		}
+		if len(conf.GetEthashDifficultyBombDelaySchedule()) != 0 {
+			t.Error("difficulty bomb delay schedule not cleared")
+		}
+		if !reflect.DeepEqual(transitions, protocolTransitions(conf)) {
+			t.Error("protocol transitions not preserved")
+		}
+		// The genesis is a valid clique genesis, signed by the signer.
+		gen := conf.(*genesisT.Genesis)
+		if extra := gen.GetGenesisExtraData(); len(extra) != 32+20+65 || common.BytesToAddress(extra[32:52]) != signer {
+			t.Errorf("wrong clique extra data: %x", extra)
+		}
+		if d := gen.GetGenesisDifficulty(); d.Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("wrong genesis difficulty, want: 1, got: %v", d)
+		}
+		if err := confp.Validate(conf, nil); err != nil {
+			t.Errorf("clique genesis invalid: %v", err)
+		}
+		// The multigeth format stores the difficulty bomb fields independently.
+		if _, ok := conf.(*genesisT.Genesis).Config.(*multigeth.MultiGethChainConfig); !ok {
+			continue
+		}
+		for _, name := range ethashPoWFields {
+			if v := reflectGet(conf, "GetEthash"+name); v != nil {
+				t.Errorf("%s not cleared: %d", name, *v)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Exit codes of failed commands.
+// Commands which report their result by exit code (eg. validate, diff) document their own.
+const (
+	failureExitCode = 1 // The configuration is invalid, or could not be read or processed.
+	usageExitCode   = 2 // The flags or arguments are invalid.
+)
+
+// usageError is an error parsing the command line.
+type usageError struct {
+	error
+}
+
+func (e usageError) Unwrap() error {
+	return e.error
+}
+
+// resultError is the error of a command which reports its result by exit code (eg. diff),
+// which exits with its own code, distinct from the codes above.
+type resultError struct {
+	error
+	code int
+}
+
+func (e resultError) Unwrap() error {
+	return e.error
+}
+
+// onUsageError wraps command line parsing errors, so that they exit with the usage exit code.
+func onUsageError(ctx *cli.Context, err error, isSubcommand bool) error {
+	return usageError{err}
+}
+
+// usageErrors are errors caused by invalid flags or arguments, rather than by the configuration.
+var usageErrors = []error{
+	errInvalidOutputFlag,
+	errNoChainspecValue,
+	errInvalidDefaultValue,
+	errInvalidOutputCompat,
+	errInvalidOutputEngine,
+	errCliqueFlagsWithoutClique,
+	errInvalidCliqueSigner,
+	errInvalidAllocKeyFormat,
+	errUnknownClientVersion,
+	errInvalidIndent,
+	errMissingDifficultyFlag,
+	errInvalidDifficulty,
+	errGenesisDifficulty,
+	errTimestampNotAfter,
+	errInvalidOutputSerialization,
+	errOutFileExists,
+	errNDJSONCommand,
+	errExplainNDJSON,
+	errConflicting161,
+	errMissingDiffOther,
+	errMissingEIPsDiffB,
+	errInvalidConcurrency,
+	errShowDefaultsSerialization,
+	errInvalidSelectPath,
+	errSelectSerialization,
+	errMissingOverlay,
+	errMissingBatchDir,
+	errMissingBatchOut,
+	errUnknownSchema,
+	errMissingBlockArg,
+	errInvalidBlockArg,
+	errMissingHashArg,
+	errInvalidHashArg,
+	errUnknownIP,
+	errMissingIPArg,
+	errMissingFormatArg,
+	errTimeIPAtBlock,
+	errInvalidTemplate,
+	errInsecureURL,
+	errConflictingFile,
+	errConflictingRPC,
+	echainspec.ErrUnknownFormat,
+	os.ErrNotExist, // eg. a missing --file
+}
+
+// exitCode returns the exit code for a failed command's error.
+func exitCode(err error) int {
+	if errors.Is(err, errTimeout) {
+		return timeoutExitCode
+	}
+	var rerr resultError
+	if errors.As(err, &rerr) {
+		return rerr.code
+	}
+	var uerr usageError
+	if errors.As(err, &uerr) {
+		return usageExitCode
+	}
+	for _, e := range usageErrors {
+		if errors.Is(err, e) {
+			return usageExitCode
+		}
+	}
+	return failureExitCode
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	for _, c := range []struct {
+		err  error
+		want int
+	}{
+		{errors.New("invalid configuration"), failureExitCode},
+		{errNoFormatDetected, failureExitCode},
+		{errTimeout, timeoutExitCode},
+		{errInvalidOutputFlag, usageExitCode},
+		{fmt.Errorf("%w: foo", errInvalidOutputEngine), usageExitCode},
+		{&os.PathError{Op: "open", Path: "missing.json", Err: os.ErrNotExist}, usageExitCode},
+		{usageError{errors.New("flag provided but not defined: -foo")}, usageExitCode},
+		{resultError{errDiffNonConsensus, diffNonConsensus}, diffNonConsensus},
+		{resultError{errDiffConsensus, diffConsensus}, diffConsensus},
+		{resultError{errNotSupported, notSupportedExitCode}, notSupportedExitCode},
+	} {
+		if got := exitCode(c.err); got != c.want {
+			t.Errorf("%v: got %d, want %d", c.err, got, c.want)
+		}
+	}
+	if _, err := parseBlockNumber("0xzz"); exitCode(err) != usageExitCode {
+		t.Errorf("invalid block number: got %d, want %d", exitCode(err), usageExitCode)
+	}
+}
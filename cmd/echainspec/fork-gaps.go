@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var forkGapsCommand = cli.Command{
+	Name:        "fork-gaps",
+	Usage:       "List block gaps between consecutive forks, flagging anomalies",
+	Description: "Gaps which are zero, or larger than 3x the median gap, are flagged.",
+	Action:      forkGaps,
+}
+
+// largeForkGapFactor is the multiple of the median gap at which a gap is considered suspiciously large.
+const largeForkGapFactor = 3
+
+// forkGap is the distance between two consecutive fork blocks.
+type forkGap struct {
+	From, To uint64
+	Anomaly  string
+}
+
+func (g forkGap) Gap() uint64 {
+	return g.To - g.From
+}
+
+// forkGapsFor returns the gaps between consecutive forks, beginning at genesis,
+// and flags those which are zero or unusually large.
+func forkGapsFor(forks []uint64) []forkGap {
+	gaps := []forkGap{}
+	prev := uint64(0)
+	for _, f := range forks {
+		gaps = append(gaps, forkGap{From: prev, To: f})
+		prev = f
+	}
+	if len(gaps) == 0 {
+		return gaps
+	}
+	sizes := make([]uint64, len(gaps))
+	for i, g := range gaps {
+		sizes[i] = g.Gap()
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i] < sizes[j]
+	})
+	median := sizes[len(sizes)/2]
+	for i, g := range gaps {
+		switch {
+		case g.Gap() == 0:
+			gaps[i].Anomaly = "zero gap"
+		case len(gaps) > 2 && g.Gap() > largeForkGapFactor*median:
+			gaps[i].Anomaly = fmt.Sprintf("large gap, %.1fx median", float64(g.Gap())/float64(median))
+		}
+	}
+	return gaps
+}
+
+func forkGaps(ctx *cli.Context) error {
+	for _, g := range forkGapsFor(confp.ForkBlocks(globalChainspecValue)) {
+		line := fmt.Sprintf("%d -> %d: %d", g.From, g.To, g.Gap())
+		if g.Anomaly != "" {
+			line += fmt.Sprintf(" (%s)", g.Anomaly)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestForkGapsFor(t *testing.T) {
+	gaps := forkGapsFor([]uint64{100, 200, 200, 300, 5000})
+	want := []struct {
+		gap     uint64
+		anomaly bool
+	}{
+		{100, false},
+		{100, false},
+		{0, true},
+		{100, false},
+		{4700, true},
+	}
+	if len(gaps) != len(want) {
+		t.Fatalf("want %d gaps, got: %d", len(want), len(gaps))
+	}
+	for i, w := range want {
+		if gaps[i].Gap() != w.gap {
+			t.Errorf("gap %d: want: %d, got: %d", i, w.gap, gaps[i].Gap())
+		}
+		if (gaps[i].Anomaly != "") != w.anomaly {
+			t.Errorf("gap %d: want anomaly: %v, got: %q", i, w.anomaly, gaps[i].Anomaly)
+		}
+	}
+}
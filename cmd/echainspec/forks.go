@@ -2,20 +2,234 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"gopkg.in/urfave/cli.v1"
 )
 
+var forksNamedFlag = cli.BoolFlag{
+	Name:  "named",
+	Usage: "Prefix each fork number with the name(s) of the hard fork(s) completed at that block",
+}
+
+var (
+	forksNextFlag = cli.StringFlag{
+		Name:  "next",
+		Usage: "Print only the next fork after the given block (decimal or 0x-prefixed hex), with its name(s)",
+	}
+	forksTimeFlag = cli.Uint64Flag{
+		Name:  "time",
+		Usage: "Timestamp after which timestamp forks are next, with --next (default: now)",
+	}
+)
+
 var forksCommand = cli.Command{
-	Name:   "forks",
-	Usage:  "List unique and non-zero fork numbers",
+	Name:  "forks",
+	Usage: "List unique and non-zero fork numbers",
+	Description: `Forks activated by timestamp rather than block number (eg. Shanghai) are listed
+after block forks, marked 't=<timestamp>'.
+
+With --named, lines are formatted as '<name>[,<name>...] <block>'.
+A hard fork is named at the block where the last of its EIPs activates.
+Blocks which complete no known hard fork are named '-'.
+
+With --json, forks are printed as an array of {"block": <block>, "eips": [<name>...]} objects,
+listing the IPs which activate at each fork block. Timestamp forks have "time" instead of "block".
+
+With --next <block>, only the first fork after the block is printed, as '<name>[,<name>...] <block>',
+or 'no further forks'. Forks are named by the hard forks they complete, or otherwise by their IPs.
+As in fork IDs, timestamp forks follow all block forks: once there are no further block forks,
+the next is the first timestamp fork after --time, eg. 'shanghai t=1681338455'.
+With --json, the next fork is printed as one object, or null.`,
+	Flags:  []cli.Flag{forksNamedFlag, forksNextFlag, forksTimeFlag},
 	Action: forks,
 }
 
-func forks(ctx *cli.Context) error {
-	for _, f := range confp.Forks(globalChainspecValue) {
-		fmt.Println(f)
+// namedFork is a hard fork, identified by the transitions (named by their Get
+// method infixes) which it activates.
+// Transitions specific to the ethash difficulty and reward schedules are omitted
+// where chains have applied them separately (eg. Ethereum Classic).
+type namedFork struct {
+	Name        string
+	Transitions []string
+}
+
+var namedForks = []namedFork{
+	{"homestead", []string{"EIP7"}},
+	{"dao", []string{"EthashEIP779"}},
+	{"tangerineWhistle", []string{"EIP150"}},
+	{"dieHard", []string{"EthashECIP1010Pause"}},
+	{"spuriousDragon", []string{"EIP155", "EIP160", "EIP161abc", "EIP161d", "EIP170"}},
+	{"gotham", []string{"EthashECIP1017"}},
+	{"defuseDifficultyBomb", []string{"EthashECIP1041"}},
+	{"byzantium", []string{"EIP140", "EIP198", "EIP211", "EIP212", "EIP213", "EIP214", "EIP658"}},
+	{"constantinople", []string{"EIP145", "EIP1014", "EIP1052"}},
+	{"petersburg", []string{"EIP1283Disable"}},
+	{"istanbul", []string{"EIP152", "EIP1108", "EIP1344", "EIP1884", "EIP2028", "EIP2200"}},
+	{"muirGlacier", []string{"EthashEIP2384"}},
+	{"berlin", []string{"EIP2565", "EIP2718", "EIP2929", "EIP2930"}},
+	{"london", []string{"EIP1559", "EIP3529", "EIP3541"}},
+	{"ewasm", []string{"EWASM"}},
+	// Shanghai and Cancun are activated by timestamp, so have no fork block; see forkTimeNames.
+	{"shanghai", []string{"EIP3651Time", "EIP3855Time", "EIP3860Time", "EIP4895Time"}},
+	{"cancun", []string{"EIP1153Time", "EIP4788Time", "EIP4844Time", "EIP5656Time", "EIP6780Time", "EIP7516Time"}},
+}
+
+// forkNames returns the names of the hard forks completed at each fork block.
+func forkNames(conf ctypes.ChainConfigurator) map[uint64][]string {
+	return namedForksAt(activations(conf, false))
+}
+
+// forkTimeNames returns the names of the hard forks completed at each fork timestamp.
+func forkTimeNames(conf ctypes.ChainConfigurator) map[uint64][]string {
+	return namedForksAt(activations(conf, true))
+}
+
+// activations returns the block, or timestamp, transition values of a configuration by name.
+func activations(conf ctypes.ChainConfigurator, times bool) map[string]*uint64 {
+	values := confp.EIPActivations(conf)
+	for name := range values {
+		if confp.IsTimeActivation(name) != times {
+			delete(values, name)
+		}
+	}
+	return values
+}
+
+// namedForksAt returns the names of the hard forks whose transitions all have values,
+// by the greatest of those values.
+func namedForksAt(values map[string]*uint64) map[uint64][]string {
+	m := make(map[uint64][]string)
+outer:
+	for _, f := range namedForks {
+		var last uint64
+		for _, t := range f.Transitions {
+			v := values[t]
+			if v == nil {
+				continue outer
+			}
+			if *v > last {
+				last = *v
+			}
+		}
+		m[last] = append(m[last], f.Name)
+	}
+	return m
+}
+
+// forkIPs is a fork block, or fork timestamp, with the names of the IPs which activate at it.
+type forkIPs struct {
+	Block *uint64  `json:"block,omitempty"`
+	Time  *uint64  `json:"time,omitempty"`
+	EIPs  []string `json:"eips"`
+}
+
+// forksWithIPs returns the fork blocks and then fork timestamps of a configuration,
+// each with the IPs activated at it.
+func forksWithIPs(conf ctypes.ChainConfigurator) []forkIPs {
+	byBlock := make(map[uint64][]string)
+	byTime := make(map[uint64][]string)
+	for _, tr := range sortedTransitions(conf, false) {
+		if tr.Value == nil {
+			continue
+		}
+		if tr.Time {
+			byTime[*tr.Value] = append(byTime[*tr.Value], tr.Name)
+		} else {
+			byBlock[*tr.Value] = append(byBlock[*tr.Value], tr.Name)
+		}
+	}
+	out := []forkIPs{}
+	for _, f := range confp.ForkBlocks(conf) {
+		f := f
+		out = append(out, forkIPs{Block: &f, EIPs: byBlock[f]})
+	}
+	for _, f := range confp.ForkTimes(conf) {
+		f := f
+		out = append(out, forkIPs{Time: &f, EIPs: byTime[f]})
+	}
+	return out
+}
+
+// nextFork returns the first fork after block n, or if there are no further block forks,
+// the first timestamp fork after time t. Nil is returned if there are no further forks.
+func nextFork(conf ctypes.ChainConfigurator, n, t uint64) *forkIPs {
+	for _, f := range forksWithIPs(conf) {
+		if (f.Block != nil && *f.Block > n) || (f.Time != nil && *f.Time > t) {
+			return &f
+		}
 	}
 	return nil
 }
+
+// nextForkLine formats the next fork as '<name>[,<name>...] <block>' (or 't=<timestamp>'),
+// naming it by the hard forks it completes, or otherwise by its IPs.
+func nextForkLine(conf ctypes.ChainConfigurator, f *forkIPs) string {
+	if f == nil {
+		return "no further forks"
+	}
+	var names []string
+	var at string
+	if f.Block != nil {
+		names, at = forkNames(conf)[*f.Block], fmt.Sprint(*f.Block)
+	} else {
+		names, at = forkTimeNames(conf)[*f.Time], fmt.Sprintf("t=%d", *f.Time)
+	}
+	if len(names) == 0 {
+		names = f.EIPs
+	}
+	return strings.Join(names, ",") + " " + at
+}
+
+func forksNext(ctx *cli.Context) error {
+	n, err := parseBlockNumber(ctx.String(forksNextFlag.Name))
+	if err != nil {
+		return err
+	}
+	t := uint64(time.Now().Unix())
+	if ctx.IsSet(forksTimeFlag.Name) {
+		t = ctx.Uint64(forksTimeFlag.Name)
+	}
+	f := nextFork(globalChainspecValue, n, t)
+	if ctx.GlobalBool(jsonFlag.Name) {
+		return printJSON(ctx, f)
+	}
+	fmt.Println(nextForkLine(globalChainspecValue, f))
+	return nil
+}
+
+func forks(ctx *cli.Context) error {
+	if ctx.IsSet(forksNextFlag.Name) {
+		return forksNext(ctx)
+	}
+	if ctx.GlobalBool(jsonFlag.Name) {
+		return printJSON(ctx, forksWithIPs(globalChainspecValue))
+	}
+	var names, timeNames map[uint64][]string
+	if ctx.Bool(forksNamedFlag.Name) {
+		names = forkNames(globalChainspecValue)
+		timeNames = forkTimeNames(globalChainspecValue)
+	}
+	p := newStdoutPrinter(ctx)
+	printFork := func(f interface{}, names []string) {
+		if !ctx.Bool(forksNamedFlag.Name) {
+			p.Print(f)
+			return
+		}
+		name := "-"
+		if len(names) > 0 {
+			name = strings.Join(names, ",")
+		}
+		p.PrintNamed(name, f)
+	}
+	for _, f := range confp.ForkBlocks(globalChainspecValue) {
+		printFork(f, names[f])
+	}
+	for _, f := range confp.ForkTimes(globalChainspecValue) {
+		printFork(fmt.Sprintf("t=%d", f), timeNames[f])
+	}
+	return p.Flush()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestForkNames(t *testing.T) {
+	names := forkNames(defaultChainspecValues["foundation"])
+	for block, want := range map[uint64][]string{
+		1150000: {"homestead"},
+		1920000: {"dao"},
+		2463000: {"tangerineWhistle"},
+		7280000: {"constantinople", "petersburg"},
+	} {
+		if got := names[block]; !reflect.DeepEqual(got, want) {
+			t.Errorf("block %d: got %v, want %v", block, got, want)
+		}
+	}
+
+	// Classic's Atlantis completes Spurious Dragon, whose EIP155 and EIP160 activated at Die Hard.
+	names = forkNames(defaultChainspecValues["classic"])
+	if got, want := names[3000000], []string{"dieHard"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("block 3000000: got %v, want %v", got, want)
+	}
+	if got, want := names[8772000], []string{"spuriousDragon", "byzantium"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("block 8772000: got %v, want %v", got, want)
+	}
+
+	// Test chains may enable eWASM.
+	data, err := ioutil.ReadFile(filepath.Join("..", "..", "params", "confp", "testdata", "parity_wasm.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := readChainspec("parity", data, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := forkNames(conf)[60000], []string{"ewasm"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("block 60000: got %v, want %v", got, want)
+	}
+}
+
+func TestForksWithIPs(t *testing.T) {
+	fs := forksWithIPs(defaultChainspecValues["foundation"])
+	if len(fs) == 0 || fs[0].Block == nil || *fs[0].Block != 1150000 {
+		t.Fatalf("got %v, want first fork at 1150000", fs)
+	}
+	if want := []string{"EthashEIP2", "EIP7", "EthashHomestead"}; !reflect.DeepEqual(fs[0].EIPs, want) {
+		t.Errorf("block 1150000: got %v, want %v", fs[0].EIPs, want)
+	}
+	for _, f := range fs {
+		if len(f.EIPs) == 0 {
+			t.Errorf("block %d: no IPs", *f.Block)
+		}
+	}
+}
+
+func TestForkTimes(t *testing.T) {
+	conf := readMergeTestConfig(t, "geth", []byte(`{
+		"config": {"chainId": 1, "berlinBlock": 10, "londonBlock": 20, "shanghaiTime": 1681338455, "ethash": {}}
+	}`))
+	if got, want := forkTimeNames(conf), map[uint64][]string{1681338455: {"shanghai"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fork time names: got %v, want %v", got, want)
+	}
+	// Timestamps are not conflated with blocks.
+	if names := forkNames(conf); len(names[1681338455]) != 0 {
+		t.Errorf("fork names at timestamp: got %v, want none", names[1681338455])
+	}
+	fs := forksWithIPs(conf)
+	if len(fs) != 3 {
+		t.Fatalf("got %d forks, want 3", len(fs))
+	}
+	last := fs[2]
+	if last.Block != nil || last.Time == nil || *last.Time != 1681338455 {
+		t.Errorf("got block %v time %v, want time 1681338455", last.Block, last.Time)
+	}
+	if want := []string{"EIP3651Time", "EIP3855Time", "EIP3860Time", "EIP4895Time"}; !reflect.DeepEqual(last.EIPs, want) {
+		t.Errorf("time fork IPs: got %v, want %v", last.EIPs, want)
+	}
+
+	conf = readMergeTestConfig(t, "geth", []byte(`{
+		"config": {"chainId": 1, "londonBlock": 20, "shanghaiTime": 1681338455, "cancunTime": 1710338135, "ethash": {}}
+	}`))
+	if got, want := forkTimeNames(conf), map[uint64][]string{1681338455: {"shanghai"}, 1710338135: {"cancun"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fork time names: got %v, want %v", got, want)
+	}
+}
+
+func TestNextFork(t *testing.T) {
+	foundation := defaultChainspecValues["foundation"]
+	for n, want := range map[uint64]string{
+		0:        "homestead 1150000",
+		1150000:  "dao 1920000",
+		7279999:  "constantinople,petersburg 7280000",
+		99999999: "no further forks",
+	} {
+		if got := nextForkLine(foundation, nextFork(foundation, n, 0)); got != want {
+			t.Errorf("block %d: got %q, want %q", n, got, want)
+		}
+	}
+
+	// Timestamp forks follow the block forks; forks which complete no hard fork are named by their IPs.
+	conf := readMergeTestConfig(t, "geth", []byte(`{
+		"config": {"chainId": 1, "eip155Block": 5, "berlinBlock": 10, "londonBlock": 20, "shanghaiTime": 1681338455, "ethash": {}}
+	}`))
+	for _, c := range []struct {
+		n, t uint64
+		want string
+	}{
+		{0, 0, "EIP155 5"},
+		{5, 1681338455, "berlin 10"},
+		{20, 1681338454, "shanghai t=1681338455"},
+		{20, 1681338455, "no further forks"},
+	} {
+		if got := nextForkLine(conf, nextFork(conf, c.n, c.t)); got != c.want {
+			t.Errorf("block %d, time %d: got %q, want %q", c.n, c.t, got, c.want)
+		}
+	}
+}
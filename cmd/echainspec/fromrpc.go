@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/rpc"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var fromRPCFlag = cli.StringFlag{
+	Name:  "from-rpc",
+	Usage: "URL of a node's JSON-RPC endpoint to reconstruct a best-effort multigeth configuration from (chain ID and genesis block)",
+}
+
+var (
+	errConflictingRPC  = errors.New("--from-rpc cannot be used with --file or --from-url")
+	errMissingRPCBlock = errors.New("node has no genesis block")
+)
+
+// rpcGenesisBlock holds the fields of an eth_getBlockByNumber result which are genesis values.
+type rpcGenesisBlock struct {
+	Hash       common.Hash      `json:"hash"`
+	Nonce      types.BlockNonce `json:"nonce"`
+	Timestamp  hexutil.Uint64   `json:"timestamp"`
+	ExtraData  hexutil.Bytes    `json:"extraData"`
+	GasLimit   hexutil.Uint64   `json:"gasLimit"`
+	Difficulty *hexutil.Big     `json:"difficulty"`
+	MixHash    common.Hash      `json:"mixHash"`
+	Coinbase   common.Address   `json:"miner"`
+}
+
+// readRPCGenesis assembles a multigeth configuration from the chain ID and genesis block of a node.
+// The genesis hash is kept as the required hash of block 0.
+// Fork blocks, genesis accounts, and the consensus engine cannot be recovered over RPC;
+// forks are left unset, accounts empty, and the engine is assumed to be ethash.
+func readRPCGenesis(ctx context.Context, endpoint string) (ctypes.Configurator, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	var chainID hexutil.Big
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return nil, rpcError(ctx, err)
+	}
+	var block *rpcGenesisBlock
+	if err := client.CallContext(ctx, &block, "eth_getBlockByNumber", "0x0", false); err != nil {
+		return nil, rpcError(ctx, err)
+	}
+	if block == nil {
+		return nil, errMissingRPCBlock
+	}
+	conf := &genesisT.Genesis{
+		Config: &multigeth.MultiGethChainConfig{
+			NetworkID: chainID.ToInt().Uint64(),
+			ChainID:   chainID.ToInt(),
+			Ethash:    new(ctypes.EthashConfig),
+		},
+		Nonce:      block.Nonce.Uint64(),
+		Timestamp:  uint64(block.Timestamp),
+		ExtraData:  block.ExtraData,
+		GasLimit:   uint64(block.GasLimit),
+		Difficulty: new(big.Int),
+		Mixhash:    block.MixHash,
+		Coinbase:   block.Coinbase,
+		Alloc:      genesisT.GenesisAlloc{},
+	}
+	if block.Difficulty != nil {
+		conf.Difficulty = block.Difficulty.ToInt()
+	}
+	if err := conf.SetForkCanonHash(0, block.Hash); err != nil {
+		return nil, err
+	}
+	log.Println("note: fork blocks cannot be recovered over RPC, and are unset")
+	log.Println("note: genesis accounts cannot be recovered over RPC, so the genesis hash will differ unless there were none")
+	log.Println("note: the network id is assumed to be the chain id, and the consensus engine ethash (use --output-engine clique for proof-of-authority chains)")
+	return conf, nil
+}
+
+// rpcError returns errTimeout if the command context is done, or else the error.
+func rpcError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return errTimeout
+	}
+	return err
+}
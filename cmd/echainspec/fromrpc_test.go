@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testEthService serves the chain ID and genesis block of a node.
+type testEthService struct {
+	chainID *big.Int
+	genesis *types.Header
+}
+
+func (s *testEthService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(s.chainID)
+}
+
+func (s *testEthService) GetBlockByNumber(n rpc.BlockNumber, fullTx bool) map[string]interface{} {
+	if n != 0 {
+		return nil
+	}
+	h := s.genesis
+	return map[string]interface{}{
+		"hash":       h.Hash(),
+		"number":     (*hexutil.Big)(h.Number),
+		"nonce":      h.Nonce,
+		"timestamp":  hexutil.Uint64(h.Time),
+		"extraData":  hexutil.Bytes(h.Extra),
+		"gasLimit":   hexutil.Uint64(h.GasLimit),
+		"difficulty": (*hexutil.Big)(h.Difficulty),
+		"mixHash":    h.MixDigest,
+		"miner":      h.Coinbase,
+	}
+}
+
+func TestReadRPCGenesis(t *testing.T) {
+	gen := &genesisT.Genesis{
+		Config:     &multigeth.MultiGethChainConfig{NetworkID: 1337, ChainID: big.NewInt(1337), Ethash: new(ctypes.EthashConfig)},
+		Nonce:      0x42,
+		Timestamp:  1600000000,
+		ExtraData:  []byte("private chain"),
+		GasLimit:   8000000,
+		Difficulty: big.NewInt(0x20000),
+		Coinbase:   common.HexToAddress("0xabc"),
+		Alloc:      genesisT.GenesisAlloc{},
+	}
+	block := core.GenesisToBlock(gen, nil)
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &testEthService{chainID: big.NewInt(1337), genesis: block.Header()}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	conf, err := readRPCGenesis(context.Background(), httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conf.GetChainID(); got == nil || got.Uint64() != 1337 {
+		t.Errorf("chain ID: got %v, want 1337", got)
+	}
+	if got := conf.GetForkCanonHash(0); got != block.Hash() {
+		t.Errorf("required genesis hash: got %x, want %x", got, block.Hash())
+	}
+	if got := conf.GetEIP155Transition(); got != nil {
+		t.Errorf("EIP155: got %d, want unset", *got)
+	}
+	// Without genesis accounts, the genesis block is reconstructed exactly.
+	hash, err := genesisHash(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != block.Hash() {
+		t.Errorf("genesis hash: got %x, want %x", hash, block.Hash())
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	fromURLFlag = cli.StringFlag{
+		Name:  "from-url",
+		Usage: "URL of JSON chain configuration file to fetch (HTTPS only, unless --insecure)",
+	}
+	insecureFlag = cli.BoolFlag{
+		Name:  "insecure",
+		Usage: "Allow --from-url to fetch over plain HTTP",
+	}
+)
+
+var (
+	errInsecureURL     = errors.New("refusing to fetch non-HTTPS URL (use --insecure to allow)")
+	errConflictingFile = errors.New("--file and --from-url are mutually exclusive")
+)
+
+// httpClient is the client used to fetch --from-url configurations.
+var httpClient = http.DefaultClient
+
+// fetchURL opens the body of a GET request for the URL.
+// Responses with non-2xx statuses are an error.
+// The request is aborted when the command context is done.
+func fetchURL(ctx context.Context, rawurl string, insecure bool) (io.ReadCloser, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" && !(insecure && u.Scheme == "http") {
+		return nil, fmt.Errorf("%w: %s", errInsecureURL, rawurl)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errTimeout
+		}
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		res.Body.Close()
+		return nil, fmt.Errorf("fetch %s: unexpected response status: %s", rawurl, res.Status)
+	}
+	return res.Body, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchURL(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/foundation.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"config":{}}`))
+	})
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	defer func(c *http.Client) { httpClient = c }(httpClient)
+	httpClient = tlsServer.Client()
+
+	r, err := fetchURL(context.Background(), tlsServer.URL+"/foundation.json", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"config":{}}` {
+		t.Errorf("got body %q", b)
+	}
+
+	if _, err := fetchURL(context.Background(), tlsServer.URL+"/missing.json", false); err == nil {
+		t.Error("want error for not found response")
+	}
+	if _, err := fetchURL(context.Background(), server.URL+"/foundation.json", false); !errors.Is(err, errInsecureURL) {
+		t.Errorf("want %v, got %v", errInsecureURL, err)
+	}
+	if r, err := fetchURL(context.Background(), server.URL+"/foundation.json", true); err != nil {
+		t.Errorf("insecure: %v", err)
+	} else {
+		r.Close()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fetchURL(ctx, tlsServer.URL+"/foundation.json", false); err != errTimeout {
+		t.Errorf("want %v, got %v", errTimeout, err)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var genesisHashCommand = cli.Command{
+	Name:  "genesis-hash",
+	Usage: "Print the hash of the configuration's genesis block",
+	Description: `The genesis block is assembled from the configuration's genesis values and accounts,
+and is the same for any input format of the configuration.
+Use verify-genesis to compare the hash against the known genesis hash for the chain ID,
+or compare-genesis-hash to compare it against a given hash.`,
+	Action: printGenesisHash,
+}
+
+var compareGenesisHashCommand = cli.Command{
+	Name:      "compare-genesis-hash",
+	Usage:     "Check the hash of the configuration's genesis block against a given hash",
+	ArgsUsage: "<0x-prefixed hash>",
+	Description: `Exits 0 if the genesis hash matches the given hash, 1 if it does not, printing both hashes.
+The genesis hash is the same for any input format of the configuration,
+so a converted configuration which does not match has not preserved the chain.`,
+	Action: compareGenesisHash,
+}
+
+var (
+	errMissingHashArg = errors.New("missing genesis hash argument")
+	errInvalidHashArg = errors.New("invalid genesis hash argument")
+)
+
+func printGenesisHash(ctx *cli.Context) error {
+	hash, err := genesisHash(globalChainspecValue)
+	if err != nil {
+		return err
+	}
+	fmt.Println(hash.Hex())
+	return nil
+}
+
+// parseHash parses a 0x-prefixed hex hash.
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("%w: %q (want 0x-prefixed hex of %d bytes)", errInvalidHashArg, s, common.HashLength)
+	}
+	return common.BytesToHash(b), nil
+}
+
+// compareGenesisHashes returns the genesis hash of a configuration, and an error if it is not want.
+func compareGenesisHashes(conf ctypes.Configurator, want common.Hash) (common.Hash, error) {
+	hash, err := genesisHash(conf)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if hash != want {
+		return hash, fmt.Errorf("genesis hash mismatch: want: %s, got: %s", want.Hex(), hash.Hex())
+	}
+	return hash, nil
+}
+
+func compareGenesisHash(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errMissingHashArg
+	}
+	want, err := parseHash(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	hash, err := compareGenesisHashes(globalChainspecValue, want)
+	if err != nil {
+		return err
+	}
+	fmt.Println("genesis hash matches:", hash.Hex())
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestGenesisHashFormats(t *testing.T) {
+	for _, format := range []string{"geth", "multigeth", "parity", "besu"} {
+		conf, err := echainspec.Convert(defaultChainspecValues["foundation"], format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		hash, err := genesisHash(conf)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if hash != params.MainnetGenesisHash {
+			t.Errorf("%s: want: %s, got: %s", format, params.MainnetGenesisHash.Hex(), hash.Hex())
+		}
+	}
+}
+
+func TestCompareGenesisHashes(t *testing.T) {
+	want, err := parseHash(params.MainnetGenesisHash.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"geth", "multigeth", "parity", "besu"} {
+		conf, err := echainspec.Convert(defaultChainspecValues["foundation"], format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if _, err := compareGenesisHashes(conf, want); err != nil {
+			t.Errorf("%s: %v", format, err)
+		}
+	}
+
+	hash, err := compareGenesisHashes(defaultChainspecValues["mordor"], want)
+	if err == nil || exitCode(err) != failureExitCode {
+		t.Errorf("mismatch: got %v, want failure", err)
+	} else if msg := err.Error(); !strings.Contains(msg, want.Hex()) || !strings.Contains(msg, hash.Hex()) {
+		t.Errorf("mismatch: want both hashes, got %q", msg)
+	}
+
+	for _, s := range []string{"", "d4e56740", "0xd4e56740", params.MainnetGenesisHash.Hex() + "00"} {
+		if _, err := parseHash(s); exitCode(err) != usageExitCode {
+			t.Errorf("%q: got %v, want usage error", s, err)
+		}
+	}
+}
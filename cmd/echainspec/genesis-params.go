@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var genesisAccountsFlag = cli.BoolFlag{
+	Name:  "accounts",
+	Usage: "Also list the code hash (keccak256 of the code) of each genesis account with code",
+}
+
+var genesisParamsCommand = cli.Command{
+	Name:  "genesis-params",
+	Usage: "List the genesis block parameters of the configuration",
+	Description: `Lines are formatted as '<name> <value>'. Numbers are printed in decimal,
+and hashes, addresses, and byte values as 0x-prefixed lowercase hex.
+With --accounts, the code hash of each genesis account with code is listed after the parameters,
+in ascending address order, as 'codeHash(<address>) <hash>'; the hash is computed from the code,
+whether or not the account declares one.
+With --json, parameters are printed as an object of names to values (as strings).`,
+	Flags:  []cli.Flag{genesisAccountsFlag},
+	Action: printGenesisParams,
+}
+
+// genesisParam is a named genesis block parameter value.
+type genesisParam struct {
+	Name  string
+	Value string
+}
+
+// genesisParams returns the genesis block parameters of a configuration, in a canonical form.
+func genesisParams(conf ctypes.GenesisBlocker) []genesisParam {
+	difficulty := "0"
+	if d := conf.GetGenesisDifficulty(); d != nil {
+		difficulty = d.String()
+	}
+	nonce := types.EncodeNonce(conf.GetGenesisSealerEthereumNonce())
+	return []genesisParam{
+		{"gasLimit", fmt.Sprint(conf.GetGenesisGasLimit())},
+		{"difficulty", difficulty},
+		{"nonce", hexutil.Encode(nonce[:])},
+		{"mixHash", conf.GetGenesisSealerEthereumMixHash().Hex()},
+		{"timestamp", fmt.Sprint(conf.GetGenesisTimestamp())},
+		{"extraData", hexutil.Encode(conf.GetGenesisExtraData())},
+		{"coinbase", hexutil.Encode(conf.GetGenesisAuthor().Bytes())},
+	}
+}
+
+// genesisCodeHashes returns the computed code hash of each genesis account with code, in ascending address order.
+func genesisCodeHashes(conf ctypes.GenesisBlocker) []genesisParam {
+	codes := make(map[common.Address][]byte)
+	var addrs []common.Address
+	conf.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		if len(code) > 0 {
+			addrs = append(addrs, address)
+			codes[address] = code
+		}
+		return nil
+	})
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	params := make([]genesisParam, len(addrs))
+	for i, addr := range addrs {
+		params[i] = genesisParam{fmt.Sprintf("codeHash(%s)", hexutil.Encode(addr.Bytes())), crypto.Keccak256Hash(codes[addr]).Hex()}
+	}
+	return params
+}
+
+func printGenesisParams(ctx *cli.Context) error {
+	params := genesisParams(globalChainspecValue)
+	if ctx.Bool(genesisAccountsFlag.Name) {
+		params = append(params, genesisCodeHashes(globalChainspecValue)...)
+	}
+	if ctx.GlobalBool(jsonFlag.Name) {
+		m := make(map[string]string, len(params))
+		for _, p := range params {
+			m[p.Name] = p.Value
+		}
+		return printJSON(ctx, m)
+	}
+	for _, p := range params {
+		fmt.Println(p.Name, p.Value)
+	}
+	return nil
+}
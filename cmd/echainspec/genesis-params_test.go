@@ -0,0 +1,59 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestGenesisParams(t *testing.T) {
+	want := []genesisParam{
+		{"gasLimit", "5000"},
+		{"difficulty", "17179869184"},
+		{"nonce", "0x0000000000000042"},
+		{"mixHash", "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"timestamp", "0"},
+		{"extraData", "0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa"},
+		{"coinbase", "0x0000000000000000000000000000000000000000"},
+	}
+	foundation := defaultChainspecValues["foundation"]
+	if got := genesisParams(foundation); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// Parameters are read through the common representation, so are the same in any format.
+	for _, format := range []string{"parity", "besu"} {
+		conf, err := echainspec.Convert(foundation, format)
+		if err != nil {
+			t.Fatal(format, err)
+		}
+		if got := genesisParams(conf); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", format, got, want)
+		}
+	}
+}
+
+func TestGenesisCodeHashes(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("..", "..", "params", "confp", "testdata", "code_hash_multigeth.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := readChainspec("", data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Hashes are computed from the code, not read from the declared hash.
+	want := []genesisParam{
+		{"codeHash(0x0000000000000000000000000000000000001000)", "0xc1d5b4ce3e2a6227293fccce2904121c8647bbe16c1216340b851bf12d12560e"},
+		{"codeHash(0x0000000000000000000000000000000000001001)", "0xc1d5b4ce3e2a6227293fccce2904121c8647bbe16c1216340b851bf12d12560e"},
+		{"codeHash(0x0000000000000000000000000000000000001002)", "0x07ad118d6cc8642c86c03827f276d8b791a65e5c99a3845faf186be720a1455d"},
+	}
+	if got := genesisCodeHashes(conf); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := genesisCodeHashes(defaultChainspecValues["foundation"]); len(got) != 0 {
+		t.Errorf("foundation: want no accounts with code, got %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var identifyCommand = cli.Command{
+	Name:  "identify",
+	Usage: "Report whether an input configuration parses as each format, and why not",
+	Description: `The input (from --file or standard input) is read strictly as each format, which is printed
+with 'ok', or the error reading it, eg. the first field the format does not know. The best guess
+of the input's format (see detect) follows. No conversion is performed.
+Exits 0 if the input parses as any format, otherwise 1.`,
+	Action: identify,
+}
+
+// formatAttempt is the result of reading an input configuration as a format.
+type formatAttempt struct {
+	Format string
+	Err    error
+}
+
+// identifyFormats reads the data strictly as each known format, returning the result of each attempt
+// in format order, and the best guess of the data's format, or an empty string if there is none.
+// The best guess is the best detected candidate which parses strictly, if any.
+func identifyFormats(data []byte) ([]formatAttempt, string) {
+	attempts := make([]formatAttempt, 0, len(chainspecFormats))
+	ok := map[string]bool{}
+	for _, name := range chainspecFormats {
+		_, err := echainspec.ReadStrict(name, bytes.NewReader(data))
+		attempts = append(attempts, formatAttempt{Format: name, Err: err})
+		ok[name] = err == nil
+	}
+	candidates := echainspec.Detect(data)
+	for _, c := range candidates {
+		if ok[c.Format] {
+			return attempts, c.Format
+		}
+	}
+	if len(candidates) > 0 {
+		return attempts, candidates[0].Format
+	}
+	return attempts, ""
+}
+
+func identify(ctx *cli.Context) error {
+	data, err := readInputData(ctx)
+	if err != nil {
+		return err
+	}
+	attempts, best := identifyFormats(data)
+	p := newStdoutPrinter(ctx)
+	parsed := false
+	for _, a := range attempts {
+		if a.Err != nil {
+			p.PrintNamed(a.Format, a.Err)
+			continue
+		}
+		parsed = true
+		p.PrintNamed(a.Format, "ok")
+	}
+	if best == "" {
+		best = "none"
+	}
+	p.Print("best guess:", best)
+	if err := p.Flush(); err != nil {
+		return err
+	}
+	if !parsed {
+		return errNoFormatDetected
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestIdentifyFormats(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("..", "..", "params", "confp", "testdata", "stureby_parity.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempts, best := identifyFormats(data)
+	if len(attempts) != len(chainspecFormats) {
+		t.Fatalf("want %d attempts, got %d", len(chainspecFormats), len(attempts))
+	}
+	for _, a := range attempts {
+		switch a.Format {
+		case "parity":
+			if a.Err != nil {
+				t.Errorf("parity: %v", a.Err)
+			}
+		case "geth", "aleth":
+			if a.Err == nil {
+				t.Errorf("%s: want error", a.Format)
+			}
+		}
+	}
+	if best != "parity" {
+		t.Errorf("best guess: want parity, got %q", best)
+	}
+
+	attempts, best = identifyFormats([]byte(`{"foo": "bar"}`))
+	for _, a := range attempts {
+		if a.Err == nil {
+			t.Errorf("%s: want error", a.Format)
+		}
+	}
+	if best != "" {
+		t.Errorf("best guess: want none, got %q", best)
+	}
+}
@@ -1,34 +1,170 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"gopkg.in/urfave/cli.v1"
 )
 
+var ipsByBlockFlag = cli.BoolFlag{
+	Name:  "by-block",
+	Usage: "Sort by activation block instead of IP number",
+}
+
+var (
+	ipsOnlyFlag = cli.StringFlag{
+		Name:  "only",
+		Usage: "Comma-separated IP names to list, eg. eip155,eip1283",
+	}
+	ipsAtFlag = cli.StringFlag{
+		Name:  "at",
+		Usage: "Only list IPs active at this block <0x042|0x42|42> (timestamp-activated IPs are omitted)",
+	}
+)
+
 var ipsCommand = cli.Command{
-	Name:   "ips",
-	Usage:  "List IP transition names and values",
+	Name:  "ips",
+	Usage: "List IP transition names and values",
+	Description: `IPs activated by block timestamp rather than block number are suffixed 'Time' (eg. EIP3860Time),
+and their values are timestamps, marked 't=<timestamp>'. With --by-block, they are listed after block-activated IPs.
+Unset IPs are listed as '-'. With --json, IPs are printed as an object of names to blocks (null if unset).`,
+	Flags:  []cli.Flag{ipsByBlockFlag, ipsOnlyFlag, ipsAtFlag},
 	Action: ips,
 }
 
-func ips(ctx *cli.Context) error {
-	fns, names := confp.Transitions(globalChainspecValue)
-	for i, fn := range fns {
-		name := strings.TrimPrefix(names[i], "Get")
-		name = strings.TrimSuffix(name, "Transition")
+var errUnknownIP = errors.New("unknown IP name")
+
+// ipTransition is a named IP transition value, where a nil value means the transition is not configured.
+// If Time is true, the value is a block timestamp, rather than a block number.
+type ipTransition struct {
+	Name  string
+	Value *uint64
+	Time  bool
+}
+
+var ipNumberRe = regexp.MustCompile(`E?C?IP(\d+)`)
 
+// ipNumber returns the (EC)IP number in a transition name,
+// or MaxUint64 if the name has none (eg. EthashHomestead).
+func ipNumber(name string) uint64 {
+	m := ipNumberRe.FindStringSubmatch(name)
+	if m == nil {
+		return math.MaxUint64
+	}
+	n, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return n
+}
+
+// sortedTransitions returns a configurator's IP transitions in canonical order:
+// by (EC)IP number, or by activation block (unset last) if byBlock is true,
+// in which case timestamp transitions follow block transitions.
+// Remaining ties are broken by IP number, then by name.
+func sortedTransitions(conf ctypes.ChainConfigurator, byBlock bool) []ipTransition {
+	trs := []ipTransition{}
+	for name, v := range confp.EIPActivations(conf) {
+		trs = append(trs, ipTransition{Name: name, Value: v, Time: confp.IsTimeActivation(name)})
+	}
+	block := func(v *uint64) uint64 {
+		if v == nil {
+			return math.MaxUint64
+		}
+		return *v
+	}
+	sort.SliceStable(trs, func(i, j int) bool {
+		if byBlock {
+			if trs[i].Time != trs[j].Time {
+				return !trs[i].Time
+			}
+			if bi, bj := block(trs[i].Value), block(trs[j].Value); bi != bj {
+				return bi < bj
+			}
+		}
+		if ni, nj := ipNumber(trs[i].Name), ipNumber(trs[j].Name); ni != nj {
+			return ni < nj
+		}
+		return trs[i].Name < trs[j].Name
+	})
+	return trs
+}
+
+// selectTransitions returns the transitions with the given names, in the given order,
+// and named as given. Names are matched case-insensitively, with or without an 'Ethash' prefix.
+func selectTransitions(trs []ipTransition, names []string) ([]ipTransition, error) {
+	byName := make(map[string]ipTransition)
+	for _, tr := range trs {
+		byName[strings.ToLower(tr.Name)] = tr
+		byName[strings.ToLower(strings.TrimPrefix(tr.Name, "Ethash"))] = tr
+	}
+	selected := []ipTransition{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		tr, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errUnknownIP, name)
+		}
+		selected = append(selected, ipTransition{Name: name, Value: tr.Value, Time: tr.Time})
+	}
+	return selected, nil
+}
+
+// activeTransitions returns the transitions activated at or before block n.
+// Timestamp transitions cannot be compared with a block, so are not included.
+func activeTransitions(trs []ipTransition, n uint64) []ipTransition {
+	active := []ipTransition{}
+	for _, tr := range trs {
+		if !tr.Time && tr.Value != nil && *tr.Value <= n {
+			active = append(active, tr)
+		}
+	}
+	return active
+}
+
+func ips(ctx *cli.Context) error {
+	trs := sortedTransitions(globalChainspecValue, ctx.Bool(ipsByBlockFlag.Name))
+	if ctx.IsSet(ipsOnlyFlag.Name) {
+		var err error
+		trs, err = selectTransitions(trs, strings.Split(ctx.String(ipsOnlyFlag.Name), ","))
+		if err != nil {
+			return err
+		}
+	}
+	if ctx.IsSet(ipsAtFlag.Name) {
+		n, err := parseBlockNumber(ctx.String(ipsAtFlag.Name))
+		if err != nil {
+			return err
+		}
+		trs = activeTransitions(trs, n)
+	}
+	if ctx.GlobalBool(jsonFlag.Name) {
+		m := make(map[string]*uint64, len(trs))
+		for _, tr := range trs {
+			m[tr.Name] = tr.Value
+		}
+		return printJSON(ctx, m)
+	}
+	p := newStdoutPrinter(ctx)
+	for _, tr := range trs {
 		var printv interface{}
-		v := fn()
-		if v != nil {
-			printv = *v
+		if tr.Value != nil && tr.Time {
+			printv = fmt.Sprintf("t=%d", *tr.Value)
+		} else if tr.Value != nil {
+			printv = *tr.Value
 		} else {
 			printv = "-"
 		}
 
-		fmt.Println(name, fmt.Sprintf("%v", printv))
+		p.PrintNamed(tr.Name, printv)
 	}
-	return nil
+	return p.Flush()
 }
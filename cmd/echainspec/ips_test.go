@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSortedTransitionsStable(t *testing.T) {
+	for _, byBlock := range []bool{false, true} {
+		want := sortedTransitions(params.DefaultClassicGenesisBlock(), byBlock)
+		for i := 0; i < 10; i++ {
+			got := sortedTransitions(params.DefaultClassicGenesisBlock(), byBlock)
+			if !reflect.DeepEqual(want, got) {
+				t.Fatalf("unstable order, by block: %v, want: %v, got: %v", byBlock, want, got)
+			}
+		}
+		for i := 1; i < len(want); i++ {
+			a, b := want[i-1], want[i]
+			if byBlock {
+				if a.Value == nil && b.Value != nil {
+					t.Errorf("unset %s sorted before %s", a.Name, b.Name)
+				}
+				if a.Value != nil && b.Value != nil && *a.Value > *b.Value {
+					t.Errorf("%s (%d) sorted before %s (%d)", a.Name, *a.Value, b.Name, *b.Value)
+				}
+				continue
+			}
+			if ipNumber(a.Name) > ipNumber(b.Name) {
+				t.Errorf("%s sorted before %s", a.Name, b.Name)
+			}
+		}
+	}
+}
+
+func TestSelectTransitions(t *testing.T) {
+	trs := sortedTransitions(params.DefaultGenesisBlock(), false)
+	got, err := selectTransitions(trs, []string{"eip155", "EIP2384", "ecip1017"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d transitions, want 3", len(got))
+	}
+	if got[0].Name != "eip155" || got[0].Value == nil || *got[0].Value != 2675000 {
+		t.Errorf("eip155: got %v", got[0])
+	}
+	if got[1].Value == nil || *got[1].Value != 9200000 {
+		t.Errorf("EIP2384: got %v", got[1])
+	}
+	if got[2].Value != nil {
+		t.Errorf("ecip1017: got %d, want unset", *got[2].Value)
+	}
+	if _, err := selectTransitions(trs, []string{"eip9999999"}); err == nil {
+		t.Error("expected error for unknown IP name")
+	}
+
+	active := activeTransitions(got, 5000000)
+	if len(active) != 1 || active[0].Name != "eip155" {
+		t.Errorf("active at 5000000: got %v, want [eip155]", active)
+	}
+}
@@ -2,19 +2,88 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"gopkg.in/urfave/cli.v1"
 )
 
+var lsFormatsVerboseFlag = cli.BoolFlag{
+	Name:  "verbose",
+	Usage: "Describe the capabilities of each format",
+}
+
 var lsFormatsCommand = cli.Command{
 	Name:   "ls-formats",
 	Usage:  "List client configuration formats",
+	Flags:  []cli.Flag{lsFormatsVerboseFlag},
 	Action: lsFormats,
 }
 
+// formatCapabilities describes what a chainspec format is able to represent.
+// It is used to inform users about what might be lost in a conversion.
+type formatCapabilities struct {
+	Alloc          bool
+	Engines        []ctypes.ConsensusEngineT
+	TimestampForks bool
+}
+
+var chainspecFormatCapabilities = map[string]formatCapabilities{
+	"parity": {
+		Alloc:   true,
+		Engines: []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash, ctypes.ConsensusEngineT_Clique},
+	},
+	"multigeth": {
+		Alloc:          true,
+		Engines:        []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash, ctypes.ConsensusEngineT_Clique},
+		TimestampForks: true,
+	},
+	"geth": {
+		Alloc:          true,
+		Engines:        []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash, ctypes.ConsensusEngineT_Clique},
+		TimestampForks: true,
+	},
+	"aleth": {
+		Alloc:   true,
+		Engines: []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash},
+	},
+	"nethermind": {
+		Alloc:          true,
+		Engines:        []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash, ctypes.ConsensusEngineT_Clique},
+		TimestampForks: true,
+	},
+	"retesteth": {
+		Alloc:   true,
+		Engines: []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash},
+	},
+	"besu": {
+		Alloc:          true,
+		Engines:        []ctypes.ConsensusEngineT{ctypes.ConsensusEngineT_Ethash, ctypes.ConsensusEngineT_Clique},
+		TimestampForks: true,
+	},
+}
+
+func (c formatCapabilities) String() string {
+	yesno := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	engines := []string{}
+	for _, e := range c.Engines {
+		engines = append(engines, e.String())
+	}
+	return fmt.Sprintf("\talloc: %s\n\tengines: %s\n\ttimestamp forks: %s",
+		yesno(c.Alloc), strings.Join(engines, ","), yesno(c.TimestampForks))
+}
+
 func lsFormats(ctx *cli.Context) error {
 	for _, name := range chainspecFormats {
 		fmt.Println(name)
+		if ctx.Bool(lsFormatsVerboseFlag.Name) {
+			fmt.Println(chainspecFormatCapabilities[name])
+		}
 	}
 	return nil
 }
@@ -7,14 +7,22 @@ import (
 )
 
 var lsDefaultsCommand = cli.Command{
-	Name:   "ls-defaults",
-	Usage:  "List default configurations",
+	Name:  "ls-defaults",
+	Usage: "List default configurations",
+	Description: `Lines are formatted as '<name> <source>', where the source is 'builtin',
+or the path of a user default configuration file.
+User defaults are read from $ECHAINSPEC_HOME/defaults/*.json (default: ~/.echainspec/defaults),
+and named by their file names. Builtin defaults take precedence, unless --override-defaults is set.`,
 	Action: lsDefaults,
 }
 
 func lsDefaults(ctx *cli.Context) error {
-	for _, name := range defaultChainspecNames {
-		fmt.Println(name)
+	sources, err := defaultSources(userDefaultsDir(), ctx.GlobalBool(overrideDefaultsFlag.Name))
+	if err != nil {
+		return err
+	}
+	for _, s := range sources {
+		fmt.Println(s.Name, s.Source)
 	}
 	return nil
 }
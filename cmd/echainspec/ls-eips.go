@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var lsEIPsVerboseFlag = cli.BoolFlag{
+	Name:  "verbose",
+	Usage: "Describe each IP, and name the hard fork it is grouped under",
+}
+
+var lsEIPsCommand = cli.Command{
+	Name:  "ls-eips",
+	Usage: "List the (EC)IP transitions which configurations can represent",
+	Description: `IPs are listed by their names in the ips command, which are those of the chain configurator model.
+Only IPs listed here are preserved by conversion between formats.`,
+	Flags:  []cli.Flag{lsEIPsVerboseFlag},
+	Action: lsEIPs,
+}
+
+// ipDescriptions are one-line descriptions of the IPs of the configurator model.
+var ipDescriptions = map[string]string{
+	"EIP7":                   "DELEGATECALL opcode",
+	"EIP150":                 "Gas cost changes for IO-heavy operations",
+	"EIP152":                 "BLAKE2b F compression function precompile",
+	"EIP155":                 "Simple replay attack protection (chain ID in signatures)",
+	"EIP160":                 "EXP opcode cost increase",
+	"EIP161abc":              "State trie clearing: nonces, empty account creation and touching",
+	"EIP161d":                "State trie clearing: removal of touched empty accounts",
+	"EIP170":                 "Contract code size limit",
+	"EIP140":                 "REVERT opcode",
+	"EIP198":                 "Big integer modular exponentiation precompile",
+	"EIP211":                 "RETURNDATASIZE and RETURNDATACOPY opcodes",
+	"EIP212":                 "alt_bn128 pairing check precompile",
+	"EIP213":                 "alt_bn128 addition and scalar multiplication precompiles",
+	"EIP214":                 "STATICCALL opcode",
+	"EIP658":                 "Transaction status code in receipts",
+	"EIP145":                 "Bitwise shifting opcodes (SHL, SHR, SAR)",
+	"EIP1014":                "CREATE2 opcode",
+	"EIP1052":                "EXTCODEHASH opcode",
+	"EIP1283":                "Net gas metering for SSTORE (without dirty maps)",
+	"EIP1283Disable":         "Removal of EIP-1283 net gas metering",
+	"EIP1108":                "Reduced alt_bn128 precompile gas costs",
+	"EIP2200":                "Structured definitions for net gas metering",
+	"EIP2200Disable":         "Removal of EIP-2200 net gas metering",
+	"EIP1344":                "CHAINID opcode",
+	"EIP1884":                "Repricing of trie-size-dependent opcodes",
+	"EIP2028":                "Transaction data gas cost reduction",
+	"ECIP1080":               "Removal of EIP-2200 net gas metering (Ethereum Classic)",
+	"EIP1706":                "Disable SSTORE with gasleft lower than call stipend",
+	"EIP2565":                "ModExp precompile gas cost",
+	"EIP2718":                "Typed transaction envelope",
+	"EIP2929":                "Gas cost increases for state access opcodes",
+	"EIP2930":                "Optional access lists (transaction type 1)",
+	"EIP1559":                "Fee market change (base fee and transaction type 2)",
+	"EIP3529":                "Reduction in refunds (London)",
+	"EIP3541":                "Reject new contracts starting with the 0xEF byte",
+	"EWASM":                  "eWASM (WebAssembly) execution engine (experimental, test chains)",
+	"EIP3651Time":            "Warm COINBASE (Shanghai, by timestamp)",
+	"EIP3855Time":            "PUSH0 instruction (Shanghai, by timestamp)",
+	"EIP3860Time":            "Limit and meter initcode (Shanghai, by timestamp)",
+	"EIP4895Time":            "Beacon chain push withdrawals as operations (Shanghai, by timestamp)",
+	"EIP1153Time":            "Transient storage opcodes (Cancun, by timestamp)",
+	"EIP4788Time":            "Beacon block root in the EVM (Cancun, by timestamp)",
+	"EIP4844Time":            "Shard blob transactions (Cancun, by timestamp)",
+	"EIP5656Time":            "MCOPY memory copying instruction (Cancun, by timestamp)",
+	"EIP6780Time":            "SELFDESTRUCT only in same transaction (Cancun, by timestamp)",
+	"EIP7516Time":            "BLOBBASEFEE instruction (Cancun, by timestamp)",
+	"EthashHomestead":        "Homestead difficulty adjustment",
+	"EthashEIP2":             "Homestead hard fork changes (contract creation cost, signature validity)",
+	"EthashEIP779":           "DAO hard fork",
+	"EthashEIP649":           "Difficulty bomb delay and block reward reduction (Byzantium)",
+	"EthashEIP1234":          "Difficulty bomb delay and block reward reduction (Constantinople)",
+	"EthashEIP2384":          "Difficulty bomb delay (Muir Glacier)",
+	"EthashEIP3554":          "Difficulty bomb delay (London)",
+	"EthashECIP1010Pause":    "Difficulty bomb pause (Ethereum Classic)",
+	"EthashECIP1010Continue": "Difficulty bomb continuation (Ethereum Classic)",
+	"EthashECIP1017":         "Monetary policy: block reward eras (Ethereum Classic)",
+	"EthashEIP100B":          "Difficulty adjustment including uncles",
+	"EthashECIP1041":         "Difficulty bomb removal (Ethereum Classic)",
+}
+
+// modelIPs returns the names of the IP transitions of the configurator model,
+// ordered by (EC)IP number, then by name.
+func modelIPs() []string {
+	names := []string{}
+	k := reflect.TypeOf((*ctypes.ChainConfigurator)(nil)).Elem()
+	for i := 0; i < k.NumMethod(); i++ {
+		name := k.Method(i).Name
+		if !strings.HasPrefix(name, "Get") {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(name, "Transition"):
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(name, "Get"), "Transition"))
+		case strings.HasSuffix(name, "TransitionTime"):
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(name, "Get"), "TransitionTime")+"Time")
+		}
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		ni, nj := ipNumber(names[i]), ipNumber(names[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// ipForkName returns the name of the hard fork which an IP is grouped under, or "-" if none.
+func ipForkName(ip string) string {
+	for _, f := range namedForks {
+		for _, t := range f.Transitions {
+			if t == ip {
+				return f.Name
+			}
+		}
+	}
+	return "-"
+}
+
+func lsEIPs(ctx *cli.Context) error {
+	for _, ip := range modelIPs() {
+		fmt.Println(ip)
+		if !ctx.Bool(lsEIPsVerboseFlag.Name) {
+			continue
+		}
+		desc, ok := ipDescriptions[ip]
+		if !ok {
+			desc = "-"
+		}
+		fmt.Printf("\tdescription: %s\n\tfork: %s\n", desc, ipForkName(ip))
+	}
+	return nil
+}
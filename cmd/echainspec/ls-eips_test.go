@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestModelIPs(t *testing.T) {
+	ips := modelIPs()
+	seen := make(map[string]bool)
+	for _, ip := range ips {
+		seen[ip] = true
+		if _, ok := ipDescriptions[ip]; !ok {
+			t.Errorf("missing description for %s", ip)
+		}
+	}
+	for ip := range ipDescriptions {
+		if !seen[ip] {
+			t.Errorf("description for %s, which is not in the model", ip)
+		}
+	}
+	for _, f := range namedForks {
+		for _, tr := range f.Transitions {
+			if !seen[tr] {
+				t.Errorf("fork %s transition %s is not in the model", f.Name, tr)
+			}
+		}
+	}
+	if got := ipForkName("EIP155"); got != "spuriousDragon" {
+		t.Errorf("EIP155 fork: got %s, want spuriousDragon", got)
+	}
+}
@@ -3,45 +3,23 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
-	"github.com/ethereum/go-ethereum/params/types/genesisT"
-	"github.com/ethereum/go-ethereum/params/types/goethereum"
-	"github.com/ethereum/go-ethereum/params/types/multigeth"
-	"github.com/ethereum/go-ethereum/params/types/parity"
 	"gopkg.in/urfave/cli.v1"
 )
 
 var gitCommit = "" // Git SHA1 commit hash of the release (set via linker flags)
 var gitDate = ""
 
-var (
-	chainspecFormatTypes = map[string]ctypes.Configurator{
-		"parity": &parity.ParityChainSpec{},
-		"multigeth": &genesisT.Genesis{
-			Config: &multigeth.MultiGethChainConfig{},
-		},
-		"geth": &genesisT.Genesis{
-			Config: &goethereum.ChainConfig{},
-		},
-		// TODO
-		// "aleth"
-		// "retesteth"
-	}
-)
-
-var chainspecFormats = func() []string {
-	names := []string{}
-	for k := range chainspecFormatTypes {
-		names = append(names, k)
-	}
-	return names
-}()
+// chainspecFormats are the names of the formats which can be read and written.
+var chainspecFormats = echainspec.Formats()
 
 var defaultChainspecValues = map[string]ctypes.Configurator{
 	"classic": params.DefaultClassicGenesisBlock(),
@@ -71,7 +49,7 @@ var (
 
 	formatInFlag = cli.StringFlag{
 		Name:  "inputf",
-		Usage: fmt.Sprintf("Input format type [%s]", strings.Join(chainspecFormats, "|")),
+		Usage: fmt.Sprintf("Input format type [%s|%s] (default: %s)", strings.Join(chainspecFormats, "|"), autoFormat, autoFormat),
 		Value: "",
 	}
 	fileInFlag = cli.StringFlag{
@@ -80,12 +58,32 @@ var (
 	}
 	defaultValueFlag = cli.StringFlag{
 		Name:  "default",
-		Usage: fmt.Sprintf("Use default chainspec values [%s]", strings.Join(defaultChainspecNames, "|")),
+		Usage: fmt.Sprintf("Use default chainspec values [%s], or a user default (see ls-defaults)", strings.Join(defaultChainspecNames, "|")),
+	}
+	fromBesuGenesisFlag = cli.StringFlag{
+		Name:  "from-besu-genesis",
+		Usage: "Path to Besu genesis file (shorthand for --inputf besu --file <path>)",
+	}
+	strictFlag = cli.BoolFlag{
+		Name:  "strict",
+		Usage: "Reject input configurations with fields unknown to their format",
 	}
 	outputFormatFlag = cli.StringFlag{
 		Name:  "outputf",
 		Usage: fmt.Sprintf("Output client format type for converted configuration file [%s]", strings.Join(chainspecFormats, "|")),
 	}
+	warnFlag = cli.BoolFlag{
+		Name:  "warn",
+		Usage: "Print fields which were dropped or changed by the --outputf conversion to stderr",
+	}
+	quietFlag = cli.BoolFlag{
+		Name:  "quiet",
+		Usage: "Suppress warnings and notes on stderr (including --warn's); only the error of a failed command is printed",
+	}
+	validateOnConvertFlag = cli.BoolFlag{
+		Name:  "validate-on-convert",
+		Usage: "Validate the output configuration (as 'validate' without a block number does) before writing it, failing if it is invalid",
+	}
 )
 
 var globalChainspecValue ctypes.Configurator
@@ -94,6 +92,39 @@ var errInvalidOutputFlag = errors.New("invalid output format type")
 var errNoChainspecValue = errors.New("undetermined chainspec value")
 var errInvalidDefaultValue = errors.New("no default chainspec found for name given")
 var errInvalidChainspecValue = errors.New("could not read given chainspec")
+var errInvalidOutputConfig = errors.New("invalid output configuration")
+
+// validateOutputConfig runs the structural (head-agnostic) validation on an output configuration.
+func validateOutputConfig(conf ctypes.Configurator) error {
+	if err := confp.Validate(conf, nil); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidOutputConfig, err)
+	}
+	return nil
+}
+
+// writeConverted writes an output configuration, first validating it if --validate-on-convert is set.
+func writeConverted(ctx *cli.Context, conf ctypes.Configurator) error {
+	if ctx.GlobalBool(validateOnConvertFlag.Name) {
+		if err := validateOutputConfig(conf); err != nil {
+			return err
+		}
+	}
+	if ctx.GlobalIsSet(diffAgainstFlag.Name) {
+		return printDiffAgainst(ctx, conf)
+	}
+	if ctx.GlobalIsSet(selectFlag.Name) {
+		return printSelectedConfig(ctx, conf)
+	}
+	return writeOutput(ctx, conf)
+}
+
+// setupQuiet discards log (stderr) output if --quiet is set.
+// The error of a failed command is printed by main, so is not suppressed.
+func setupQuiet(ctx *cli.Context) {
+	if ctx.GlobalBool(quietFlag.Name) {
+		log.SetOutput(ioutil.Discard)
+	}
+}
 
 func mustGetChainspecValue(ctx *cli.Context) error {
 	if ctx.NArg() >= 1 {
@@ -103,52 +134,104 @@ func mustGetChainspecValue(ctx *cli.Context) error {
 		if strings.Contains(ctx.Args().First(), "help") {
 			return nil
 		}
+		// These commands do not operate on an established chainspec value, or read it themselves.
+		for _, c := range []cli.Command{detectCommand, newCommand, verifyDefaultsCommand, batchCommand, migrateCommand, identifyCommand, schemaCommand, eipsDiffCommand} {
+			if ctx.Args().First() == c.Name {
+				return nil
+			}
+		}
 	}
+	if ctx.GlobalBool(ndjsonFlag.Name) {
+		// Configurations are read record by record by the command.
+		if name := ctx.Args().First(); name != validateCommand.Name && name != validateCommand.Aliases[0] {
+			return errNDJSONCommand
+		}
+		return nil
+	}
+	v, err := readChainspecValue(ctx)
+	if err != nil {
+		return err
+	}
+	globalChainspecValue = v
+	return nil
+}
+
+// readChainspecValue reads the configuration given by the global flags: a default, a file, a URL, or standard input.
+func readChainspecValue(ctx *cli.Context) (ctypes.Configurator, error) {
 	if ctx.GlobalIsSet(defaultValueFlag.Name) {
 		if ctx.GlobalString(defaultValueFlag.Name) == "" {
-			return errNoChainspecValue
+			return nil, errNoChainspecValue
 		}
-		v, ok := defaultChainspecValues[ctx.GlobalString(defaultValueFlag.Name)]
-		if !ok {
-			return fmt.Errorf("error: %v, name: %s", errInvalidDefaultValue, ctx.GlobalString(defaultValueFlag.Name))
+		return lookupDefault(ctx.GlobalString(defaultValueFlag.Name), userDefaultsDir(), ctx.GlobalBool(overrideDefaultsFlag.Name))
+	}
+	if ctx.GlobalIsSet(fromBesuGenesisFlag.Name) {
+		return readBesuGenesis(ctx.GlobalString(fromBesuGenesisFlag.Name), ctx.GlobalBool(strictFlag.Name))
+	}
+	if ctx.GlobalIsSet(fromRPCFlag.Name) {
+		if ctx.GlobalIsSet(fileInFlag.Name) || ctx.GlobalIsSet(fromURLFlag.Name) {
+			return nil, errConflictingRPC
 		}
-		globalChainspecValue = v
-		return nil
+		return readRPCGenesis(commandContext, ctx.GlobalString(fromRPCFlag.Name))
 	}
 	data, err := readInputData(ctx)
 	if err != nil {
+		return nil, err
+	}
+	return readChainspec(ctx.GlobalString(formatInFlag.Name), data, ctx.GlobalBool(strictFlag.Name))
+}
+
+func convertf(ctx *cli.Context) error {
+	if err := applyMergeAlloc(ctx, globalChainspecValue); err != nil {
 		return err
 	}
-	configurator, err := unmarshalChainSpec(ctx.GlobalString(formatInFlag.Name), data)
+	out, err := convertOutput(ctx, globalChainspecValue)
 	if err != nil {
 		return err
 	}
-	globalChainspecValue = configurator
-	return nil
+	return writeConverted(ctx, out)
 }
 
-func convertf(ctx *cli.Context) error {
-	c, ok := chainspecFormatTypes[ctx.String(outputFormatFlag.Name)]
-	if !ok && ctx.String(outputFormatFlag.Name) == "" {
-		b, err := jsonMarshalPretty(globalChainspecValue)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(b))
-		return nil
-	} else if !ok {
-		return errInvalidOutputFlag
+// convertOutput converts a configuration to the --outputf format, if given,
+// and applies the output engine, compatibility, and EIP-161 flags to it.
+func convertOutput(ctx *cli.Context, conf ctypes.Configurator) (ctypes.Configurator, error) {
+	if err := apply161Flags(ctx, conf); err != nil {
+		return nil, err
 	}
-	err := confp.Convert(globalChainspecValue, c)
+	out, err := convertOutputFormat(ctx, conf)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if ctx.GlobalBool(split161Flag.Name) {
+		if err := checkSplit161(conf, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func convertOutputFormat(ctx *cli.Context, conf ctypes.Configurator) (ctypes.Configurator, error) {
+	if ctx.GlobalString(outputFormatFlag.Name) == "" {
+		if err := overrideConsensusEngine(ctx, conf); err != nil {
+			return nil, err
+		}
+		return applyOutputCompat(ctx, conf)
+	}
+	c, warnings, err := echainspec.ConvertWithWarnings(conf, ctx.GlobalString(outputFormatFlag.Name))
+	if errors.Is(err, echainspec.ErrUnknownFormat) {
+		return nil, errInvalidOutputFlag
+	}
+	if ctx.GlobalBool(warnFlag.Name) {
+		for _, w := range warnings {
+			log.Println("warning:", w)
+		}
 	}
-	b, err := jsonMarshalPretty(c)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	fmt.Println(string(b))
-	return nil
+	if err := overrideConsensusEngine(ctx, c); err != nil {
+		return nil, err
+	}
+	return applyOutputCompat(ctx, c)
 }
 
 func init() {
@@ -169,17 +252,52 @@ USAGE:
 
 	(1.) When reading an external configuration, specify --inputf to define how the provided
 	configuration should be interpreted.
+	If --inputf is not given (or is 'auto'), the format is guessed by trying each format in turn.
+	The geth format reads either a full genesis or a bare chain config (the genesis 'config' object);
+	a bare config is read as the config of a genesis with no accounts.
+	Fields which the format does not know are ignored, unless --strict is given, in which case
+	they are an error (eg. a misspelled fork field).
 
-	The tool expects to read from standard input (fd 0). Use --file to specify a filepath instead.
+	The tool expects to read from standard input (fd 0). Use --file to specify a filepath instead,
+	or --from-url to fetch the configuration over HTTPS (eg. a raw file from a client's repository).
+	Use --from-rpc to reconstruct a best-effort multigeth configuration from a running node's
+	chain ID and genesis block; fork blocks and genesis accounts cannot be recovered this way.
 
 	With an optional --outputf flag, the tool will write the established configuration in the desired format.
 	If no --outputf is given, the configuration will be printed in its original format.
+	Fields which the output format cannot represent are dropped; use --warn to list them.
+	Parity builtins other than the standard precompiles (eg. a chain's custom precompile) are kept
+	by multigeth as a 'builtin' object on the genesis account, which is not part of the genesis state.
+	Genesis config fields which the tool does not know (eg. of newer client versions) are kept
+	when the output format is the input's (geth, multigeth, or besu), and otherwise dropped.
+	The EIP-161 sub-parts (EIP161abc, EIP161d) are configured separately by some formats (eg. Parity),
+	and together by others (eg. geth). Use --merge-161 to write them as a single group, or --split-161
+	to fail rather than write them at blocks other than their own.
+	With --validate-on-convert, an output configuration which fails the structural checks of
+	'validate' is not written, and the tool exits 1.
+	With --merge-alloc <file>, the genesis accounts of the file (a JSON object of accounts by address,
+	as in a genesis 'alloc') are added to the configuration before it is written; existing accounts
+	with the same address are replaced, with a warning. Only the genesis accounts are changed.
+	The genesis 'alloc' keys of geth, multigeth, and besu output are written as go-ethereum writes them
+	(unprefixed lowercase hex), or as set by --alloc-key-format, or as the genesis marshaling
+	of --output-client-version <client>[/<version>] writes them (clients: core-geth, geth, multigeth).
+	With --show-defaults, every fork field of the (JSON) output configuration is written, rather than
+	only those which are set: a field which the format infers from others (eg. a hard fork's EIPs)
+	is written with its effective value, and a field which never activates as null. Each fork field
+	is listed on stderr as '<field>: explicit', '<field>: defaulted', or '<field>: never'.
+	Object keys are then written in sorted order.
+	With --diff-against <chain>, only the fields of the output configuration which differ from the
+	named default configuration are printed, as a JSON object of field names to values ('{}' if none).
+	With --select <path>, only the value at a dot-separated path of the output configuration is printed,
+	as JSON, eg. 'config' or 'config.chainId' (array elements are selected by index, eg. 'nodes.0').
+	With --diff-against, the path selects from the differences.
 
 	Run the following to list available client formats (both for reading and writing):
 
 		{{.Name}} ls-formats
 
-	(2.) Use --default [<chain>] to set the chain configuration value to one of the built in defaults.
+	(2.) Use --default [<chain>] to set the chain configuration value to one of the built in defaults,
+	or to a user default configuration file in $ECHAINSPEC_HOME/defaults (default: ~/.echainspec/defaults).
 	Run the following to list available default configuration values.
 
 		{{.Name}} ls-defaults
@@ -187,6 +305,8 @@ USAGE:
 - Inspecting chain configurations:
 
 	Additional commands are provided (see COMMANNDS section) to help grok chain configurations.
+	When standard output is a terminal, the columns of forks, ips, and rewards are aligned,
+	and fork and IP names are colored (unless --no-color is given). Piped output is unchanged.
 
 EXAMPLES:
 
@@ -198,14 +318,89 @@ EXAMPLES:
 
 		> {{.Name}} --inputf parity --file my-parity-spec.json --outputf [geth|multigeth]
 
+	Check that a Parity chainspec has the Ethereum mainnet genesis (exits 1 if it does not):
+
+		> {{.Name}} --inputf parity --file my-parity-spec.json compare-genesis-hash 0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3
+
 	Print a default Ethereum Classic network chain configuration in multigeth format:
 	
 		> {{.Name}} --default classic --outputf multigeth
 
+	Print only the chain ID of a Parity chainspec converted to multigeth format:
+
+		> {{.Name}} --inputf parity --file my-parity-spec.json --outputf multigeth --select config.chainId
+
 	Validate a default Kotti network chain configuration for block #3000000:
 	
 		> {{.Name}} --default kotti validate 3000000
 
+	Block numbers may also be given in hex, eg. as copied from a Parity chainspec:
+
+		> {{.Name}} --default kotti validate 0x2dc6c0
+
+	Print why a configuration is invalid at block #3000000 (or that it is valid):
+
+		> {{.Name}} --file my-spec.json validate --explain 3000000
+
+	Add prefunded accounts to a default Goerli network chain configuration, written in multigeth format:
+
+		> {{.Name}} --default goerli --merge-alloc dev-accounts.json --outputf multigeth
+
+	Print a Parity chainspec with every fork field written, listing which were explicit, defaulted, or never activate:
+
+		> {{.Name}} --inputf parity --file my-parity-spec.json --show-defaults
+
+	Apply the fields set by a (partial) override configuration to a default Goerli network chain configuration:
+
+		> {{.Name}} --default goerli merge --overlay overrides.json
+
+	List the IPs whose activations differ between the Ethereum Foundation and Ethereum Classic networks:
+
+		> {{.Name}} eips-diff --a foundation --b classic
+
+	Convert each Parity chainspec of a directory to multigeth format, writing them to another directory:
+
+		> {{.Name}} --inputf parity --outputf multigeth batch ./parity-specs --out ./multigeth-specs
+
+	Upgrade a multigeth configuration of the former schema to the current schema, reporting the changed fields:
+
+		> {{.Name}} --file old-multigeth-genesis.json migrate --since multigethv0
+
+	Report whether each of a list of IPs is active at a block, for the default Ethereum Foundation configuration:
+
+		> printf "eip155 2000000\neip1283 7000000\n" | {{.Name}} --default foundation supports-batch
+
+	Report whether a configuration parses as each format, and the error for each which it does not:
+
+		> {{.Name}} --file x.json identify
+
+	Print a JSON Schema of Parity chainspecs, eg. for editor validation of hand-edited specs:
+
+		> {{.Name}} schema parity > parity.schema.json
+
+	Print the next fork of the default Goerli network configuration after block #7000000 (or 'no further forks'):
+
+		> {{.Name}} --default goerli forks --next 7000000
+
+	Calculate the difficulty of block #5000000 of the Ethereum Foundation configuration, 14 seconds after its parent:
+
+		> {{.Name}} --default foundation difficulty --block 5000000 --parent-difficulty 2000000000000000 --parent-timestamp 1518000000 --timestamp 1518000014
+
+	Derive a proof-of-authority (clique) development chain configuration from the Ethereum Foundation configuration:
+
+		> {{.Name}} --default foundation --outputf geth --output-engine clique --clique-period 0 --clique-signers 0x<signer address>
+
+EXIT STATUS:
+
+	0	Success.
+	1	The configuration is invalid, or could not be read or processed.
+	2	The flags or arguments are invalid (eg. an unknown --outputf, or a missing --file).
+	124	The command was aborted by --timeout.
+
+	The diff command uses exit status 3 and 4 to report the kind of differences found (see diff --help),
+	and the supports command uses exit status 3 if the IP is not activated.
+	With --quiet, a failed command prints only its error to stderr; exit statuses are unchanged.
+
 VERSION:
    {{.Version}}
 
@@ -220,23 +415,95 @@ GLOBAL OPTIONS:
 	app.Flags = []cli.Flag{
 		formatInFlag,
 		fileInFlag,
+		fromURLFlag,
+		insecureFlag,
+		fromRPCFlag,
 		defaultValueFlag,
+		overrideDefaultsFlag,
+		fromBesuGenesisFlag,
+		strictFlag,
 		outputFormatFlag,
+		warnFlag,
+		quietFlag,
+		noColorFlag,
+		validateOnConvertFlag,
+		mergeAllocFlag,
+		showDefaultsFlag,
+		diffAgainstFlag,
+		selectFlag,
+		outputCompatFlag,
+		outputEngineFlag,
+		merge161Flag,
+		split161Flag,
+		cliquePeriodFlag,
+		cliqueEpochFlag,
+		cliqueSignersFlag,
+		allocKeyFormatFlag,
+		outputClientVersionFlag,
+		outputSerializationFlag,
+		compactFlag,
+		indentFlag,
+		outFileFlag,
+		outFileForceFlag,
+		ndjsonFlag,
+		concurrencyFlag,
+		jsonFlag,
+		timeoutFlag,
 	}
 	app.Commands = []cli.Command{
 		lsDefaultsCommand,
 		lsFormatsCommand,
+		lsEIPsCommand,
 		validateCommand,
 		forksCommand,
 		ipsCommand,
+		detectCommand,
+		txTypesCommand,
+		newCommand,
+		forkGapsCommand,
+		diffCommand,
+		allocDiffCommand,
+		eipsDiffCommand,
+		verifyGenesisCommand,
+		verifyDefaultsCommand,
+		genesisHashCommand,
+		compareGenesisHashCommand,
+		genesisParamsCommand,
+		rewardsCommand,
+		difficultyCommand,
+		precompilesCommand,
+		chainIDCommand,
+		consensusCommand,
+		normalizeCommand,
+		supportsCommand,
+		supportsBatchCommand,
+		mergeCommand,
+		batchCommand,
+		migrateCommand,
+		identifyCommand,
+		schemaCommand,
+	}
+	app.OnUsageError = onUsageError
+	for i := range app.Commands {
+		app.Commands[i].OnUsageError = onUsageError
+	}
+	app.Before = func(ctx *cli.Context) error {
+		setupTimeout(ctx)
+		setupQuiet(ctx)
+		if err := setupIndent(ctx); err != nil {
+			return err
+		}
+		return mustGetChainspecValue(ctx)
 	}
-	app.Before = mustGetChainspecValue
 	app.Action = convertf
 }
 
 func main() {
-	if err := app.Run(os.Args); err != nil {
+	err := runWithTimeout(func() error {
+		return app.Run(os.Args)
+	})
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var mergeAllocFlag = cli.StringFlag{
+	Name:  "merge-alloc",
+	Usage: "Path to a JSON object of genesis accounts (by address, as in a genesis 'alloc') to add to the configuration before it is written, replacing existing accounts",
+}
+
+var errInvalidAllocAddress = errors.New("invalid genesis account address")
+
+// readMergeAlloc reads a genesis allocation, as a JSON object of accounts by address.
+// Every key must be an address (40 hex characters, optionally 0x-prefixed).
+func readMergeAlloc(data []byte) (genesisT.GenesisAlloc, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for k := range raw {
+		if !common.IsHexAddress(k) {
+			return nil, fmt.Errorf("%w: %q", errInvalidAllocAddress, k)
+		}
+	}
+	alloc := genesisT.GenesisAlloc{}
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, err
+	}
+	return alloc, nil
+}
+
+// mergeAlloc adds the accounts of alloc to the configuration's genesis accounts, in ascending address order.
+// Accounts which the configuration already has are replaced; their addresses are returned.
+func mergeAlloc(conf ctypes.GenesisBlocker, alloc genesisT.GenesisAlloc) ([]common.Address, error) {
+	existing := make(map[common.Address]bool)
+	err := conf.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		existing[address] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]common.Address, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	overwritten := []common.Address{}
+	for _, addr := range addrs {
+		acc := alloc[addr]
+		if err := conf.UpdateAccount(addr, acc.Balance, acc.Nonce, acc.Code, acc.Storage); err != nil {
+			return overwritten, err
+		}
+		if existing[addr] {
+			overwritten = append(overwritten, addr)
+		}
+	}
+	return overwritten, nil
+}
+
+// applyMergeAlloc merges the --merge-alloc accounts, if given, into the configuration,
+// warning of each account which is replaced.
+func applyMergeAlloc(ctx *cli.Context, conf ctypes.GenesisBlocker) error {
+	if !ctx.GlobalIsSet(mergeAllocFlag.Name) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(ctx.GlobalString(mergeAllocFlag.Name))
+	if err != nil {
+		return err
+	}
+	alloc, err := readMergeAlloc(data)
+	if err != nil {
+		return err
+	}
+	overwritten, err := mergeAlloc(conf, alloc)
+	for _, addr := range overwritten {
+		log.Println("warning: --merge-alloc replaces genesis account", addr.Hex())
+	}
+	return err
+}
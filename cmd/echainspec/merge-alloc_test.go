@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestMergeAlloc(t *testing.T) {
+	alloc, err := readMergeAlloc([]byte(`{
+		"0x0000000000000000000000000000000000000001": {"balance": "0x10"},
+		"0000000000000000000000000000000000000abc": {"balance": "1000", "nonce": "0x1"}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"multigeth", "parity", "besu"} {
+		conf, err := echainspec.Convert(defaultChainspecValues["goerli"], format)
+		if err != nil {
+			t.Fatal(format, err)
+		}
+		overwritten, err := mergeAlloc(conf, alloc)
+		if err != nil {
+			t.Fatal(format, err)
+		}
+		// Goerli's genesis has the precompile accounts.
+		if want := []common.Address{common.HexToAddress("0x1")}; !reflect.DeepEqual(overwritten, want) {
+			t.Errorf("%s: overwritten: got %v, want %v", format, overwritten, want)
+		}
+		balances := make(map[common.Address]*big.Int)
+		conf.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+			balances[address] = bal
+			return nil
+		})
+		if got := balances[common.HexToAddress("0x1")]; got == nil || got.Int64() != 16 {
+			t.Errorf("%s: replaced account balance: got %v, want 16", format, got)
+		}
+		if got := balances[common.HexToAddress("0xabc")]; got == nil || got.Int64() != 1000 {
+			t.Errorf("%s: added account balance: got %v, want 1000", format, got)
+		}
+	}
+}
+
+func TestReadMergeAllocInvalidAddress(t *testing.T) {
+	for _, input := range []string{
+		`{"0x12": {"balance": "0x1"}}`,
+		`{"coinbase": {"balance": "0x1"}}`,
+		`{"0x00000000000000000000000000000000000000zz": {"balance": "0x1"}}`,
+	} {
+		if _, err := readMergeAlloc([]byte(input)); !errors.Is(err, errInvalidAllocAddress) {
+			t.Errorf("%s: got %v, want invalid address error", input, err)
+		}
+	}
+	// The same address may not be given twice.
+	if _, err := readMergeAlloc([]byte(`{"0x0000000000000000000000000000000000000001": {"balance": "0x1"}, "0000000000000000000000000000000000000001": {"balance": "0x2"}}`)); err == nil {
+		t.Error("want duplicate account error")
+	}
+}
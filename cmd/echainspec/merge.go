@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	mergeOverlayFlag = cli.StringFlag{
+		Name:  "overlay",
+		Usage: "Path to JSON chain configuration file whose set fields override the configuration's",
+	}
+	mergeOverlayFormatFlag = cli.StringFlag{
+		Name:  "overlayf",
+		Usage: fmt.Sprintf("Format type of the --overlay configuration [%s] (default: detected)", strings.Join(chainspecFormats, "|")),
+	}
+)
+
+var mergeCommand = cli.Command{
+	Name:  "merge",
+	Usage: "Apply the fields set by an overlay configuration to the configuration",
+	Description: `Fields which the overlay sets override the configuration's; fields which are null or absent
+from the overlay leave the configuration's values unchanged. Overlay accounts are added to (or replace)
+the genesis accounts. The overlay may be of any format; its fields are compared to those of an empty
+configuration of its format, so a field set to its format's default value is treated as absent.
+The result is written like the configuration itself, so --outputf and the other output flags apply.`,
+	Flags:  []cli.Flag{mergeOverlayFlag, mergeOverlayFormatFlag},
+	Action: merge,
+}
+
+var (
+	errMissingOverlay    = errors.New("missing --overlay configuration")
+	errConflictingEngine = errors.New("overlay consensus engine conflicts with the configuration's")
+)
+
+// genesisRequiredFields are the fields which genesis formats require, with zero values.
+var genesisRequiredFields = map[string]json.RawMessage{
+	"difficulty": json.RawMessage(`"0x0"`),
+	"gasLimit":   json.RawMessage(`"0x0"`),
+	"alloc":      json.RawMessage(`{}`),
+}
+
+// completeOverlay adds the fields which genesis formats require to a partial
+// genesis (ie. one with a config object) configuration, so that it can be read.
+func completeOverlay(data []byte) ([]byte, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if _, ok := m["config"]; !ok {
+		return data, nil
+	}
+	for k, v := range genesisRequiredFields {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+	return json.Marshal(m)
+}
+
+// readOverlay reads the --overlay configuration, returning it with its format.
+func readOverlay(ctx *cli.Context) (string, ctypes.Configurator, error) {
+	if !ctx.IsSet(mergeOverlayFlag.Name) {
+		return "", nil, errMissingOverlay
+	}
+	data, err := ioutil.ReadFile(ctx.String(mergeOverlayFlag.Name))
+	if err != nil {
+		return "", nil, err
+	}
+	if data, err = completeOverlay(data); err != nil {
+		return "", nil, err
+	}
+	if ctx.IsSet(mergeOverlayFormatFlag.Name) {
+		format := ctx.String(mergeOverlayFormatFlag.Name)
+		conf, err := readFormat(format, bytes.NewReader(data), ctx.GlobalBool(strictFlag.Name))
+		return format, conf, err
+	}
+	candidates := echainspec.Detect(data)
+	if len(candidates) == 0 {
+		return "", nil, errNoFormatDetected
+	}
+	if ctx.GlobalBool(strictFlag.Name) {
+		conf, err := readFormat(candidates[0].Format, bytes.NewReader(data), true)
+		return candidates[0].Format, conf, err
+	}
+	return candidates[0].Format, candidates[0].Conf, nil
+}
+
+// mergeConfigs sets the fields of overlay which differ from those of empty,
+// an empty configuration of the overlay's format, on base.
+// Fields which base's format does not support are returned as warnings.
+func mergeConfigs(base, overlay, empty ctypes.Configurator) ([]string, error) {
+	if t := overlay.GetConsensusEngineType(); t != empty.GetConsensusEngineType() && t != base.GetConsensusEngineType() {
+		return nil, fmt.Errorf("%w: %s, want %s", errConflictingEngine, t, base.GetConsensusEngineType())
+	}
+	interfaces := []reflect.Type{
+		reflect.TypeOf((*ctypes.CatHerder)(nil)).Elem(),
+		reflect.TypeOf((*ctypes.GenesisBlocker)(nil)).Elem(),
+	}
+	if overlay.GetConsensusEngineType() == base.GetConsensusEngineType() {
+		switch base.GetConsensusEngineType() {
+		case ctypes.ConsensusEngineT_Ethash:
+			interfaces = append(interfaces, reflect.TypeOf((*ctypes.EthashConfigurator)(nil)).Elem())
+		case ctypes.ConsensusEngineT_Clique:
+			interfaces = append(interfaces, reflect.TypeOf((*ctypes.CliqueConfigurator)(nil)).Elem())
+		}
+	}
+	warnings := []string{}
+	for _, k := range interfaces {
+		for i := 0; i < k.NumMethod(); i++ {
+			method := k.Method(i)
+			field := strings.TrimPrefix(method.Name, "Get")
+			// The sealing type is that of the genesis header fields, not a field itself.
+			if field == method.Name || field == "SealingType" || method.Type.NumIn() != 0 || method.Type.NumOut() != 1 {
+				continue
+			}
+			if _, ok := k.MethodByName("Set" + field); !ok {
+				continue
+			}
+			v := reflect.ValueOf(overlay).MethodByName(method.Name).Call(nil)[0]
+			if formatDiffValue(v.Interface()) == formatDiffValue(reflect.ValueOf(empty).MethodByName(method.Name).Call(nil)[0].Interface()) {
+				continue
+			}
+			res := reflect.ValueOf(base).MethodByName("Set" + field).Call([]reflect.Value{v})
+			if res[0].IsNil() {
+				continue
+			}
+			err := res[0].Interface().(error)
+			if ctypes.IsFatalUnsupportedErr(err) {
+				return warnings, ctypes.UnsupportedConfigError(err, field, formatDiffValue(v.Interface()))
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: %s (not supported by the configuration's format)", field, formatDiffValue(v.Interface())))
+		}
+	}
+	return warnings, overlay.ForEachAccount(base.UpdateAccount)
+}
+
+func merge(ctx *cli.Context) error {
+	format, overlay, err := readOverlay(ctx)
+	if err != nil {
+		return err
+	}
+	// The empty overlay is completed like the overlay, so that the completed fields compare equal.
+	data, err := completeOverlay([]byte(`{"config":{}}`))
+	if err != nil {
+		return err
+	}
+	empty, err := readFormat(format, bytes.NewReader(data), false)
+	if err != nil {
+		return err
+	}
+	warnings, err := mergeConfigs(globalChainspecValue, overlay, empty)
+	for _, w := range warnings {
+		log.Println("warning:", w)
+	}
+	if err != nil {
+		return err
+	}
+	// The merged configuration is written as the configuration itself would be.
+	return convertf(ctx.Parent())
+}
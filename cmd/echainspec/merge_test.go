@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+func readMergeTestConfig(t *testing.T, format string, data []byte) ctypes.Configurator {
+	t.Helper()
+	data, err := completeOverlay(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := readFormat(format, bytes.NewReader(data), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conf
+}
+
+func TestMergeConfigs(t *testing.T) {
+	// The base is read from JSON, so that the default configuration is not modified.
+	b, err := json.Marshal(params.DefaultGoerliGenesisBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := readMergeTestConfig(t, "geth", b)
+	overlay := readMergeTestConfig(t, "geth", []byte(`{
+		"config": {"chainId": 1337, "berlinBlock": 5000000, "clique": {"period": 5}},
+		"gasLimit": "0x1000000",
+		"alloc": {"0x0000000000000000000000000000000000000abc": {"balance": "0x10"}}
+	}`))
+	empty := readMergeTestConfig(t, "geth", []byte(`{"config": {}}`))
+
+	if _, err := mergeConfigs(base, overlay, empty); err != nil {
+		t.Fatal(err)
+	}
+	if got := base.GetChainID(); got.Uint64() != 1337 {
+		t.Errorf("chain ID: got %v, want 1337", got)
+	}
+	if got := base.GetEIP2929Transition(); got == nil || *got != 5000000 {
+		t.Errorf("berlin: got %v, want 5000000", got)
+	}
+	if got := base.GetCliquePeriod(); got != 5 {
+		t.Errorf("clique period: got %d, want 5", got)
+	}
+	if got := base.GetGenesisGasLimit(); got != 0x1000000 {
+		t.Errorf("gas limit: got %d, want %d", got, 0x1000000)
+	}
+	// Fields absent from the overlay are unchanged.
+	if got := base.GetEIP1344Transition(); got == nil || *got != 1561651 {
+		t.Errorf("istanbul: got %v, want 1561651", got)
+	}
+	if got := base.GetCliqueEpoch(); got != 30000 {
+		t.Errorf("clique epoch: got %d, want 30000", got)
+	}
+	if got := base.GetGenesisDifficulty(); got.Uint64() != 1 {
+		t.Errorf("difficulty: got %v, want 1", got)
+	}
+	accounts := 0
+	added := false
+	base.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		accounts++
+		if address == common.HexToAddress("0xabc") {
+			added = bal.Uint64() == 0x10
+		}
+		return nil
+	})
+	if !added {
+		t.Error("overlay account not added")
+	}
+	if want := len(params.DefaultGoerliGenesisBlock().Alloc) + 1; accounts != want {
+		t.Errorf("got %d accounts, want %d", accounts, want)
+	}
+}
+
+func TestMergeConflictingEngine(t *testing.T) {
+	b, err := json.Marshal(params.DefaultGoerliGenesisBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := readMergeTestConfig(t, "geth", b)
+	overlay := readMergeTestConfig(t, "multigeth", []byte(`{"config": {"eip155Block": 10, "ethash": {}}}`))
+	empty := readMergeTestConfig(t, "multigeth", []byte(`{"config": {}}`))
+	if _, err := mergeConfigs(base, overlay, empty); !errors.Is(err, errConflictingEngine) {
+		t.Errorf("got error %v, want %v", err, errConflictingEngine)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/multigethv0"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// migrationSchemas are the former multigeth configuration schemas which can be migrated, by name.
+var migrationSchemas = map[string]func() ctypes.ChainConfigurator{
+	"multigethv0": func() ctypes.ChainConfigurator {
+		return &multigethv0.ChainConfig{}
+	},
+}
+
+var migrateSinceFlag = cli.StringFlag{
+	Name:  "since",
+	Usage: "Former multigeth schema of the input configuration [multigethv0]",
+	Value: "multigethv0",
+}
+
+var migrateCommand = cli.Command{
+	Name:  "migrate",
+	Usage: "Upgrade a multigeth configuration of a former schema to the current schema",
+	Description: `The input genesis configuration (from --file or standard input) is read with its config in the --since
+schema, and written in the current multigeth schema; the output flags apply, but not --outputf.
+
+Each field of the former config which the current schema represents differently is reported on stderr,
+eg. 'migrated muirGlacierBlock: now represented by difficultyBombDelays, eip2384FBlock'.
+Fields which have no effect (given the others) are reported as removed, and values which the current
+schema cannot represent as dropped.`,
+	Flags:  []cli.Flag{migrateSinceFlag},
+	Action: migrate,
+}
+
+var (
+	errUnknownSchema = errors.New("unknown configuration schema")
+	errMissingConfig = errors.New("input has no genesis config")
+)
+
+// migrateGenesis returns a genesis whose config (of the given former schema) is upgraded to the current
+// multigeth schema, with a description of each field change, and any values which were dropped.
+func migrateGenesis(data []byte, schema func() ctypes.ChainConfigurator) (*genesisT.Genesis, []string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+	var old map[string]json.RawMessage
+	if err := json.Unmarshal(raw["config"], &old); err != nil || old == nil {
+		return nil, nil, errMissingConfig
+	}
+	migrated := func(config map[string]json.RawMessage) (*genesisT.Genesis, map[string]interface{}, []confp.Warning, error) {
+		b, err := json.Marshal(config)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		conf := schema()
+		if err := json.Unmarshal(b, conf); err != nil {
+			return nil, nil, nil, err
+		}
+		// The genesis reads its config by the schema it detects, which may not be the former schema.
+		gen := &genesisT.Genesis{}
+		if err := json.Unmarshal(data, gen); err != nil {
+			return nil, nil, nil, err
+		}
+		gen.Config = conf
+		out := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}, Alloc: genesisT.GenesisAlloc{}}
+		warnings, err := confp.ConvertWithWarnings(gen, out)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		fields, err := jsonFields(out.Config)
+		return out, fields, warnings, err
+	}
+	out, current, warnings, err := migrated(old)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]string, 0, len(old))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	changes := []string{}
+	for _, k := range keys {
+		var v interface{}
+		if err := json.Unmarshal(old[k], &v); err != nil {
+			return nil, nil, err
+		}
+		if reflect.DeepEqual(v, current[k]) {
+			continue
+		}
+		// The fields which replace a field are those which change when it is absent.
+		without := make(map[string]json.RawMessage, len(old)-1)
+		for kk, vv := range old {
+			if kk != k {
+				without[kk] = vv
+			}
+		}
+		_, other, _, err := migrated(without)
+		if err != nil {
+			return nil, nil, err
+		}
+		replacements := []string{}
+		for kk := range current {
+			if !reflect.DeepEqual(current[kk], other[kk]) {
+				replacements = append(replacements, kk)
+			}
+		}
+		for kk := range other {
+			if _, ok := current[kk]; !ok {
+				replacements = append(replacements, kk)
+			}
+		}
+		sort.Strings(replacements)
+		switch {
+		case len(replacements) == 0:
+			changes = append(changes, fmt.Sprintf("removed %s (no effect on the migrated configuration)", k))
+		case len(replacements) == 1 && replacements[0] == k:
+			// The field is unchanged but for its encoding.
+		default:
+			changes = append(changes, fmt.Sprintf("migrated %s: now represented by %s", k, strings.Join(replacements, ", ")))
+		}
+	}
+	for _, w := range warnings {
+		changes = append(changes, fmt.Sprintf("dropped %s", w))
+	}
+	return out, changes, nil
+}
+
+// jsonFields returns the top-level fields of a value's JSON encoding, decoded.
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(b, &fields)
+	return fields, err
+}
+
+func migrate(ctx *cli.Context) error {
+	name := ctx.String(migrateSinceFlag.Name)
+	schema, ok := migrationSchemas[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSchema, name)
+	}
+	data, err := readInputData(ctx)
+	if err != nil {
+		return err
+	}
+	out, changes, err := migrateGenesis(data, schema)
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		log.Println("migrate:", c)
+	}
+	return writeConverted(ctx, out)
+}
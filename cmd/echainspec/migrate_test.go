@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+func TestMigrateGenesis(t *testing.T) {
+	data := []byte(`{
+  "config": {
+    "chainId": 61,
+    "homesteadBlock": 1150000,
+    "eip150Block": 2500000,
+    "eip155Block": 3000000,
+    "eip160Block": 3000000,
+    "eip158Block": 8772000,
+    "byzantiumBlock": 8772000,
+    "constantinopleBlock": 9573000,
+    "petersburgBlock": 9573000,
+    "muirGlacierBlock": 9200000,
+    "istanbulBlock": 10500839,
+    "eip1884DisableFBlock": 10500839,
+    "mcip3Block": 100,
+    "ethash": {}
+  },
+  "difficulty": "0x400000000",
+  "gasLimit": "0x1388",
+  "alloc": {}
+}`)
+	gen, changes, err := migrateGenesis(data, migrationSchemas["multigethv0"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, ok := gen.Config.(*multigeth.MultiGethChainConfig)
+	if !ok {
+		t.Fatalf("got config %T, want current multigeth", gen.Config)
+	}
+	if n := conf.GetEthashEIP2384Transition(); n == nil || *n != 9200000 {
+		t.Errorf("EIP2384 transition: got %v, want 9200000", n)
+	}
+	if n := conf.GetEIP1884Transition(); n != nil {
+		t.Errorf("EIP1884 transition: got %v, want disabled", *n)
+	}
+	report := strings.Join(changes, "\n")
+	for _, want := range []string{
+		"migrated muirGlacierBlock: now represented by difficultyBombDelays, eip2384FBlock",
+		"migrated eip1884DisableFBlock: now represented by eip1884FBlock",
+		"migrated homesteadBlock: now represented by eip2FBlock, eip7FBlock",
+		"removed mcip3Block (no effect on the migrated configuration)",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report does not contain %q:\n%s", want, report)
+		}
+	}
+	// Fields of the current schema are not reported.
+	if strings.Contains(report, "eip155Block") {
+		t.Errorf("report contains unchanged field:\n%s", report)
+	}
+
+	if _, _, err := migrateGenesis([]byte(`{"difficulty": "0x1"}`), migrationSchemas["multigethv0"]); err != errMissingConfig {
+		t.Errorf("no config: got %v, want %v", err, errMissingConfig)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var newTemplateFlag = cli.StringFlag{
+	Name:  "template",
+	Usage: "Starter configuration template [pow|poa]",
+	Value: "pow",
+}
+
+var newCommand = cli.Command{
+	Name:        "new",
+	Usage:       "Print a starter multigeth configuration for a new chain",
+	Description: "The configuration enables all protocol upgrades (through London) at genesis, with an empty alloc. Edit the placeholder values before use.",
+	Flags:       []cli.Flag{newTemplateFlag},
+	Action:      newChainspec,
+}
+
+var errInvalidTemplate = errors.New("invalid template name")
+
+// templateChainID is a placeholder chain and network id for starter configurations.
+const templateChainID = 1337
+
+// newTemplateGenesis returns a starter genesis configuration with all protocol upgrades
+// (through London) enabled at genesis, using the given consensus engine.
+// Shanghai and Cancun are left unset, since they follow the merge, and neither
+// template engine (ethash, clique) is a post-merge one.
+func newTemplateGenesis(engine ctypes.ConsensusEngineT) (*genesisT.Genesis, error) {
+	gen := &genesisT.Genesis{
+		Config:   &multigeth.MultiGethChainConfig{},
+		GasLimit: vars.GenesisGasLimit,
+		Alloc:    genesisT.GenesisAlloc{},
+	}
+	conf := gen.Config
+
+	if err := conf.MustSetConsensusEngineType(engine); err != nil {
+		return nil, ctypes.UnsupportedConfigError(err, "consensus engine", engine)
+	}
+	id := uint64(templateChainID)
+	if err := conf.SetNetworkID(&id); err != nil {
+		return nil, err
+	}
+	if err := conf.SetChainID(new(big.Int).SetUint64(id)); err != nil {
+		return nil, err
+	}
+
+	setters := []func(*uint64) error{
+		conf.SetEthashHomesteadTransition,
+		conf.SetEIP7Transition,
+		conf.SetEIP150Transition,
+		conf.SetEIP155Transition,
+		conf.SetEIP160Transition,
+		conf.SetEIP161abcTransition,
+		conf.SetEIP161dTransition,
+		conf.SetEIP170Transition,
+		conf.SetEIP140Transition,
+		conf.SetEIP198Transition,
+		conf.SetEIP211Transition,
+		conf.SetEIP212Transition,
+		conf.SetEIP213Transition,
+		conf.SetEIP214Transition,
+		conf.SetEIP658Transition,
+		conf.SetEIP145Transition,
+		conf.SetEIP1014Transition,
+		conf.SetEIP1052Transition,
+		conf.SetEIP152Transition,
+		conf.SetEIP1108Transition,
+		conf.SetEIP1344Transition,
+		conf.SetEIP1884Transition,
+		conf.SetEIP2028Transition,
+		conf.SetEIP2200Transition,
+		conf.SetEIP2565Transition,
+		conf.SetEIP2718Transition,
+		conf.SetEIP2929Transition,
+		conf.SetEIP2930Transition,
+		conf.SetEIP1559Transition,
+		conf.SetEIP3529Transition,
+		conf.SetEIP3541Transition,
+	}
+
+	switch engine {
+	case ctypes.ConsensusEngineT_Ethash:
+		setters = append(setters,
+			conf.SetEthashEIP100BTransition,
+			conf.SetEthashEIP649Transition,
+			conf.SetEthashEIP1234Transition,
+			conf.SetEthashEIP2384Transition,
+			conf.SetEthashEIP3554Transition,
+		)
+		gen.Difficulty = vars.GenesisDifficulty
+	case ctypes.ConsensusEngineT_Clique:
+		if err := conf.SetCliquePeriod(15); err != nil {
+			return nil, err
+		}
+		if err := conf.SetCliqueEpoch(30000); err != nil {
+			return nil, err
+		}
+		gen.Difficulty = big.NewInt(1)
+		// Vanity, a single (placeholder) signer address, and an empty seal.
+		gen.ExtraData = cliqueExtraData(nil, nil)
+	}
+
+	genesisBlock := uint64(0)
+	for _, set := range setters {
+		if err := set(&genesisBlock); err != nil {
+			return nil, err
+		}
+	}
+	return gen, nil
+}
+
+func newChainspec(ctx *cli.Context) error {
+	var engine ctypes.ConsensusEngineT
+	switch ctx.String(newTemplateFlag.Name) {
+	case "pow":
+		engine = ctypes.ConsensusEngineT_Ethash
+	case "poa":
+		engine = ctypes.ConsensusEngineT_Clique
+	default:
+		return fmt.Errorf("%w: %s", errInvalidTemplate, ctx.String(newTemplateFlag.Name))
+	}
+	gen, err := newTemplateGenesis(engine)
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(ctx, gen); err != nil {
+		return err
+	}
+	log.Printf("note: replace the placeholder chain id (%d)", templateChainID)
+	if engine.IsClique() {
+		log.Println("note: replace the placeholder signer (zero address) in extraData")
+	}
+	return nil
+}
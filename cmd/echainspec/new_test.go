@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+func TestNewTemplateGenesis(t *testing.T) {
+	for _, engine := range []ctypes.ConsensusEngineT{
+		ctypes.ConsensusEngineT_Ethash,
+		ctypes.ConsensusEngineT_Clique,
+	} {
+		gen, err := newTemplateGenesis(engine)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := gen.GetConsensusEngineType(); got != engine {
+			t.Errorf("wrong engine, want: %s, got: %s", engine, got)
+		}
+		head := uint64(0)
+		if err := confp.IsValid(gen, &head); err != nil {
+			t.Errorf("%s template invalid: %v", engine, err)
+		}
+		if forks := confp.Forks(gen); len(forks) != 0 {
+			t.Errorf("%s template has non-genesis forks: %v", engine, forks)
+		}
+		for name, fn := range map[string]func() *uint64{
+			"EIP2200": gen.GetEIP2200Transition,
+			"EIP2929": gen.GetEIP2929Transition,
+			"EIP1559": gen.GetEIP1559Transition,
+			"EIP3541": gen.GetEIP3541Transition,
+		} {
+			if v := fn(); v == nil || *v != 0 {
+				t.Errorf("%s template %s not enabled at genesis", engine, name)
+			}
+		}
+		// Shanghai and Cancun follow the merge, so pre-merge engine templates leave them unset.
+		for name, fn := range map[string]func() *uint64{
+			"EIP3855Time": gen.GetEIP3855TransitionTime,
+			"EIP4788Time": gen.GetEIP4788TransitionTime,
+			"EIP4844Time": gen.GetEIP4844TransitionTime,
+			"EIP4895Time": gen.GetEIP4895TransitionTime,
+		} {
+			if v := fn(); v != nil {
+				t.Errorf("%s template %s enabled, want unset", engine, name)
+			}
+		}
+	}
+}
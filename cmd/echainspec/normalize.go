@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var normalizeCommand = cli.Command{
+	Name:  "normalize",
+	Usage: "Print the configuration in a canonical JSON form of its format",
+	Description: `Semantically identical configurations of a format are printed identically:
+object keys are sorted, hex values are lowercase and minimally encoded,
+and fork blocks which the format infers (eg. from difficulty bomb delay schedules) are written explicitly.
+Normalizing a normalized configuration does not change it.
+With --show-defaults, every fork field is written, as it is for conversion.`,
+	Action: normalize,
+}
+
+var (
+	// hexStringRe matches 0x-prefixed hex strings.
+	hexStringRe = regexp.MustCompile(`^0[xX][0-9a-fA-F]*$`)
+	// hexKeyRe matches object keys which are hex, ie. addresses, hashes (eg. storage keys), and numbers.
+	hexKeyRe = regexp.MustCompile(`^(0[xX][0-9a-fA-F]+|[0-9a-fA-F]{40}|[0-9a-fA-F]{64})$`)
+)
+
+// normalizeConfig returns the canonical JSON encoding of a configuration, indented unless compact.
+// Numeric values are minimally encoded by the configuration's data type.
+// If showDefaults, every fork field is written, and the sources of their values are listed on stderr.
+func normalizeConfig(conf ctypes.Configurator, compact, showDefaults bool) ([]byte, error) {
+	// Getters fill in transitions which the data type infers.
+	fns, _ := confp.Transitions(conf)
+	for _, fn := range fns {
+		fn()
+	}
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if showDefaults {
+		sources, err := fillForkDefaults(conf, v)
+		if err != nil {
+			return nil, err
+		}
+		logForkFieldSources(sources)
+	}
+	// Maps are marshaled with sorted keys.
+	v = lowercaseHex(v)
+	if compact {
+		b, err = json.Marshal(v)
+	} else {
+		b, err = jsonMarshalPretty(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// lowercaseHex lowercases the hex strings and object keys of a decoded JSON value.
+func lowercaseHex(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		if hexStringRe.MatchString(t) {
+			return strings.ToLower(t)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = lowercaseHex(t[i])
+		}
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, vv := range t {
+			if hexKeyRe.MatchString(k) {
+				k = strings.ToLower(k)
+			}
+			m[k] = lowercaseHex(vv)
+		}
+		return m
+	}
+	return v
+}
+
+func normalize(ctx *cli.Context) error {
+	b, err := normalizeConfig(globalChainspecValue, ctx.GlobalBool(compactFlag.Name), ctx.GlobalBool(showDefaultsFlag.Name))
+	if err != nil {
+		return err
+	}
+	return writeOutputData(ctx, b)
+}
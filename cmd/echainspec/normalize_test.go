@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestNormalizeConfig(t *testing.T) {
+	specs := []string{
+		`{"name": "test", "engine": {"Ethash": {"params": {"minimumDifficulty": "0x20000", "difficultyBoundDivisor": "0x800", "durationLimit": "0xd", "blockReward": "0x4563918244F40000", "homesteadTransition": "0x0", "difficultyBombDelays": {"0x0": "0x2dc6c0"}}}},
+			"params": {"gasLimitBoundDivisor": "0x400", "maximumExtraDataSize": "0x20", "minGasLimit": "0x1388", "networkID": "0x0A", "eip155Transition": "0x00"},
+			"genesis": {"seal": {"ethereum": {"nonce": "0x0000000000000042", "mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000"}}, "difficulty": "0x400", "gasLimit": "0x1388"},
+			"accounts": {"0x00000000000000000000000000000000000000AB": {"balance": "0x01"}}}`,
+		`{"accounts": {"00000000000000000000000000000000000000ab": {"balance": "1"}},
+			"genesis": {"gasLimit": "5000", "difficulty": "1024", "seal": {"ethereum": {"mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000", "nonce": "0x0000000000000042"}}},
+			"params": {"eip155Transition": "0x0", "networkID": "0xa", "minGasLimit": "0x1388", "maximumExtraDataSize": "0x20", "gasLimitBoundDivisor": "0x400"},
+			"engine": {"Ethash": {"params": {"difficultyBombDelays": {"0": "0x2DC6C0"}, "homesteadTransition": "0x0", "blockReward": "0x4563918244f40000", "durationLimit": "0xd", "difficultyBoundDivisor": "0x800", "minimumDifficulty": "0x20000"}}},
+			"name": "test"}`,
+	}
+	var want []byte
+	for i, s := range specs {
+		conf, err := echainspec.Read("parity", strings.NewReader(s))
+		if err != nil {
+			t.Fatalf("spec %d: %v", i, err)
+		}
+		got, err := normalizeConfig(conf, false, false)
+		if err != nil {
+			t.Fatalf("spec %d: %v", i, err)
+		}
+		if want == nil {
+			want = got
+		} else if !bytes.Equal(got, want) {
+			t.Errorf("spec %d: got\n%s\nwant\n%s", i, got, want)
+		}
+	}
+	if bytes.Contains(want, []byte("AB")) || bytes.Contains(want, []byte("2DC6C0")) {
+		t.Errorf("got uppercase hex: %s", want)
+	}
+
+	// Normalizing is idempotent.
+	conf, err := echainspec.Read("parity", bytes.NewReader(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := normalizeConfig(conf, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, want) {
+		t.Errorf("not idempotent: got\n%s\nwant\n%s", again, want)
+	}
+}
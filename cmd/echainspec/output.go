@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Alloc (account) key formats.
+// Go-ethereum (and so multigeth) genesis marshaling, as expected by 'geth init',
+// writes unprefixed lowercase hex addresses.
+const (
+	allocKeysUnprefixed = "unprefixed"
+	allocKeysPrefixed   = "prefixed"
+	allocKeysChecksum   = "checksum"
+)
+
+var (
+	allocKeyFormatFlag = cli.StringFlag{
+		Name:  "alloc-key-format",
+		Usage: fmt.Sprintf("Address format for genesis alloc (account) keys of geth, multigeth, and besu output [%s]", strings.Join([]string{allocKeysUnprefixed, allocKeysPrefixed, allocKeysChecksum}, "|")),
+		Value: allocKeysUnprefixed,
+	}
+	outputClientVersionFlag = cli.StringFlag{
+		Name:  "output-client-version",
+		Usage: "Client version (<client>[/<version>], eg. geth/1.10.26) whose genesis marshaling alloc keys are written in, unless --alloc-key-format is set",
+	}
+)
+
+var errUnknownClientVersion = errors.New("unknown output client version")
+
+// clientAllocKeyFormat is the alloc key format which a client writes with its genesis marshaling,
+// from a version onwards.
+type clientAllocKeyFormat struct {
+	Since  string
+	Format string
+}
+
+// clientAllocKeyFormats are the alloc key formats of client versions' genesis marshaling, by client,
+// ordered by version. A version uses the format of the last entry at or below it;
+// versions before the first entry have no genesis marshaling.
+var clientAllocKeyFormats = map[string][]clientAllocKeyFormat{
+	// Genesis marshaling was generated by gencodec from 1.6.0, with common.UnprefixedAddress keys.
+	"geth": {{"1.6.0", allocKeysUnprefixed}},
+	// Forks of go-ethereum after 1.6.0, with its genesis marshaling.
+	"multigeth": {{"0", allocKeysUnprefixed}},
+	"core-geth": {{"0", allocKeysUnprefixed}},
+}
+
+// parseVersion parses a dot-separated numeric version, with an optional 'v' prefix.
+func parseVersion(s string) ([]int, error) {
+	var v []int
+	for _, p := range strings.Split(strings.TrimPrefix(s, "v"), ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version: %q", s)
+		}
+		v = append(v, n)
+	}
+	return v, nil
+}
+
+// compareVersions returns -1, 0, or 1 as version a is less than, equal to, or greater than b.
+// Missing components are zero.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// clientVersionAllocKeyFormat returns the alloc key format of a client version, as <client>[/<version>].
+// Without a version, the latest is used.
+func clientVersionAllocKeyFormat(s string) (string, error) {
+	client, version := s, ""
+	if i := strings.Index(s, "/"); i >= 0 {
+		client, version = s[:i], s[i+1:]
+	}
+	formats, ok := clientAllocKeyFormats[strings.ToLower(client)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s (known clients: %s)", errUnknownClientVersion, s, strings.Join(knownAllocKeyClients(), ", "))
+	}
+	if version == "" {
+		return formats[len(formats)-1].Format, nil
+	}
+	v, err := parseVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errUnknownClientVersion, err)
+	}
+	format := ""
+	for _, f := range formats {
+		since, _ := parseVersion(f.Since)
+		if compareVersions(v, since) >= 0 {
+			format = f.Format
+		}
+	}
+	if format == "" {
+		return "", fmt.Errorf("%w: %s (no genesis marshaling before %s)", errUnknownClientVersion, s, formats[0].Since)
+	}
+	return format, nil
+}
+
+// knownAllocKeyClients returns the clients of the alloc key format table, sorted.
+func knownAllocKeyClients() []string {
+	clients := make([]string, 0, len(clientAllocKeyFormats))
+	for c := range clientAllocKeyFormats {
+		clients = append(clients, c)
+	}
+	sort.Strings(clients)
+	return clients
+}
+
+// allocKeyFormat returns the alloc key format to write output with:
+// --alloc-key-format if set, otherwise that of --output-client-version, if set.
+func allocKeyFormat(ctx *cli.Context) (string, error) {
+	if !ctx.GlobalIsSet(allocKeyFormatFlag.Name) && ctx.GlobalIsSet(outputClientVersionFlag.Name) {
+		return clientVersionAllocKeyFormat(ctx.GlobalString(outputClientVersionFlag.Name))
+	}
+	return ctx.GlobalString(allocKeyFormatFlag.Name), nil
+}
+
+var compactFlag = cli.BoolFlag{
+	Name:  "compact",
+	Usage: "Write JSON output on a single line, without indentation",
+}
+
+var indentFlag = cli.StringFlag{
+	Name:  "indent",
+	Usage: "Indentation of (not --compact) JSON output: a number of spaces from 0 to 8, or 'tab'",
+	Value: "4",
+}
+
+var errInvalidIndent = errors.New("invalid indent")
+
+// maxIndent is the greatest number of spaces which --indent accepts.
+const maxIndent = 8
+
+// jsonIndent is the indentation of pretty JSON output, as configured by --indent.
+var jsonIndent = "    "
+
+// parseIndent returns the indentation of an --indent value.
+func parseIndent(s string) (string, error) {
+	if s == "tab" {
+		return "\t", nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > maxIndent {
+		return "", fmt.Errorf("%w: %q (want a number of spaces from 0 to %d, or 'tab')", errInvalidIndent, s, maxIndent)
+	}
+	return strings.Repeat(" ", n), nil
+}
+
+// setupIndent configures the indentation of pretty JSON output with --indent.
+func setupIndent(ctx *cli.Context) error {
+	indent, err := parseIndent(ctx.GlobalString(indentFlag.Name))
+	if err != nil {
+		return err
+	}
+	jsonIndent = indent
+	return nil
+}
+
+var jsonFlag = cli.BoolFlag{
+	Name:  "json",
+	Usage: "Print the results of the forks, ips, rewards, and genesis-params commands as JSON",
+}
+
+// printJSON prints a command's results as JSON, indented unless --compact is set.
+func printJSON(ctx *cli.Context, v interface{}) error {
+	var (
+		b   []byte
+		err error
+	)
+	if ctx.GlobalBool(compactFlag.Name) {
+		b, err = json.Marshal(v)
+	} else {
+		b, err = jsonMarshalPretty(v)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+var (
+	outFileFlag = cli.StringFlag{
+		Name:  "outfile",
+		Usage: "Path to write the output configuration to, creating parent directories if needed (default: stdout)",
+	}
+	outFileForceFlag = cli.BoolFlag{
+		Name:  "force",
+		Usage: "Overwrite the --outfile file if it exists",
+	}
+)
+
+var errInvalidAllocKeyFormat = errors.New("invalid alloc key format")
+var errOutFileExists = errors.New("output file exists (use --force to overwrite)")
+
+// allocKeyRe matches alloc keys which are addresses.
+var allocKeyRe = regexp.MustCompile(`^(?:0x)?([0-9a-fA-F]{40})$`)
+
+// formatAllocKeys rewrites the address keys of the top-level 'alloc' object (of genesis formats)
+// of marshaled JSON to the given format, returning it compacted. Other address-keyed objects
+// (eg. Parity's 'accounts') are written by their format, so are not changed.
+// The alloc is written with sorted keys, as by go-ethereum.
+func formatAllocKeys(b []byte, format string) ([]byte, error) {
+	var fn func(a common.Address) string
+	switch format {
+	case allocKeysUnprefixed:
+		fn = func(a common.Address) string {
+			return fmt.Sprintf("%x", a.Bytes())
+		}
+	case allocKeysPrefixed:
+		fn = func(a common.Address) string {
+			return strings.ToLower(a.Hex())
+		}
+	case allocKeysChecksum:
+		fn = func(a common.Address) string {
+			return a.Hex()
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidAllocKeyFormat, format)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		// Not an object, so there is no alloc.
+		return b, nil
+	}
+	out := new(bytes.Buffer)
+	out.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		if key := tok.(string); key == "alloc" && bytes.HasPrefix(v, []byte("{")) {
+			var alloc map[string]json.RawMessage
+			if err := json.Unmarshal(v, &alloc); err != nil {
+				return nil, err
+			}
+			formatted := make(map[string]json.RawMessage, len(alloc))
+			for k, acc := range alloc {
+				if m := allocKeyRe.FindStringSubmatch(k); m != nil {
+					k = fn(common.HexToAddress(m[1]))
+				}
+				formatted[k] = acc
+			}
+			if v, err = json.Marshal(formatted); err != nil {
+				return nil, err
+			}
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		k, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(k)
+		out.WriteByte(':')
+		out.Write(v)
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+// indentOutputJSON indents (by --indent) compact JSON output unless compact, ending it with a newline.
+func indentOutputJSON(b []byte, compact bool) ([]byte, error) {
+	if compact {
+		return append(b, '\n'), nil
+	}
+	buf := new(bytes.Buffer)
+	if err := json.Indent(buf, b, "", jsonIndent); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// marshalOutputJSON marshals a configuration as JSON, indented (by --indent) unless compact.
+// Either way, the output ends with a newline.
+func marshalOutputJSON(v ctypes.Configurator, compact bool) ([]byte, error) {
+	if !compact {
+		b, err := jsonMarshalPretty(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeOutput writes a configuration value to standard output, or to the --outfile file.
+func writeOutput(ctx *cli.Context, v ctypes.Configurator) error {
+	b, err := marshalOutput(ctx, v)
+	if err != nil {
+		return err
+	}
+	return writeOutputData(ctx, b)
+}
+
+// marshalOutput marshals a configuration value with the --output-serialization format.
+// Alloc key formatting applies to JSON and YAML output only.
+func marshalOutput(ctx *cli.Context, v ctypes.Configurator) ([]byte, error) {
+	f := ctx.GlobalString(outputSerializationFlag.Name)
+	if ctx.GlobalBool(showDefaultsFlag.Name) && f != outputFormatJSON {
+		return nil, errShowDefaultsSerialization
+	}
+	if f == outputFormatTOML {
+		return tomlMarshal(v)
+	}
+	if f != outputFormatJSON && f != outputFormatYAML {
+		return nil, fmt.Errorf("%w: %s", errInvalidOutputSerialization, f)
+	}
+	format, err := allocKeyFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	if ctx.GlobalBool(showDefaultsFlag.Name) {
+		b, err = marshalShowDefaults(v, true)
+		b = bytes.TrimSuffix(b, []byte("\n"))
+	} else {
+		b, err = json.Marshal(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if b, err = formatAllocKeys(b, format); err != nil {
+		return nil, err
+	}
+	if f == outputFormatYAML {
+		return yamlMarshal(json.RawMessage(b))
+	}
+	return indentOutputJSON(b, ctx.GlobalBool(compactFlag.Name))
+}
+
+// writeOutputData writes output to standard output, or to the --outfile file.
+// An existing file is only overwritten if --force is set.
+func writeOutputData(ctx *cli.Context, b []byte) error {
+	if !ctx.GlobalIsSet(outFileFlag.Name) {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+	return writeOutFile(ctx.GlobalString(outFileFlag.Name), b, ctx.GlobalBool(outFileForceFlag.Name))
+}
+
+func writeOutFile(path string, b []byte, force bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if os.IsExist(err) {
+		return fmt.Errorf("%w: %s", errOutFileExists, path)
+	} else if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// TestFormatAllocKeysUpstream tests that the default alloc key format is identical to
+// go-ethereum's genesis marshaling, whose alloc is keyed by common.UnprefixedAddress.
+func TestFormatAllocKeysUpstream(t *testing.T) {
+	gen := params.DefaultGenesisBlock()
+	b, err := json.Marshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := formatAllocKeys(b, allocKeyFormatFlag.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Alloc json.RawMessage `json:"alloc"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	upstream := make(map[common.UnprefixedAddress]genesisT.GenesisAccount, len(gen.Alloc))
+	for a, acc := range gen.Alloc {
+		upstream[common.UnprefixedAddress(a)] = acc
+	}
+	want, err := json.Marshal(upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Alloc, want) {
+		t.Error("default alloc key format differs from upstream marshaling")
+	}
+}
+
+// TestFormatAllocKeysOnlyAlloc tests that only the keys of the genesis alloc are formatted.
+func TestFormatAllocKeysOnlyAlloc(t *testing.T) {
+	in := []byte(`{"config":{"requireBlockHashes":{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":"x"}},"accounts":{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":{}},"alloc":{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":{"balance":"0x1"}}}`)
+	want := `{"config":{"requireBlockHashes":{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":"x"}},"accounts":{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":{}},"alloc":{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed":{"balance":"0x1"}}}`
+	got, err := formatAllocKeys(in, allocKeysChecksum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestClientVersionAllocKeyFormat(t *testing.T) {
+	for s, want := range map[string]string{
+		"geth":           allocKeysUnprefixed,
+		"geth/1.6.0":     allocKeysUnprefixed,
+		"geth/v1.10.26":  allocKeysUnprefixed,
+		"core-geth/1.12": allocKeysUnprefixed,
+	} {
+		got, err := clientVersionAllocKeyFormat(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if got != want {
+			t.Errorf("%s: got %s, want %s", s, got, want)
+		}
+	}
+	for _, s := range []string{"geth/1.5.9", "geth/x", "parity/2.7"} {
+		if _, err := clientVersionAllocKeyFormat(s); !errors.Is(err, errUnknownClientVersion) {
+			t.Errorf("%s: want %v, got %v", s, errUnknownClientVersion, err)
+		}
+	}
+}
+
+func TestFormatAllocKeys(t *testing.T) {
+	addr := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	gen := &genesisT.Genesis{
+		Config:     params.DefaultGenesisBlock().Config,
+		Difficulty: common.Big1,
+		Alloc:      genesisT.GenesisAlloc{addr: {Balance: common.Big1}},
+	}
+	b, err := json.Marshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for format, key := range map[string]string{
+		allocKeysUnprefixed: "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		allocKeysPrefixed:   "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		allocKeysChecksum:   "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	} {
+		out, err := formatAllocKeys(b, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m struct {
+			Alloc map[string]json.RawMessage `json:"alloc"`
+		}
+		if err := json.Unmarshal(out, &m); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := m.Alloc[key]; !ok || len(m.Alloc) != 1 {
+			t.Errorf("%s: want key %s, got: %v", format, key, m.Alloc)
+		}
+		// All formats must be readable as a genesis.
+		var g genesisT.Genesis
+		if err := json.Unmarshal(out, &g); err != nil {
+			t.Errorf("%s: %v", format, err)
+		} else if _, ok := g.Alloc[addr]; !ok {
+			t.Errorf("%s: address not read", format)
+		}
+	}
+	if _, err := formatAllocKeys(b, "upper"); err == nil {
+		t.Error("want error for invalid format")
+	}
+}
+
+func TestMarshalOutputJSONCompact(t *testing.T) {
+	gen := params.DefaultGoerliGenesisBlock()
+	pretty, err := marshalOutputJSON(gen, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compact, err := marshalOutputJSON(gen, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(compact, []byte("\n")); n != 1 || !bytes.HasSuffix(compact, []byte("\n")) {
+		t.Errorf("compact output has %d lines, want 1", n)
+	}
+	var want bytes.Buffer
+	if err := json.Compact(&want, pretty); err != nil {
+		t.Fatal(err)
+	}
+	if got := bytes.TrimSpace(compact); !bytes.Equal(got, want.Bytes()) {
+		t.Error("compact output differs from indented output")
+	}
+}
+
+func TestWriteOutFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "echainspec-outfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "nested", "out.json")
+	if err := writeOutFile(path, []byte("first\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeOutFile(path, []byte("second\n"), false); !errors.Is(err, errOutFileExists) {
+		t.Fatalf("want %v, got %v", errOutFileExists, err)
+	}
+	if err := writeOutFile(path, []byte("2\n"), true); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "2\n" {
+		t.Errorf("got %q, want %q", b, "2\n")
+	}
+}
+
+func TestParseIndent(t *testing.T) {
+	for s, want := range map[string]string{"tab": "\t", "0": "", "2": "  ", "4": "    "} {
+		got, err := parseIndent(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if got != want {
+			t.Errorf("%s: got %q, want %q", s, got, want)
+		}
+	}
+	for _, s := range []string{"", "-1", "9", "tabs", "2.5"} {
+		if _, err := parseIndent(s); !errors.Is(err, errInvalidIndent) {
+			t.Errorf("%q: want %v, got %v", s, errInvalidIndent, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var precompilesCommand = cli.Command{
+	Name:      "precompiles",
+	Usage:     "List precompiled contracts active at a block",
+	ArgsUsage: "<0x042|0x42|42>",
+	Action:    precompiles,
+}
+
+// precompileInfo names a precompiled contract and the EIP which introduced it.
+type precompileInfo struct {
+	Name string
+	EIP  string
+}
+
+var precompileInfos = map[common.Address]precompileInfo{
+	common.BytesToAddress([]byte{1}): {"ecrecover", "-"},
+	common.BytesToAddress([]byte{2}): {"sha256", "-"},
+	common.BytesToAddress([]byte{3}): {"ripemd160", "-"},
+	common.BytesToAddress([]byte{4}): {"identity", "-"},
+	common.BytesToAddress([]byte{5}): {"modexp", "EIP-198"},
+	common.BytesToAddress([]byte{6}): {"bn256Add", "EIP-196"},
+	common.BytesToAddress([]byte{7}): {"bn256ScalarMul", "EIP-196"},
+	common.BytesToAddress([]byte{8}): {"bn256Pairing", "EIP-197"},
+	common.BytesToAddress([]byte{9}): {"blake2f", "EIP-152"},
+}
+
+// activePrecompiles returns the sorted addresses of the precompiled contracts
+// which the EVM makes available at block n.
+func activePrecompiles(conf ctypes.ChainConfigurator, n *big.Int) []common.Address {
+	addrs := []common.Address{}
+	for addr := range vm.PrecompiledContractsForConfig(conf, n) {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Hex() < addrs[j].Hex()
+	})
+	return addrs
+}
+
+func precompiles(ctx *cli.Context) error {
+	n, err := parseBlockArg(ctx)
+	if err != nil {
+		return err
+	}
+	for _, addr := range activePrecompiles(globalChainspecValue, n) {
+		info, ok := precompileInfos[addr]
+		if !ok {
+			info = precompileInfo{"unknown", "-"}
+		}
+		fmt.Println(addr.Hex(), info.Name, info.EIP)
+	}
+	return nil
+}
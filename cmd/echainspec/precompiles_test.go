@@ -0,0 +1,32 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestActivePrecompiles(t *testing.T) {
+	foundation := defaultChainspecValues["foundation"]
+	for _, c := range []struct {
+		block uint64
+		want  int
+	}{
+		{0, 4},
+		{4370000, 8}, // Byzantium
+		{9069000, 9}, // Istanbul
+	} {
+		got := activePrecompiles(foundation, new(big.Int).SetUint64(c.block))
+		if len(got) != c.want {
+			t.Errorf("block %d: got %d precompiles, want %d", c.block, len(got), c.want)
+		}
+		for _, addr := range got {
+			if _, ok := precompileInfos[addr]; !ok {
+				t.Errorf("block %d: unnamed precompile %s", c.block, addr.Hex())
+			}
+		}
+	}
+	// Classic activated the Byzantium precompiles at Atlantis.
+	if got := activePrecompiles(defaultChainspecValues["classic"], big.NewInt(8772000)); len(got) != 8 {
+		t.Errorf("classic: got %d precompiles at Atlantis, want 8", len(got))
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var rewardsErasFlag = cli.Uint64Flag{
+	Name:  "eras",
+	Usage: "Number of ECIP-1017 eras to list (the schedule is unbounded)",
+	Value: 10,
+}
+
+var rewardsCommand = cli.Command{
+	Name:  "rewards",
+	Usage: "List the block reward schedule: activation block and base block reward (wei)",
+	Description: `Rewards are read from the configured reward forks (EIP-649, EIP-1234),
+block reward schedule, and ECIP-1017 era length.
+Uncle and inclusion rewards are not shown.
+Non-ethash configurations have no block reward.
+
+With --json, eras are printed as an array of {"block": <block>, "reward": "<wei>"} objects;
+rewards are decimal strings, since they exceed the integer precision of many JSON parsers.`,
+	Flags:  []cli.Flag{rewardsErasFlag},
+	Action: rewards,
+}
+
+var errMissingEraRounds = errors.New("ECIP-1017 transition is configured without an era length")
+
+// rewardEra is a block number from which a base block reward applies.
+type rewardEra struct {
+	Block  uint64
+	Reward *big.Int
+}
+
+// MarshalJSON implements json.Marshaler, writing the reward as a decimal string.
+func (e rewardEra) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Block  uint64 `json:"block"`
+		Reward string `json:"reward"`
+	}{e.Block, e.Reward.String()})
+}
+
+// blockRewardAt returns the base block reward (excluding uncle inclusion rewards) at block n.
+// Like ethash's reward accumulation, ECIP-1017 era rewards take precedence over other reward configuration.
+func blockRewardAt(conf ctypes.ChainConfigurator, n uint64) *big.Int {
+	bn := new(big.Int).SetUint64(n)
+	if conf.IsForked(conf.GetEthashECIP1017Transition, bn) {
+		era := ethash.GetBlockEra(bn, new(big.Int).SetUint64(*conf.GetEthashECIP1017EraRounds()))
+		return ethash.GetBlockWinnerRewardByEra(era, vars.FrontierBlockReward)
+	}
+	return ctypes.EthashBlockReward(conf, bn)
+}
+
+// rewardSchedule returns the base block reward eras of a configuration, beginning at genesis.
+// Only the first maxEras ECIP-1017 eras are included.
+func rewardSchedule(conf ctypes.ChainConfigurator, maxEras uint64) ([]rewardEra, error) {
+	if !conf.GetConsensusEngineType().IsEthash() {
+		return []rewardEra{{Block: 0, Reward: new(big.Int)}}, nil
+	}
+	candidates := []uint64{0}
+	for _, fn := range []func() *uint64{conf.GetEthashEIP649Transition, conf.GetEthashEIP1234Transition} {
+		if n := fn(); n != nil {
+			candidates = append(candidates, *n)
+		}
+	}
+	for n := range conf.GetEthashBlockRewardSchedule() {
+		candidates = append(candidates, n)
+	}
+	if t := conf.GetEthashECIP1017Transition(); t != nil {
+		eraLen := conf.GetEthashECIP1017EraRounds()
+		if eraLen == nil || *eraLen == 0 {
+			return nil, errMissingEraRounds
+		}
+		candidates = append(candidates, *t)
+		// An era begins at the block following each multiple of the era length.
+		for i := uint64(1); i < maxEras; i++ {
+			candidates = append(candidates, *eraLen*i+1)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i] < candidates[j]
+	})
+	eras := []rewardEra{}
+	for _, n := range candidates {
+		r := blockRewardAt(conf, n)
+		if len(eras) > 0 && eras[len(eras)-1].Reward.Cmp(r) == 0 {
+			continue
+		}
+		eras = append(eras, rewardEra{Block: n, Reward: r})
+	}
+	return eras, nil
+}
+
+func rewards(ctx *cli.Context) error {
+	eras, err := rewardSchedule(globalChainspecValue, ctx.Uint64(rewardsErasFlag.Name))
+	if err != nil {
+		return err
+	}
+	if ctx.GlobalBool(jsonFlag.Name) {
+		return printJSON(ctx, eras)
+	}
+	p := newStdoutPrinter(ctx)
+	for _, e := range eras {
+		p.Print(e.Block, e.Reward)
+	}
+	return p.Flush()
+}
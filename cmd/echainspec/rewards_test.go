@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestRewardSchedule(t *testing.T) {
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{"classic", []string{
+			"0 5000000000000000000",
+			"5000001 4000000000000000000",
+			"10000001 3200000000000000000",
+		}},
+		{"foundation", []string{
+			"0 5000000000000000000",
+			"4370000 3000000000000000000",
+			"7280000 2000000000000000000",
+		}},
+		{"goerli", []string{
+			"0 0",
+		}},
+	}
+	for _, c := range cases {
+		eras, err := rewardSchedule(defaultChainspecValues[c.name], 3)
+		if err != nil {
+			t.Fatal(c.name, err)
+		}
+		if len(eras) != len(c.want) {
+			t.Fatalf("%s: got %d eras, want %d: %v", c.name, len(eras), len(c.want), eras)
+		}
+		for i, e := range eras {
+			if got := fmt.Sprint(e.Block, " ", e.Reward); got != c.want[i] {
+				t.Errorf("%s: era %d: got %s, want %s", c.name, i, got, c.want[i])
+			}
+		}
+	}
+}
+
+func TestRewardScheduleNoForks(t *testing.T) {
+	conf := &goethereum.ChainConfig{Ethash: new(ctypes.EthashConfig)}
+	eras, err := rewardSchedule(conf, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eras) != 1 || eras[0].Block != 0 || eras[0].Reward.Cmp(vars.FrontierBlockReward) != 0 {
+		t.Errorf("got %v, want genesis reward only", eras)
+	}
+}
+
+func TestRewardEraJSON(t *testing.T) {
+	b, err := json.Marshal([]rewardEra{{Block: 5000001, Reward: vars.FrontierBlockReward}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `[{"block":5000001,"reward":"5000000000000000000"}]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
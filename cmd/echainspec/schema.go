@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var schemaCommand = cli.Command{
+	Name:      "schema",
+	Usage:     "Print a JSON Schema (draft-07) of a format's configurations",
+	ArgsUsage: "<format>",
+	Description: `The schema is generated from the format's data type, so it describes the fields the tool reads:
+their names, types, and which are required. Values which may take several forms (eg. numbers
+which may be hex strings) are not constrained. Unknown fields are allowed, as they are when reading
+without --strict. No configuration is read.`,
+	Action: schema,
+}
+
+var errMissingFormatArg = errors.New("missing format argument")
+
+func schema(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errMissingFormatArg
+	}
+	s, err := echainspec.Schema(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	return printJSON(ctx, s)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var selectFlag = cli.StringFlag{
+	Name:  "select",
+	Usage: "Instead of the whole output configuration, print as JSON only the value at a dot-separated path of it (eg. config.chainId)",
+}
+
+var (
+	errInvalidSelectPath   = errors.New("invalid --select path")
+	errSelectSerialization = errors.New("--select is only supported with JSON output")
+)
+
+// selectJSONPath returns the value at a dot-separated path of a (decoded JSON) tree.
+// Path elements are object keys, or indexes of arrays. If the path does not exist,
+// the error names the longest prefix of it which does.
+func selectJSONPath(tree interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: empty path", errInvalidSelectPath)
+	}
+	v := tree
+	keys := strings.Split(path, ".")
+	for i, k := range keys {
+		var ok bool
+		switch t := v.(type) {
+		case map[string]interface{}:
+			v, ok = t[k]
+		case []interface{}:
+			n, err := strconv.Atoi(k)
+			if ok = err == nil && n >= 0 && n < len(t); ok {
+				v = t[n]
+			}
+		}
+		if !ok {
+			prefix := strings.Join(keys[:i], ".")
+			if prefix == "" {
+				return nil, fmt.Errorf("%w: %q: the configuration has no %q", errInvalidSelectPath, path, k)
+			}
+			return nil, fmt.Errorf("%w: %q: %q has no %q", errInvalidSelectPath, path, prefix, k)
+		}
+	}
+	return v, nil
+}
+
+// printSelected prints the value at the --select path of v (by its JSON encoding) as JSON.
+func printSelected(ctx *cli.Context, v interface{}) error {
+	tree, err := decodeJSONTree(v)
+	if err != nil {
+		return err
+	}
+	selected, err := selectJSONPath(tree, ctx.GlobalString(selectFlag.Name))
+	if err != nil {
+		return err
+	}
+	return printJSON(ctx, selected)
+}
+
+// printSelectedConfig prints the value at the --select path of an output configuration as JSON,
+// with every fork field if --show-defaults is set.
+func printSelectedConfig(ctx *cli.Context, conf ctypes.Configurator) error {
+	if f := ctx.GlobalString(outputSerializationFlag.Name); f != outputFormatJSON {
+		return errSelectSerialization
+	}
+	if !ctx.GlobalBool(showDefaultsFlag.Name) {
+		return printSelected(ctx, conf)
+	}
+	tree, err := decodeJSONTree(conf)
+	if err != nil {
+		return err
+	}
+	sources, err := fillForkDefaults(conf, tree)
+	if err != nil {
+		return err
+	}
+	logForkFieldSources(sources)
+	return printSelected(ctx, tree)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSelectJSONPath(t *testing.T) {
+	tree, err := decodeJSONTree(defaultChainspecValues["classic"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := selectJSONPath(tree, "config.chainId")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != json.Number("61") {
+		t.Errorf("config.chainId: got %v, want 61", got)
+	}
+	if _, err := selectJSONPath(tree, "alloc"); err != nil {
+		t.Errorf("alloc: %v", err)
+	}
+	list := []interface{}{map[string]interface{}{"a": "b"}}
+	if got, err := selectJSONPath(map[string]interface{}{"l": list}, "l.0.a"); err != nil || got != "b" {
+		t.Errorf("array index: got %v, %v, want b", got, err)
+	}
+
+	for _, c := range []struct {
+		path, prefix string
+	}{
+		{"config.chainid", `"config" has no "chainid"`},
+		{"config.chainId.x", `"config.chainId" has no "x"`},
+		{"nope", `the configuration has no "nope"`},
+		{"", "empty path"},
+	} {
+		_, err := selectJSONPath(tree, c.path)
+		if !errors.Is(err, errInvalidSelectPath) || !strings.Contains(err.Error(), c.prefix) {
+			t.Errorf("%q: got %v, want error with %q", c.path, err, c.prefix)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var showDefaultsFlag = cli.BoolFlag{
+	Name:  "show-defaults",
+	Usage: "Write every fork field of the (JSON) output configuration with its effective value, null if it never activates, and list on stderr which were explicit or defaulted",
+}
+
+var errShowDefaultsSerialization = errors.New("--show-defaults is only supported with JSON output")
+
+// Sources of the fork field values written with --show-defaults.
+const (
+	forkFieldExplicit  = "explicit"  // The configuration sets the field.
+	forkFieldDefaulted = "defaulted" // The data type infers the field from others.
+	forkFieldNever     = "never"     // The field is unset, so never activates.
+)
+
+// forkFieldSource is the source of the value of a fork field, named by its dot-separated JSON path.
+type forkFieldSource struct {
+	Path   string
+	Source string
+}
+
+// forkFieldSentinel is a transition value which no default configuration uses,
+// set to find the JSON field a transition is written to.
+const forkFieldSentinel = 7777777
+
+// decodeJSONTree returns the JSON encoding of v as a tree of maps, slices, and values,
+// with numbers as written.
+func decodeJSONTree(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree interface{}
+	err = dec.Decode(&tree)
+	return tree, err
+}
+
+// jsonTreeLeaves returns the values of the leaves (non-object values) of a JSON tree, by dot-separated path.
+func jsonTreeLeaves(tree interface{}) map[string]interface{} {
+	leaves := make(map[string]interface{})
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			leaves[prefix] = v
+			return
+		}
+		for k, vv := range m {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			walk(k, vv)
+		}
+	}
+	walk("", tree)
+	return leaves
+}
+
+// setJSONTreeValue sets the value at a dot-separated path of a JSON object tree, adding objects as needed.
+func setJSONTreeValue(tree map[string]interface{}, path string, v interface{}) {
+	keys := strings.Split(path, ".")
+	for _, k := range keys[:len(keys)-1] {
+		m, ok := tree[k].(map[string]interface{})
+		if !ok {
+			m = make(map[string]interface{})
+			tree[k] = m
+		}
+		tree = m
+	}
+	tree[keys[len(keys)-1]] = v
+}
+
+// transitionNames returns the names of the transition getters of a configuration which have setters, sorted.
+func transitionNames(conf ctypes.Configurator) []string {
+	_, blockNames := confp.Transitions(conf)
+	_, timeNames := confp.TransitionTimes(conf)
+	names := []string{}
+	for _, name := range append(blockNames, timeNames...) {
+		if reflect.ValueOf(conf).MethodByName(transitionSetter(name)).IsValid() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// transitionSetter returns the name of the setter of a transition getter.
+func transitionSetter(getter string) string {
+	return "Set" + strings.TrimPrefix(getter, "Get")
+}
+
+// forkFieldValue returns the JSON value which the format writes for a transition value,
+// and the path it is written at, by setting it on an empty configuration of the format
+// (with the consensus engine given). Nil is returned if the format cannot set the value.
+func forkFieldValue(format string, engine ctypes.ConsensusEngineT, setter string, n uint64) (map[string]interface{}, error) {
+	empty, err := echainspec.New(format)
+	if err != nil {
+		return nil, err
+	}
+	if err := empty.MustSetConsensusEngineType(engine); err != nil {
+		return nil, nil
+	}
+	before, err := decodeJSONTree(empty)
+	if err != nil {
+		return nil, err
+	}
+	res := reflect.ValueOf(empty).MethodByName(setter).Call([]reflect.Value{reflect.ValueOf(&n)})
+	if !res[0].IsNil() {
+		return nil, nil
+	}
+	after, err := decodeJSONTree(empty)
+	if err != nil {
+		return nil, err
+	}
+	beforeLeaves := jsonTreeLeaves(before)
+	changed := make(map[string]interface{})
+	for p, v := range jsonTreeLeaves(after) {
+		if bv, ok := beforeLeaves[p]; !ok || !reflect.DeepEqual(bv, v) {
+			changed[p] = v
+		}
+	}
+	return changed, nil
+}
+
+// fillForkDefaults sets each fork field of a configuration's (decoded JSON) tree which it does not set
+// to its effective value, or to null if it never activates, returning the source of each fork field's value.
+// Fork fields are found by setting each transition on an empty configuration of the same format;
+// fields keyed by block (eg. block reward schedules) are not fork fields.
+func fillForkDefaults(conf ctypes.Configurator, tree interface{}) ([]forkFieldSource, error) {
+	root, ok := tree.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	format, ok := echainspec.FormatOf(conf)
+	if !ok {
+		return nil, nil
+	}
+	engine := conf.GetConsensusEngineType()
+	sentinelKey := fmt.Sprint(forkFieldSentinel)
+	sentinelHexKey := fmt.Sprintf("0x%x", forkFieldSentinel)
+
+	// The transition whose value each fork field is written with. Fields which the setter changes,
+	// but not to the transition value (eg. the DAO fork beneficiary), are not fork fields.
+	isSentinel := func(v interface{}) bool {
+		switch t := v.(type) {
+		case json.Number:
+			return t.String() == sentinelKey
+		case string:
+			return strings.EqualFold(t, sentinelHexKey) || t == sentinelKey
+		}
+		return false
+	}
+	fieldTransitions := make(map[string]string)
+	for _, name := range transitionNames(conf) {
+		fields, err := forkFieldValue(format, engine, transitionSetter(name), forkFieldSentinel)
+		if err != nil {
+			return nil, err
+		}
+		for p, v := range fields {
+			if !isSentinel(v) || strings.Contains(p, sentinelKey) || strings.Contains(p, sentinelHexKey) {
+				continue
+			}
+			if _, ok := fieldTransitions[p]; !ok {
+				fieldTransitions[p] = name
+			}
+		}
+	}
+
+	leaves := jsonTreeLeaves(root)
+	paths := make([]string, 0, len(fieldTransitions))
+	for p := range fieldTransitions {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	sources := make([]forkFieldSource, 0, len(paths))
+	for _, p := range paths {
+		if v, ok := leaves[p]; ok && v != nil {
+			sources = append(sources, forkFieldSource{p, forkFieldExplicit})
+			continue
+		}
+		name := fieldTransitions[p]
+		n := reflect.ValueOf(conf).MethodByName(name).Call(nil)[0].Interface().(*uint64)
+		if n == nil {
+			setJSONTreeValue(root, p, nil)
+			sources = append(sources, forkFieldSource{p, forkFieldNever})
+			continue
+		}
+		fields, err := forkFieldValue(format, engine, transitionSetter(name), *n)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := fields[p]
+		if !ok {
+			v = json.Number(fmt.Sprint(*n))
+		}
+		setJSONTreeValue(root, p, v)
+		sources = append(sources, forkFieldSource{p, forkFieldDefaulted})
+	}
+	return sources, nil
+}
+
+// logForkFieldSources lists the source of each fork field's value on stderr.
+func logForkFieldSources(sources []forkFieldSource) {
+	for _, s := range sources {
+		log.Printf("%s: %s", s.Path, s.Source)
+	}
+}
+
+// marshalShowDefaults marshals a configuration as JSON (indented unless compact) with every fork field,
+// listing their sources on stderr. Object keys are sorted.
+func marshalShowDefaults(conf ctypes.Configurator, compact bool) ([]byte, error) {
+	tree, err := decodeJSONTree(conf)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := fillForkDefaults(conf, tree)
+	if err != nil {
+		return nil, err
+	}
+	logForkFieldSources(sources)
+	var b []byte
+	if compact {
+		b, err = json.Marshal(tree)
+	} else {
+		b, err = jsonMarshalPretty(tree)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestFillForkDefaults(t *testing.T) {
+	// The parity format defaults an absent eip155Transition to 0.
+	spec := `{"name": "test", "engine": {"Ethash": {"params": {"minimumDifficulty": "0x20000", "difficultyBoundDivisor": "0x800", "durationLimit": "0xd", "blockReward": "0x4563918244f40000", "homesteadTransition": "0x0"}}},
+		"params": {"gasLimitBoundDivisor": "0x400", "maximumExtraDataSize": "0x20", "minGasLimit": "0x1388", "networkID": "0xa", "eip150Transition": "0x5"},
+		"genesis": {"seal": {"ethereum": {"nonce": "0x0000000000000042", "mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000"}}, "difficulty": "0x400", "gasLimit": "0x1388"},
+		"accounts": {}}`
+	conf, err := echainspec.Read("parity", strings.NewReader(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := decodeJSONTree(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources, err := fillForkDefaults(conf, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, s := range sources {
+		got[s.Path] = s.Source
+	}
+	leaves := jsonTreeLeaves(tree)
+	cases := []struct {
+		path   string
+		source string
+		value  interface{}
+	}{
+		{"params.eip150Transition", forkFieldExplicit, "0x5"},
+		{"engine.Ethash.params.homesteadTransition", forkFieldExplicit, "0x0"},
+		{"params.eip155Transition", forkFieldDefaulted, "0x0"},
+		{"params.eip140Transition", forkFieldNever, nil},
+	}
+	for _, c := range cases {
+		if got[c.path] != c.source {
+			t.Errorf("%s: got source %q, want %q", c.path, got[c.path], c.source)
+		}
+		if v, ok := leaves[c.path]; !ok || v != c.value {
+			t.Errorf("%s: got value %v (present: %v), want %v", c.path, v, ok, c.value)
+		}
+	}
+	// Fields keyed by block are not fork fields.
+	for p := range got {
+		if strings.Contains(p, "difficultyBombDelays") || strings.Contains(p, "blockReward") {
+			t.Errorf("%s: want not a fork field", p)
+		}
+	}
+}
+
+func TestMarshalShowDefaults(t *testing.T) {
+	b, err := marshalShowDefaults(params.DefaultClassicGenesisBlock(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"eip1884FBlock", "eip4895FTime"} {
+		if v, ok := g.Config[k]; !ok || v != nil {
+			t.Errorf("%s: got %v (present: %v), want null", k, v, ok)
+		}
+	}
+	if v := g.Config["eip155Block"]; v != float64(3000000) {
+		t.Errorf("eip155Block: got %v, want 3000000", v)
+	}
+}
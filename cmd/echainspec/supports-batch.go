@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var supportsBatchCommand = cli.Command{
+	Name:  "supports-batch",
+	Usage: "Report whether the configuration activates each of a list of IPs at blocks, read from standard input",
+	Description: `Standard input is read as lines of '<ip> <block>' queries, eg. 'eip155 2000000', and each is
+printed with 'true' or 'false', as the supports command answers it, eg. 'eip155 2000000 true'.
+The configuration must be given by a flag (eg. --default or --file), not on standard input.
+
+Blank lines are skipped. Malformed lines (or unknown IPs) are reported with their line number
+on stderr, and the remaining lines are still answered.
+Exits 0 if all lines are answered, otherwise 1.`,
+	Action: supportsBatch,
+}
+
+var errMalformedQueries = errors.New("malformed queries")
+
+// supportsQueries answers the '<ip> <block>' query lines of r against the configuration, writing
+// each query and its answer to w. Malformed lines are logged with their line number and skipped;
+// their number is returned.
+func supportsQueries(conf ctypes.ChainConfigurator, r io.Reader, w io.Writer) (int, error) {
+	trs := sortedTransitions(conf, false)
+	malformed := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		ok, err := supportsQuery(trs, fields)
+		if err != nil {
+			malformed++
+			log.Printf("line %d: %v", line, err)
+			continue
+		}
+		fmt.Fprintln(w, fields[0], fields[1], ok)
+	}
+	return malformed, scanner.Err()
+}
+
+func supportsQuery(trs []ipTransition, fields []string) (bool, error) {
+	if len(fields) != 2 {
+		return false, fmt.Errorf("want '<ip> <block>', got %q", strings.Join(fields, " "))
+	}
+	n, err := parseBlockNumber(fields[1])
+	if err != nil {
+		return false, err
+	}
+	return supportsTransition(trs, fields[0], &n)
+}
+
+func supportsBatch(ctx *cli.Context) error {
+	malformed, err := supportsQueries(globalChainspecValue, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if malformed > 0 {
+		return fmt.Errorf("%d %w", malformed, errMalformedQueries)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSupportsQueries(t *testing.T) {
+	in := strings.NewReader("eip155 2000000\n\neip1283 7280000\neip0 1\neip155\neip155 x\nEIP155 0x2a3b4c\n")
+	var out bytes.Buffer
+	malformed, err := supportsQueries(defaultChainspecValues["foundation"], in, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if malformed != 3 {
+		t.Errorf("malformed: got %d, want 3", malformed)
+	}
+	want := "eip155 2000000 false\neip1283 7280000 false\nEIP155 0x2a3b4c true\n"
+	if got := out.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var supportsCommand = cli.Command{
+	Name:      "supports",
+	Usage:     "Report whether the configuration activates an IP, optionally at a block",
+	ArgsUsage: "<ip> [<0x042|0x42|42>]",
+	Description: `Prints 'true' and exits 0 if the IP is activated, otherwise prints 'false' and exits 3.
+IP names are those listed by ls-eips, matched case-insensitively, eg. eip1283.
+Given a block, the IP must be active at the block: activated, and not disabled by a
+corresponding '<ip>Disable' transition (eg. EIP1283Disable). IPs activated by timestamp
+(eg. EIP3860Time) cannot be given a block.`,
+	Action: supports,
+}
+
+// notSupportedExitCode is the exit code of the supports command if the IP is not activated.
+const notSupportedExitCode = 3
+
+var (
+	errMissingIPArg  = errors.New("missing IP name argument")
+	errTimeIPAtBlock = errors.New("IP is activated by timestamp, not at a block")
+	errNotSupported  = errors.New("IP is not activated")
+)
+
+// supportsIP reports whether the configuration ever activates the named IP,
+// or, if at is not nil, whether the IP is active at that block.
+// Transitions at Parity's conventional never-occurring blocks are not activations.
+func supportsIP(conf ctypes.ChainConfigurator, name string, at *uint64) (bool, error) {
+	return supportsTransition(sortedTransitions(conf, false), name, at)
+}
+
+// supportsTransition is supportsIP for the transitions of a configuration (see sortedTransitions).
+func supportsTransition(trs []ipTransition, name string, at *uint64) (bool, error) {
+	selected, err := selectTransitions(trs, []string{name})
+	if err != nil {
+		return false, err
+	}
+	activated := func(v *uint64) bool {
+		return v != nil && *v < ctypes.ParityNeverTransition && (at == nil || *v <= *at)
+	}
+	if at != nil && selected[0].Time {
+		return false, fmt.Errorf("%w: %s", errTimeIPAtBlock, name)
+	}
+	if !activated(selected[0].Value) {
+		return false, nil
+	}
+	if at == nil {
+		return true, nil
+	}
+	if disable, err := selectTransitions(trs, []string{name + "Disable"}); err == nil && activated(disable[0].Value) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func supports(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errMissingIPArg
+	}
+	var at *uint64
+	if ctx.NArg() > 1 {
+		n, err := parseBlockNumber(ctx.Args().Get(1))
+		if err != nil {
+			return err
+		}
+		at = &n
+	}
+	ok, err := supportsIP(globalChainspecValue, ctx.Args().First(), at)
+	if err != nil {
+		return err
+	}
+	fmt.Println(ok)
+	if !ok {
+		return resultError{errNotSupported, notSupportedExitCode}
+	}
+	return nil
+}
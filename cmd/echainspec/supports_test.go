@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSupportsIP(t *testing.T) {
+	u64 := func(n uint64) *uint64 { return &n }
+	for _, c := range []struct {
+		name string
+		ip   string
+		at   *uint64
+		want bool
+	}{
+		{"mordor", "eip1283", nil, true},
+		{"mordor", "EIP1283", u64(0), false},
+		{"mordor", "eip1283", u64(5000000), true},
+		{"mordor", "eip2929", nil, false},
+		{"mordor", "ecip1017", nil, true}, // Matched without the Ethash prefix.
+		// Constantinople's EIP1283 is disabled by Petersburg at the same block.
+		{"foundation", "eip1283", nil, true},
+		{"foundation", "eip1283", u64(7280000), false},
+		{"foundation", "eip3860time", nil, false},
+	} {
+		got, err := supportsIP(defaultChainspecValues[c.name], c.ip, c.at)
+		if err != nil {
+			t.Fatalf("%s %s: %v", c.name, c.ip, err)
+		}
+		if got != c.want {
+			t.Errorf("%s %s at %v: got %v, want %v", c.name, c.ip, c.at, got, c.want)
+		}
+	}
+	if _, err := supportsIP(defaultChainspecValues["mordor"], "eip0", nil); !errors.Is(err, errUnknownIP) {
+		t.Errorf("want %v, got %v", errUnknownIP, err)
+	}
+	if _, err := supportsIP(defaultChainspecValues["mordor"], "eip3860time", u64(0)); !errors.Is(err, errTimeIPAtBlock) {
+		t.Errorf("want %v, got %v", errTimeIPAtBlock, err)
+	}
+}
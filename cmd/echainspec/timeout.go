@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var timeoutFlag = cli.DurationFlag{
+	Name:  "timeout",
+	Usage: "Abort the command if it runs longer than the given duration, eg. 30s (default: no timeout)",
+}
+
+// timeoutExitCode is the exit code of a command aborted by --timeout,
+// following the timeout(1) convention.
+const timeoutExitCode = 124
+
+var errTimeout = errors.New("timeout: command exceeded --timeout duration")
+
+// commandContext is cancelled when the --timeout duration elapses.
+// Long-running operations should check it between units of work,
+// returning errTimeout if it is done.
+var commandContext, cancelCommand = context.WithCancel(context.Background())
+
+// setupTimeout starts the --timeout clock, if set.
+func setupTimeout(ctx *cli.Context) {
+	if d := ctx.GlobalDuration(timeoutFlag.Name); d > 0 {
+		time.AfterFunc(d, cancelCommand)
+	}
+}
+
+// runWithTimeout runs the function, returning errTimeout if the command
+// context is done first. The function is not stopped: it keeps running until it
+// next checks commandContext (eg. between allocs while computing a genesis hash),
+// or until the process exits.
+func runWithTimeout(fn func() error) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- fn()
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-commandContext.Done():
+		return errTimeout
+	}
+}
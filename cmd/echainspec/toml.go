@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Output (serialization) formats.
+const (
+	outputFormatJSON = "json"
+	outputFormatTOML = "toml"
+)
+
+var outputSerializationFlag = cli.StringFlag{
+	Name:  "outputformat",
+	Usage: fmt.Sprintf("Output serialization format [%s|%s|%s]", outputFormatJSON, outputFormatTOML, outputFormatYAML),
+	Value: outputFormatJSON,
+}
+
+var errInvalidOutputSerialization = errors.New("invalid output serialization format")
+
+// tomlGenesisPath is the table path of the genesis in a geth TOML (--config) file.
+var tomlGenesisPath = []string{"Eth", "Genesis"}
+
+// tomlMarshal encodes a configuration value as TOML, nested under the geth
+// config file genesis table path.
+// Like geth's TOML config, keys are Go field names.
+// Numbers are encoded as integers, except those which overflow a TOML (64-bit) integer,
+// which are encoded as decimal strings.
+func tomlMarshal(v interface{}) ([]byte, error) {
+	tree, ok := tomlValue(reflect.ValueOf(v))
+	if !ok {
+		return nil, errors.New("empty configuration")
+	}
+	if _, ok := tree.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("cannot encode %T as TOML table", v)
+	}
+	for i := len(tomlGenesisPath) - 1; i >= 0; i-- {
+		tree = map[string]interface{}{tomlGenesisPath[i]: tree}
+	}
+	buf := new(bytes.Buffer)
+	writeTOMLTable(buf, nil, tree.(map[string]interface{}))
+	return bytes.TrimLeft(buf.Bytes(), "\n"), nil
+}
+
+var (
+	bigIntType        = reflect.TypeOf(big.Int{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// tomlValue converts a value to a TOML-encodable tree of maps, slices, and scalars.
+// The boolean is false for nil values, which are omitted.
+func tomlValue(v reflect.Value) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Type().ConvertibleTo(bigIntType) {
+		i := v.Convert(bigIntType).Interface().(big.Int)
+		if i.IsInt64() {
+			return i.Int64(), true
+		}
+		return i.String(), true
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return strconv.FormatUint(v.Uint(), 10), true
+		}
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return v.String(), true
+	}
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err == nil {
+			return string(b), true
+		}
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, false
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return fmt.Sprintf("0x%x", b), true
+		}
+		list := []interface{}{}
+		for i := 0; i < v.Len(); i++ {
+			if e, ok := tomlValue(v.Index(i)); ok {
+				list = append(list, e)
+			}
+		}
+		return list, true
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, false
+		}
+		m := make(map[string]interface{})
+		for _, k := range v.MapKeys() {
+			e, ok := tomlValue(v.MapIndex(k))
+			if !ok {
+				continue
+			}
+			key := fmt.Sprint(k.Interface())
+			if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+				if b, err := tm.MarshalText(); err == nil {
+					key = string(b)
+				}
+			}
+			m[key] = e
+		}
+		return m, true
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue // unexported
+			}
+			if e, ok := tomlValue(v.Field(i)); ok {
+				m[f.Name] = e
+			}
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+var tomlBareKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(k string) string {
+	if tomlBareKeyRe.MatchString(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+func tomlScalar(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return strconv.Quote(t)
+	case []interface{}:
+		items := make([]string, len(t))
+		for i, e := range t {
+			items[i] = tomlScalar(e)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		// Inline table, eg. as an array element.
+		keys := sortedKeys(t)
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = tomlKey(k) + " = " + tomlScalar(t[k])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeTOMLTable writes a table's key/value pairs followed by its sub-tables.
+// Table headers are written for tables with values, and for empty tables,
+// since an empty table may be meaningful (eg. an empty ethash engine configuration).
+func writeTOMLTable(buf *bytes.Buffer, path []string, m map[string]interface{}) {
+	var values, tables []string
+	for _, k := range sortedKeys(m) {
+		if _, ok := m[k].(map[string]interface{}); ok {
+			tables = append(tables, k)
+		} else {
+			values = append(values, k)
+		}
+	}
+	if len(path) > 0 && (len(values) > 0 || len(tables) == 0) {
+		keys := make([]string, len(path))
+		for i, p := range path {
+			keys[i] = tomlKey(p)
+		}
+		fmt.Fprintf(buf, "\n[%s]\n", strings.Join(keys, "."))
+	}
+	for _, k := range values {
+		fmt.Fprintf(buf, "%s = %s\n", tomlKey(k), tomlScalar(m[k]))
+	}
+	for _, k := range tables {
+		writeTOMLTable(buf, append(append([]string{}, path...), k), m[k].(map[string]interface{}))
+	}
+}
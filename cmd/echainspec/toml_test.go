@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/naoina/toml"
+)
+
+func TestTOMLMarshal(t *testing.T) {
+	addr := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	gen := &genesisT.Genesis{
+		Config: &goethereum.ChainConfig{
+			ChainID:        big.NewInt(61),
+			HomesteadBlock: big.NewInt(1150000),
+		},
+		Difficulty: common.Big1,
+		GasLimit:   5000,
+		Alloc:      genesisT.GenesisAlloc{addr: {Balance: new(big.Int).Lsh(common.Big1, 70)}},
+	}
+	b, err := tomlMarshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"[Eth.Genesis]\n",
+		"[Eth.Genesis.Config]\n",
+		"HomesteadBlock = 1150000\n",
+		"ChainID = 61\n",
+		"GasLimit = 5000\n",
+		// Overflows a TOML integer.
+		`Balance = "1180591620717411303424"`,
+	} {
+		if !bytes.Contains(b, []byte(want)) {
+			t.Errorf("missing %q in output:\n%s", want, b)
+		}
+	}
+	if bytes.Contains(b, []byte("Code =")) {
+		t.Errorf("unexpected nil field in output:\n%s", b)
+	}
+
+	var v map[string]interface{}
+	if err := toml.Unmarshal(b, &v); err != nil {
+		t.Fatalf("invalid TOML: %v\n%s", err, b)
+	}
+}
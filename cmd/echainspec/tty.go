@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mattn/go-isatty"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var noColorFlag = cli.BoolFlag{
+	Name:  "no-color",
+	Usage: "Do not color the names in the output of inspection commands (forks, ips) on a terminal",
+}
+
+// ANSI escape sequences with which names are colored (cyan), and the color reset.
+const (
+	nameColor  = "\x1b[36m"
+	resetColor = "\x1b[0m"
+)
+
+// linePrinter prints lines of space-separated columns, the first of which may be a name.
+// On a terminal, columns are aligned and, unless disabled, names are colored;
+// otherwise columns are separated by a single space, so that output is stable for scripts.
+type linePrinter struct {
+	w     io.Writer
+	tw    *tabwriter.Writer
+	color bool
+}
+
+func newLinePrinter(w io.Writer, tty, color bool) *linePrinter {
+	p := &linePrinter{w: w, color: tty && color}
+	if tty {
+		p.tw = tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	}
+	return p
+}
+
+// newStdoutPrinter returns a linePrinter for standard output.
+func newStdoutPrinter(ctx *cli.Context) *linePrinter {
+	fd := os.Stdout.Fd()
+	tty := isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+	color := !ctx.GlobalBool(noColorFlag.Name) && os.Getenv("TERM") != "dumb"
+	return newLinePrinter(os.Stdout, tty, color)
+}
+
+// PrintNamed prints a line of a name followed by values.
+func (p *linePrinter) PrintNamed(name string, values ...interface{}) {
+	if p.color {
+		name = nameColor + name + resetColor
+	}
+	p.Print(append([]interface{}{name}, values...)...)
+}
+
+// Print prints a line of values.
+func (p *linePrinter) Print(values ...interface{}) {
+	cols := make([]string, len(values))
+	for i, v := range values {
+		cols[i] = fmt.Sprint(v)
+	}
+	if p.tw == nil {
+		fmt.Fprintln(p.w, strings.Join(cols, " "))
+		return
+	}
+	fmt.Fprintln(p.tw, strings.Join(cols, "\t"))
+}
+
+// Flush writes any buffered (aligned) lines.
+func (p *linePrinter) Flush() error {
+	if p.tw == nil {
+		return nil
+	}
+	return p.tw.Flush()
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLinePrinter(t *testing.T) {
+	print := func(p *linePrinter) {
+		p.PrintNamed("EIP7", 1150000)
+		p.PrintNamed("EthashHomestead", "-")
+		if err := p.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []struct {
+		tty, color bool
+		want       string
+	}{
+		// Output which is not to a terminal is unchanged, so that scripts keep working.
+		{false, true, "EIP7 1150000\nEthashHomestead -\n"},
+		{true, false, "EIP7            1150000\nEthashHomestead -\n"},
+		{true, true, "\x1b[36mEIP7\x1b[0m            1150000\n\x1b[36mEthashHomestead\x1b[0m -\n"},
+	} {
+		var buf bytes.Buffer
+		print(newLinePrinter(&buf, c.tty, c.color))
+		if got := buf.String(); got != c.want {
+			t.Errorf("tty %v color %v: got %q, want %q", c.tty, c.color, got, c.want)
+		}
+	}
+}
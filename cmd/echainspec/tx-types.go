@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var txTypesTimeFlag = cli.Uint64Flag{
+	Name:  "time",
+	Usage: "Block timestamp at which types enabled by timestamp forks (eg. blob) are checked; without it, those types are not listed",
+}
+
+var txTypesCommand = cli.Command{
+	Name:      "tx-types",
+	Usage:     "List transaction types enabled at a block",
+	ArgsUsage: "<0x042|0x42|42>",
+	Flags:     []cli.Flag{txTypesTimeFlag},
+	Action:    txTypes,
+}
+
+var (
+	errMissingBlockArg = errors.New("missing block number argument")
+	errInvalidBlockArg = errors.New("invalid block number argument")
+)
+
+// txType describes an EIP-2718 transaction type and the EIP which enables it.
+// Block and Time are the configurator getters for the enabling EIP's block or
+// timestamp transition; if both are nil the type is always enabled.
+type txType struct {
+	Type  uint8
+	Name  string
+	EIP   string
+	Block func(ctypes.ChainConfigurator) *uint64
+	Time  func(ctypes.ChainConfigurator) *uint64
+}
+
+var txTypeSchedule = []txType{
+	{Type: 0, Name: "legacy", EIP: "-"},
+	{Type: 1, Name: "access-list", EIP: "EIP-2930", Block: ctypes.ChainConfigurator.GetEIP2930Transition},
+	{Type: 2, Name: "fee-market", EIP: "EIP-1559", Block: ctypes.ChainConfigurator.GetEIP1559Transition},
+	{Type: 3, Name: "blob", EIP: "EIP-4844", Time: ctypes.ChainConfigurator.GetEIP4844TransitionTime},
+}
+
+// enabledTxTypes returns the transaction types enabled at block n, with timestamp t.
+// If t is nil, types activated by timestamp are left out.
+func enabledTxTypes(conf ctypes.ChainConfigurator, n *big.Int, t *uint64) []txType {
+	enabled := []txType{}
+	for _, tt := range txTypeSchedule {
+		switch {
+		case tt.Block != nil:
+			if !conf.IsForked(func() *uint64 { return tt.Block(conf) }, n) {
+				continue
+			}
+		case tt.Time != nil:
+			if at := tt.Time(conf); t == nil || at == nil || *at > *t {
+				continue
+			}
+		}
+		enabled = append(enabled, tt)
+	}
+	return enabled
+}
+
+func parseBlockArg(ctx *cli.Context) (*big.Int, error) {
+	if !ctx.Args().Present() {
+		return nil, errMissingBlockArg
+	}
+	n, err := parseBlockNumber(ctx.Args().First())
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(n), nil
+}
+
+// parseBlockNumber parses a hex (0x-prefixed) or decimal block number.
+func parseBlockNumber(s string) (uint64, error) {
+	var n math.HexOrDecimal64
+	if err := n.UnmarshalText([]byte(s)); err != nil || s == "" {
+		return 0, fmt.Errorf("%w: %q (want decimal or 0x-prefixed hex)", errInvalidBlockArg, s)
+	}
+	return uint64(n), nil
+}
+
+func txTypes(ctx *cli.Context) error {
+	n, err := parseBlockArg(ctx)
+	if err != nil {
+		return err
+	}
+	var ts *uint64
+	if ctx.IsSet(txTypesTimeFlag.Name) {
+		t := ctx.Uint64(txTypesTimeFlag.Name)
+		ts = &t
+	}
+	for _, t := range enabledTxTypes(globalChainspecValue, n, ts) {
+		fmt.Println(t.Type, t.Name, t.EIP)
+	}
+	return nil
+}
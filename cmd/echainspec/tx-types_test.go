@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+func TestEnabledTxTypes(t *testing.T) {
+	u64 := func(n uint64) *uint64 { return &n }
+	conf := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	london, cancun := uint64(100), uint64(1700000000)
+	conf.SetEIP2930Transition(&london)
+	conf.SetEIP1559Transition(&london)
+	conf.SetEIP4844TransitionTime(&cancun)
+
+	cases := []struct {
+		block uint64
+		time  *uint64
+		want  []uint8
+	}{
+		{0, nil, []uint8{0}},
+		{100, nil, []uint8{0, 1, 2}},
+		{100, u64(cancun - 1), []uint8{0, 1, 2}},
+		{100, u64(cancun), []uint8{0, 1, 2, 3}},
+	}
+	for _, c := range cases {
+		got := []uint8{}
+		for _, tt := range enabledTxTypes(conf, new(big.Int).SetUint64(c.block), c.time) {
+			got = append(got, tt.Type)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			ts := "unset"
+			if c.time != nil {
+				ts = fmt.Sprint(*c.time)
+			}
+			t.Errorf("block %d, time %s: got types %v, want %v", c.block, ts, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// echainspecHomeEnv names the environment variable of the directory from which
+// user default configurations are loaded (from its 'defaults' subdirectory).
+// If unset, it is ~/.echainspec.
+const echainspecHomeEnv = "ECHAINSPEC_HOME"
+
+var overrideDefaultsFlag = cli.BoolFlag{
+	Name:  "override-defaults",
+	Usage: "Prefer user default configurations over builtin defaults of the same name",
+}
+
+// userDefaultsDir returns the directory of the user default configurations.
+func userDefaultsDir() string {
+	home := os.Getenv(echainspecHomeEnv)
+	if home == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		home = filepath.Join(userHome, ".echainspec")
+	}
+	return filepath.Join(home, "defaults")
+}
+
+// userDefaultPaths returns the paths of the user default configuration files in the directory,
+// by name (file basename without the .json extension). A missing directory has none.
+func userDefaultPaths(dir string) (map[string]string, error) {
+	paths := make(map[string]string)
+	if dir == "" {
+		return paths, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range matches {
+		paths[strings.TrimSuffix(filepath.Base(p), ".json")] = p
+	}
+	return paths, nil
+}
+
+// readUserDefault reads a user default configuration file, detecting its format.
+func readUserDefault(path string) (ctypes.Configurator, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := readChainspec("", data, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default configuration: %s: %v", path, err)
+	}
+	return conf, nil
+}
+
+// lookupDefault returns the named default configuration, which is either builtin or a file in dir.
+// Builtin configurations take precedence over files of the same name, unless override is true.
+func lookupDefault(name, dir string, override bool) (ctypes.Configurator, error) {
+	builtin, isBuiltin := defaultChainspecValues[name]
+	if isBuiltin && !override {
+		return builtin, nil
+	}
+	paths, err := userDefaultPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := paths[name]; ok {
+		return readUserDefault(p)
+	}
+	if isBuiltin {
+		return builtin, nil
+	}
+	return nil, fmt.Errorf("error: %w, name: %s", errInvalidDefaultValue, name)
+}
+
+// defaultSource is the source of a default configuration name: "builtin", or a file path.
+type defaultSource struct {
+	Name   string
+	Source string
+}
+
+// defaultSources returns the sources of the builtin and user default configurations, sorted by name.
+// Each name is listed once, with the source lookupDefault would use.
+func defaultSources(dir string, override bool) ([]defaultSource, error) {
+	paths, err := userDefaultPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	sources := []defaultSource{}
+	for _, name := range defaultChainspecNames {
+		if _, ok := paths[name]; ok {
+			if override {
+				continue
+			}
+			delete(paths, name)
+		}
+		sources = append(sources, defaultSource{Name: name, Source: "builtin"})
+	}
+	for name, p := range paths {
+		sources = append(sources, defaultSource{Name: name, Source: p})
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Name < sources[j].Name
+	})
+	return sources, nil
+}
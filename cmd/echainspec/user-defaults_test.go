@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+func TestUserDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "echainspec-defaults")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// A private chain, in parity format, and a file named as a builtin default.
+	for name, c := range map[string]struct{ def, format string }{
+		"private.json": {"goerli", "parity"},
+		"classic.json": {"foundation", "geth"},
+	} {
+		conf, err := echainspec.Convert(defaultChainspecValues[c.def], c.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := echainspec.Write(conf, f); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	for _, c := range []struct {
+		name     string
+		override bool
+		chainID  uint64
+	}{
+		{"private", false, 5},
+		{"classic", false, 61},
+		{"classic", true, 1},
+		{"goerli", true, 5},
+	} {
+		conf, err := lookupDefault(c.name, dir, c.override)
+		if err != nil {
+			t.Fatalf("%s (override %v): %v", c.name, c.override, err)
+		}
+		if got := conf.GetChainID(); got == nil || got.Uint64() != c.chainID {
+			t.Errorf("%s (override %v): got chain ID %v, want %d", c.name, c.override, got, c.chainID)
+		}
+	}
+	if _, err := lookupDefault("missing", dir, false); !errors.Is(err, errInvalidDefaultValue) {
+		t.Errorf("got error %v, want %v", err, errInvalidDefaultValue)
+	}
+
+	sources, err := defaultSources(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, s := range sources {
+		got[s.Name] = s.Source
+	}
+	if len(sources) != len(defaultChainspecValues)+1 {
+		t.Errorf("got %d defaults, want %d", len(sources), len(defaultChainspecValues)+1)
+	}
+	want := map[string]string{"classic": "builtin", "private": filepath.Join(dir, "private.json")}
+	for name, source := range want {
+		if got[name] != source {
+			t.Errorf("%s: got source %q, want %q", name, got[name], source)
+		}
+	}
+	sources, err = defaultSources(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range sources {
+		if s.Name == "classic" && s.Source != filepath.Join(dir, "classic.json") {
+			t.Errorf("classic (override): got source %q", s.Source)
+		}
+	}
+}
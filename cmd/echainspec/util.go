@@ -1,63 +1,116 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
-	"strings"
+	"reflect"
 
+	"github.com/ethereum/go-ethereum/params/echainspec"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
-	"github.com/ethereum/go-ethereum/params/types/genesisT"
-	"github.com/ethereum/go-ethereum/params/types/goethereum"
-	"github.com/ethereum/go-ethereum/params/types/multigeth"
 	"gopkg.in/urfave/cli.v1"
 )
 
+// openInput opens the input configuration file or URL, or standard input if neither is given.
+func openInput(ctx *cli.Context) (io.ReadCloser, error) {
+	if ctx.GlobalIsSet(fromURLFlag.Name) {
+		if ctx.GlobalIsSet(fileInFlag.Name) {
+			return nil, errConflictingFile
+		}
+		return fetchURL(commandContext, ctx.GlobalString(fromURLFlag.Name), ctx.GlobalBool(insecureFlag.Name))
+	}
+	if !ctx.GlobalIsSet(fileInFlag.Name) {
+		return ioutil.NopCloser(os.Stdin), nil
+	}
+	return os.Open(ctx.GlobalString(fileInFlag.Name))
+}
+
 func readInputData(ctx *cli.Context) ([]byte, error) {
+	if ctx.GlobalIsSet(fromURLFlag.Name) {
+		r, err := openInput(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
 	if !ctx.GlobalIsSet(fileInFlag.Name) {
 		return ioutil.ReadAll(os.Stdin)
 	}
 	return ioutil.ReadFile(ctx.GlobalString(fileInFlag.Name))
 }
 
-func unmarshalChainSpec(format string, data []byte) (conf ctypes.Configurator, err error) {
-	conf, ok := chainspecFormatTypes[format]
-	if !ok {
-		return nil, errInvalidChainspecValue
+// readChainspec reads a configuration of the given format, detecting the format if none
+// (or auto) is given. If strict, fields unknown to the format are an error; a detected
+// format is then the best match, so that the error names the unknown field.
+func readChainspec(format string, data []byte, strict bool) (ctypes.Configurator, error) {
+	if format == "" || format == autoFormat {
+		if !strict {
+			_, conf, err := echainspec.GuessFormat(data)
+			if err == echainspec.ErrNoFormatGuessed {
+				return nil, errInvalidChainspecValue
+			}
+			return conf, err
+		}
+		candidates := echainspec.Detect(data)
+		if len(candidates) == 0 {
+			return nil, errInvalidChainspecValue
+		}
+		format = candidates[0].Format
+	}
+	return readFormat(format, bytes.NewReader(data), strict)
+}
+
+// readFormat reads a configuration of the given format, strictly or not.
+func readFormat(format string, r io.Reader, strict bool) (ctypes.Configurator, error) {
+	if strict {
+		return echainspec.ReadStrict(format, r)
+	}
+	return echainspec.Read(format, r)
+}
+
+// besuFormat is the format name for Besu genesis files.
+const besuFormat = "besu"
+
+var errBesuFormatUnsupported = errors.New("besu format not supported")
+
+// readBesuGenesis reads a Besu genesis file as a configurator.
+func readBesuGenesis(path string, strict bool) (ctypes.Configurator, error) {
+	if _, err := echainspec.New(besuFormat); err != nil {
+		return nil, errBesuFormatUnsupported
 	}
-	err = json.Unmarshal(data, conf)
+	f, err := os.Open(path)
 	if err != nil {
-		return conf, err
-	}
-	if !strings.Contains(format, "geth") {
-		return
-	}
-	// Logic in params/types/gen_genesis.go already "auto-magically"
-	// handles genesis Config unmarshaling, and IT PREFERS MULTIGETH,
-	// and the two data types are not mutually exclusive (are overlapping).
-	// So we need to redo custom unmarshaling logic to enforce data type
-	// preference based on passed format value.
-	type dec struct {
-		Config ctypes.ChainConfigurator `json:"config"`
-	}
-	var d dec
-	if format == "geth" {
-		d.Config = &goethereum.ChainConfig{}
-	} else if format == "multigeth" {
-		d.Config = &multigeth.MultiGethChainConfig{}
-	} else {
-		panic("impossible")
-	}
-	t := chainspecFormatTypes[format].(*genesisT.Genesis)
-	err = json.Unmarshal(data, &d)
+		return nil, err
+	}
+	defer f.Close()
+	conf, err := readFormat(besuFormat, f, strict)
 	if err != nil {
-		return conf, err
+		return nil, fmt.Errorf("invalid Besu genesis: %s: %v", path, err)
+	}
+	return conf, nil
+}
+
+// reflectGet calls a named *uint64 getter method on a configurator.
+func reflectGet(conf interface{}, method string) *uint64 {
+	res := reflect.ValueOf(conf).MethodByName(method).Call([]reflect.Value{})
+	return res[0].Interface().(*uint64)
+}
+
+// reflectSet calls a named *uint64 setter method on a configurator.
+func reflectSet(conf interface{}, method string, n *uint64) error {
+	res := reflect.ValueOf(conf).MethodByName(method).Call([]reflect.Value{reflect.ValueOf(n)})
+	if res[0].IsNil() {
+		return nil
 	}
-	t.Config = d.Config
-	conf = t
-	return
+	return res[0].Interface().(error)
 }
 
+// jsonMarshalPretty marshals a value as JSON indented by --indent.
 func jsonMarshalPretty(i interface{}) ([]byte, error) {
-	return json.MarshalIndent(i, "", "    ")
+	return json.MarshalIndent(i, "", jsonIndent)
 }
@@ -1,40 +1,157 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"os"
 
-	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/params/confp"
 	"gopkg.in/urfave/cli.v1"
 )
 
+var ndjsonFlag = cli.BoolFlag{
+	Name:  "ndjson",
+	Usage: "Read the input as newline-delimited JSON configurations, one per line (validate only)",
+}
+
+var errNDJSONCommand = errors.New("--ndjson is only supported by the validate command")
+
+var validateExplainFlag = cli.BoolFlag{
+	Name:  "explain",
+	Usage: "Print a description of the first condition for which the configuration is invalid, or that it is valid",
+}
+
+var errExplainNDJSON = errors.New("--explain is not supported with --ndjson")
+
+// errInvalidConfig is returned by the validate command if a configuration is not valid.
+var errInvalidConfig = errors.New("invalid configuration")
+
 var validateCommand = cli.Command{
-	Name:        "validate",
-	Aliases:     []string{"valid"},
-	Description: "Exits 0 if valid, 1 if not.",
-	Usage:       "Tests whether a configuration is valid",
-	ArgsUsage:   "[|0x042|0x42|42]",
-	Action:      validate,
+	Name:    "validate",
+	Aliases: []string{"valid"},
+	Description: `Exits 0 if valid, 1 if not. The reasons a configuration is not valid are written
+to stderr, also with --quiet (which only suppresses the 'Valid' message and warnings).
+EIP-161 sub-parts which activate at different blocks (eg. of a Parity chainspec) are valid, with a warning.
+Without a block number, only structural (head-agnostic) checks are run,
+eg. that hard fork transitions activate in protocol dependency order.
+
+With --explain, the first condition for which the configuration is invalid is printed,
+eg. 'EIP161d active but EIP161abc prerequisite not activated at block 3000000',
+or, if it is valid, 'valid at block <number>' (or 'valid' without a block number).
+
+With --ndjson, each input line is validated, and a result line is printed
+for each, eg. '0 ok' or '5 invalid: <reason>'. Exits 1 if any is not valid.
+With --concurrency N, up to N lines are validated in parallel; results are still printed in input order.`,
+	Usage:     "Tests whether a configuration is valid",
+	ArgsUsage: "[|0x042|0x42|42]",
+	Flags:     []cli.Flag{validateExplainFlag},
+	Action:    validate,
+}
+
+// ndjsonResult is the validation result of the nth configuration of newline-delimited JSON input.
+type ndjsonResult struct {
+	n   int
+	err error
+}
+
+// validateNDJSON validates each line of newline-delimited JSON configurations as it is read,
+// on up to concurrency goroutines, writing a result line for each to w, in input order.
+// Blank lines are skipped.
+// It returns false if any configuration could not be read or is not valid.
+func validateNDJSON(r io.Reader, w io.Writer, format string, strict bool, head *uint64, concurrency int) (bool, error) {
+	br := bufio.NewReader(r)
+	ok := true
+	i, eof := 0, false
+	next := func() (func() interface{}, error) {
+		for !eof {
+			line, err := br.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			eof = err == io.EOF
+			if data := bytes.TrimSpace(line); len(data) > 0 {
+				n := i
+				i++
+				return func() interface{} {
+					conf, err := readChainspec(format, data, strict)
+					if err == nil {
+						err = confp.Validate(conf, head)
+					}
+					return ndjsonResult{n, err}
+				}, nil
+			}
+		}
+		return nil, nil
+	}
+	err := runOrdered(concurrency, next, func(v interface{}) bool {
+		res := v.(ndjsonResult)
+		if res.err != nil {
+			ok = false
+			fmt.Fprintf(w, "%d invalid: %v\n", res.n, res.err)
+		} else {
+			fmt.Fprintf(w, "%d ok\n", res.n)
+		}
+		return true
+	})
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
 }
 
 func validate(ctx *cli.Context) error {
 	var h *uint64
 	if ctx.Args().Present() {
-		var head math.HexOrDecimal64
-		err := head.UnmarshalText([]byte(ctx.Args().First()))
+		head, err := parseBlockNumber(ctx.Args().First())
 		if err != nil {
 			return err
 		}
-		var hh = uint64(head)
-		h = &hh
+		h = &head
 	}
-	err := confp.IsValid(globalChainspecValue, h)
-	if err != nil {
-		log.Println(err)
-		os.Exit(1)
+	if ctx.GlobalBool(ndjsonFlag.Name) {
+		if ctx.Bool(validateExplainFlag.Name) {
+			return errExplainNDJSON
+		}
+		r, err := openInput(ctx)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		n, err := concurrency(ctx)
+		if err != nil {
+			return err
+		}
+		ok, err := validateNDJSON(r, os.Stdout, ctx.GlobalString(formatInFlag.Name), ctx.GlobalBool(strictFlag.Name), h, n)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidConfig
+		}
+		return nil
+	}
+	if split := confp.EIP161Split(globalChainspecValue); split != nil {
+		log.Println("warning:", split.Explain())
+	}
+	if ctx.Bool(validateExplainFlag.Name) {
+		if s := confp.Explain(globalChainspecValue, h); s != "" {
+			fmt.Println(s)
+			return errInvalidConfig
+		}
+		if h != nil {
+			fmt.Println("valid at block", *h)
+		} else {
+			fmt.Println("valid")
+		}
+		return nil
+	}
+	if err := confp.Validate(globalChainspecValue, h); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidConfig, err)
 	}
 	log.Println("Valid")
-	os.Exit(0)
 	return nil
 }
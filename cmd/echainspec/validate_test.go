@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestValidateNDJSON(t *testing.T) {
+	valid, err := json.Marshal(params.DefaultClassicGenesisBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// EIP1344 (CHAINID) is active without EIP155.
+	regression := `{"config":{"chainId":1,"networkId":1,"eip140FBlock":100,"eip1344FBlock":10,"ethash":{}},"difficulty":"0x1","gasLimit":"0x1388","alloc":{}}`
+	input := strings.Join([]string{string(valid), "", regression, "{not json"}, "\n")
+
+	out := new(bytes.Buffer)
+	ok, err := validateNDJSON(strings.NewReader(input), out, "multigeth", false, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("want invalid result")
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d result lines, want 3: %q", len(lines), lines)
+	}
+	if lines[0] != "0 ok" {
+		t.Errorf("line 0: got %q, want %q", lines[0], "0 ok")
+	}
+	for i, line := range lines[1:] {
+		if want := string(rune('1'+i)) + " invalid: "; !strings.HasPrefix(line, want) {
+			t.Errorf("line %d: got %q, want prefix %q", i+1, line, want)
+		}
+	}
+
+	out.Reset()
+	ok, err = validateNDJSON(strings.NewReader(string(valid)), out, autoFormat, false, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || out.String() != "0 ok\n" {
+		t.Errorf("got %v %q, want valid", ok, out.String())
+	}
+}
+
+func TestValidateNDJSONStrict(t *testing.T) {
+	input := `{"config": {"chainId": 61, "networkId": 1, "eip155Bloc": 0, "ethash": {}}, "difficulty": "0x400000000", "gasLimit": "0x1388", "alloc": {}}
+`
+	out := new(bytes.Buffer)
+	ok, err := validateNDJSON(strings.NewReader(input), out, "multigeth", false, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatalf("lenient: got not ok, output: %s", out)
+	}
+	out.Reset()
+	ok, err = validateNDJSON(strings.NewReader(input), out, "multigeth", true, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `0 invalid: json: unknown field "config.eip155Bloc"` + "\n"; ok || out.String() != want {
+		t.Errorf("strict: got ok %v, output %q, want output %q", ok, out.String(), want)
+	}
+}
+
+func TestParseBlockNumber(t *testing.T) {
+	for _, c := range []struct {
+		arg  string
+		want uint64
+	}{
+		{"3000000", 3000000},
+		{"0x2dc6c0", 3000000},
+		{"0x2DC6C0", 3000000},
+		{"0", 0},
+		{"0x0", 0},
+	} {
+		got, err := parseBlockNumber(c.arg)
+		if err != nil {
+			t.Errorf("%s: %v", c.arg, err)
+		} else if got != c.want {
+			t.Errorf("%s: got %d, want %d", c.arg, got, c.want)
+		}
+	}
+	for _, arg := range []string{"", "0x", "0xzz", "-1", "3e6", "99999999999999999999999"} {
+		if _, err := parseBlockNumber(arg); !errors.Is(err, errInvalidBlockArg) {
+			t.Errorf("%q: got error %v, want %v", arg, err, errInvalidBlockArg)
+		}
+	}
+}
+
+func TestValidateOutputConfig(t *testing.T) {
+	if err := validateOutputConfig(params.DefaultGoerliGenesisBlock()); err != nil {
+		t.Errorf("goerli: %v", err)
+	}
+	// EIP1344 (CHAINID) is active without EIP155.
+	conf, err := readChainspec("multigeth", []byte(`{"config":{"chainId":1,"networkId":1,"eip140FBlock":100,"eip1344FBlock":10,"ethash":{}},"difficulty":"0x1","gasLimit":"0x1388","alloc":{}}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateOutputConfig(conf); !errors.Is(err, errInvalidOutputConfig) {
+		t.Errorf("got error %v, want %v", err, errInvalidOutputConfig)
+	}
+}
+
+// TestValidateQuiet tests that the reasons a configuration is not valid are returned
+// (so written to stderr by main) with --quiet.
+func TestValidateQuiet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "echainspec-validate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer log.SetOutput(os.Stderr)
+
+	path := filepath.Join(dir, "invalid.json")
+	// EIP1344 (CHAINID) is active without EIP155.
+	if err := ioutil.WriteFile(path, []byte(`{"config":{"chainId":1,"networkId":1,"eip1344FBlock":10,"ethash":{}},"difficulty":"0x1","gasLimit":"0x1388","alloc":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = app.Run([]string{"echainspec", "--quiet", "--inputf", "multigeth", "--file", path, "validate"})
+	if !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("got error %v, want %v", err, errInvalidConfig)
+	}
+	if !strings.Contains(err.Error(), "EIP1344 requires EIP155") {
+		t.Errorf("error %q does not give the reason", err)
+	}
+	if code := exitCode(err); code != failureExitCode {
+		t.Errorf("exit code: got %d, want %d", code, failureExitCode)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var verifyDefaultsCommand = cli.Command{
+	Name:  "verify-defaults",
+	Usage: "Check that each builtin default configuration round-trips through each format",
+	Description: `Each builtin default is converted to each format, written and read again, and converted back.
+It passes if the result is semantically equal to the default (see diff-against), and its genesis hash
+is the known genesis hash for its chain ID (or, if there is none, the default's genesis hash).
+Fields which the format is known not to represent (eg. the Homestead block of a Clique chain,
+in Parity's format) are not compared.
+
+Results are printed as a table of defaults by formats: 'pass', 'fail', or '-' if the default cannot
+be converted to the format (eg. it uses a fork the format cannot configure). Reasons for failures follow.
+Exits 0 if all pass, otherwise 1.`,
+	Action: verifyDefaults,
+}
+
+var errDefaultsFailed = errors.New("default configurations failed verification")
+
+// errNotConvertible marks a default which cannot be converted to a format.
+var errNotConvertible = errors.New("cannot convert")
+
+// verifyDefault round-trips a default configuration through a format, and checks the result.
+// An error wrapping errNotConvertible is returned if the default cannot be converted to the format.
+func verifyDefault(conf ctypes.Configurator, format string) error {
+	mid, err := echainspec.Convert(conf, format)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errNotConvertible, err)
+	}
+	var buf bytes.Buffer
+	if err := echainspec.Write(mid, &buf); err != nil {
+		return err
+	}
+	mid, err = echainspec.Read(format, &buf)
+	if err != nil {
+		return fmt.Errorf("read: %v", err)
+	}
+	back, err := echainspec.Convert(mid, "multigeth")
+	if err != nil {
+		return fmt.Errorf("convert back: %v", err)
+	}
+	if ok, diffs := confp.EqualConfigs(conf, back); !ok {
+		var unexpected []string
+		for _, field := range diffs {
+			if !echainspec.Unrepresentable(format, conf, field) {
+				unexpected = append(unexpected, field)
+			}
+		}
+		if len(unexpected) > 0 {
+			return fmt.Errorf("differs: %s", strings.Join(unexpected, ", "))
+		}
+	}
+	got, err := genesisHash(back)
+	if err != nil {
+		return err
+	}
+	var want common.Hash
+	if id := conf.GetChainID(); id != nil && id.IsUint64() {
+		want = knownGenesisHashes[id.Uint64()]
+	}
+	if want == (common.Hash{}) {
+		if want, err = genesisHash(conf); err != nil {
+			return err
+		}
+	}
+	if got != want {
+		return fmt.Errorf("genesis hash mismatch: want: %s, got: %s", want.Hex(), got.Hex())
+	}
+	return nil
+}
+
+func verifyDefaults(ctx *cli.Context) error {
+	names := append([]string{}, defaultChainspecNames...)
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "network\t"+strings.Join(chainspecFormats, "\t"))
+	failures := []string{}
+	for _, name := range names {
+		cells := []string{name}
+		for _, format := range chainspecFormats {
+			if commandContext.Err() != nil {
+				return errTimeout
+			}
+			err := verifyDefault(defaultChainspecValues[name], format)
+			switch {
+			case err == nil:
+				cells = append(cells, "pass")
+			case errors.Is(err, errNotConvertible):
+				cells = append(cells, "-")
+			default:
+				cells = append(cells, "fail")
+				failures = append(failures, fmt.Sprintf("%s %s: %v", name, format, err))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	fmt.Println()
+	for _, f := range failures {
+		fmt.Println(f)
+	}
+	return fmt.Errorf("%d %w", len(failures), errDefaultsFailed)
+}
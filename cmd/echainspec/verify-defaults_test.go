@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// notConvertibleDefaults are the formats to which each builtin default cannot be converted.
+var notConvertibleDefaults = map[string][]string{
+	"classic": {"aleth", "besu", "geth", "nethermind", "parity", "retesteth"},
+	"goerli":  {"aleth", "retesteth"},
+	"kotti":   {"aleth", "besu", "geth", "nethermind", "parity", "retesteth"},
+	"mordor":  {"aleth", "besu", "geth", "nethermind", "parity", "retesteth"},
+	"rinkeby": {"aleth", "retesteth"},
+	"social":  {"aleth", "geth", "retesteth"},
+}
+
+func TestVerifyDefault(t *testing.T) {
+	for _, name := range defaultChainspecNames {
+		skip := map[string]bool{}
+		for _, format := range notConvertibleDefaults[name] {
+			skip[format] = true
+		}
+		for _, format := range chainspecFormats {
+			err := verifyDefault(defaultChainspecValues[name], format)
+			switch {
+			case skip[format] && !errors.Is(err, errNotConvertible):
+				t.Errorf("%s %s: got %v, want %v", name, format, err, errNotConvertible)
+			case !skip[format] && err != nil:
+				t.Errorf("%s %s: %v", name, format, err)
+			}
+		}
+	}
+
+	// A configuration whose genesis differs from the known genesis of its chain ID fails.
+	b, err := json.Marshal(params.DefaultGenesisBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &genesisT.Genesis{}
+	if err := json.Unmarshal(b, conf); err != nil {
+		t.Fatal(err)
+	}
+	conf.ExtraData = []byte("not mainnet")
+	if err := verifyDefault(conf, "geth"); err == nil || !strings.Contains(err.Error(), "genesis hash mismatch") {
+		t.Errorf("modified genesis: got %v, want genesis hash mismatch", err)
+	}
+}
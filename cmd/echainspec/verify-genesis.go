@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var verifyGenesisCommand = cli.Command{
+	Name:        "verify-genesis",
+	Usage:       "Check the genesis hash against the known genesis hash for the configuration's chain ID",
+	Description: "Exits 0 if the genesis hash matches or the chain ID is not known, 1 if it does not match.",
+	Action:      verifyGenesis,
+}
+
+// knownGenesisHashes maps well-known chain IDs to their canonical genesis hashes.
+var knownGenesisHashes = map[uint64]common.Hash{
+	1:     params.MainnetGenesisHash,
+	3:     params.TestnetGenesisHash,
+	4:     params.RinkebyGenesisHash,
+	5:     params.GoerliGenesisHash,
+	6:     params.KottiGenesisHash,
+	28:    params.SocialGenesisHash,
+	61:    params.MainnetGenesisHash, // Ethereum Classic shares the Ethereum genesis.
+	63:    params.MordorGenesisHash,
+	76:    params.MixGenesisHash,
+	31102: params.EthersocialGenesisHash,
+}
+
+// genesisHash computes the hash of a configuration's genesis block.
+// It returns errTimeout if the command context is done while the genesis state is built.
+func genesisHash(conf ctypes.Configurator) (common.Hash, error) {
+	g, ok := conf.(*genesisT.Genesis)
+	if !ok {
+		c, err := echainspec.Convert(conf, "multigeth")
+		if err != nil {
+			return common.Hash{}, err
+		}
+		g = c.(*genesisT.Genesis)
+	}
+	block, err := core.GenesisToBlockContext(commandContext, g, nil)
+	if err != nil {
+		return common.Hash{}, errTimeout
+	}
+	return block.Hash(), nil
+}
+
+func verifyGenesis(ctx *cli.Context) error {
+	hash, err := genesisHash(globalChainspecValue)
+	if err != nil {
+		return err
+	}
+	fmt.Println("genesis hash:", hash.Hex())
+	chainID := globalChainspecValue.GetChainID()
+	if chainID == nil || !chainID.IsUint64() {
+		fmt.Println("note: no chain ID, skipping known genesis check")
+		return nil
+	}
+	want, ok := knownGenesisHashes[chainID.Uint64()]
+	if !ok {
+		fmt.Printf("note: chain ID %d has no known genesis hash\n", chainID)
+		return nil
+	}
+	if hash != want {
+		return fmt.Errorf("genesis hash mismatch for chain ID %d: want: %s, got: %s", chainID, want.Hex(), hash.Hex())
+	}
+	fmt.Printf("genesis hash matches known genesis for chain ID %d\n", chainID)
+	return nil
+}
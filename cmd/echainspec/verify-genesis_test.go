@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestKnownGenesisHashes(t *testing.T) {
+	for name, conf := range defaultChainspecValues {
+		hash, err := genesisHash(conf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		want, ok := knownGenesisHashes[conf.GetChainID().Uint64()]
+		if !ok {
+			t.Errorf("%s: missing known genesis hash for chain ID %v", name, conf.GetChainID())
+			continue
+		}
+		if hash != want {
+			t.Errorf("%s: want: %s, got: %s", name, want.Hex(), hash.Hex())
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// outputFormatYAML is the YAML output serialization format.
+const outputFormatYAML = "yaml"
+
+var (
+	// yamlForkKeyRe matches the keys of fork block values.
+	yamlForkKeyRe = regexp.MustCompile(`(?i)(block|transition|activate_at)$`)
+	// yamlBareKeyRe matches keys which may be written unquoted.
+	yamlBareKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// yamlReservedRe matches plain scalars which YAML (1.1) parsers read as other than strings.
+	yamlReservedRe = regexp.MustCompile(`(?i)^(y|n|yes|no|on|off|true|false|null)$`)
+)
+
+// yamlMarshal encodes a value as block style YAML, with the keys of its JSON encoding.
+// Mappings are written with sorted keys, fork blocks are written as integers,
+// and hex strings are lowercase. All strings are quoted, so that hex values are not read as numbers.
+func yamlMarshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	tree = yamlForkIntegers(lowercaseHex(tree))
+	buf := new(bytes.Buffer)
+	switch t := tree.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			buf.WriteString("{}\n")
+		}
+		writeYAMLMap(buf, 0, t)
+	default:
+		return nil, fmt.Errorf("cannot encode %T as YAML mapping", v)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlForkIntegers converts the string values of fork block keys to integers.
+func yamlForkIntegers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		for i := range t {
+			t[i] = yamlForkIntegers(t[i])
+		}
+	case map[string]interface{}:
+		for k, vv := range t {
+			if s, ok := vv.(string); ok && yamlForkKeyRe.MatchString(k) {
+				if n, ok := new(big.Int).SetString(s, 0); ok {
+					t[k] = json.Number(n.String())
+				}
+				continue
+			}
+			t[k] = yamlForkIntegers(vv)
+		}
+	}
+	return v
+}
+
+func yamlKey(k string) string {
+	if yamlBareKeyRe.MatchString(k) && !yamlReservedRe.MatchString(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+// yamlScalar returns the flow representation of a scalar, or of an empty mapping or sequence.
+func yamlScalar(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(t)
+	case map[string]interface{}:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	}
+	return fmt.Sprint(v)
+}
+
+// yamlIsBlock reports whether a value is written as a nested block, ie. a non-empty mapping or sequence.
+func yamlIsBlock(v interface{}) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return len(t) > 0
+	case []interface{}:
+		return len(t) > 0
+	}
+	return false
+}
+
+func writeYAMLMap(buf *bytes.Buffer, indent int, m map[string]interface{}) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pad := strings.Repeat(" ", indent)
+	for _, k := range keys {
+		fmt.Fprintf(buf, "%s%s:", pad, yamlKey(k))
+		writeYAMLValue(buf, indent+2, m[k])
+	}
+}
+
+func writeYAMLValue(buf *bytes.Buffer, indent int, v interface{}) {
+	if !yamlIsBlock(v) {
+		fmt.Fprintf(buf, " %s\n", yamlScalar(v))
+		return
+	}
+	buf.WriteString("\n")
+	switch t := v.(type) {
+	case map[string]interface{}:
+		writeYAMLMap(buf, indent, t)
+	case []interface{}:
+		writeYAMLSeq(buf, indent, t)
+	}
+}
+
+func writeYAMLSeq(buf *bytes.Buffer, indent int, list []interface{}) {
+	pad := strings.Repeat(" ", indent)
+	for _, e := range list {
+		switch t := e.(type) {
+		case map[string]interface{}:
+			if len(t) > 0 {
+				// The first key of a mapping element follows the sequence indicator.
+				item := new(bytes.Buffer)
+				writeYAMLMap(item, indent+2, t)
+				buf.WriteString(pad + "- ")
+				buf.Write(item.Bytes()[indent+2:])
+				continue
+			}
+		case []interface{}:
+			if len(t) > 0 {
+				buf.WriteString(pad + "-\n")
+				writeYAMLSeq(buf, indent+2, t)
+				continue
+			}
+		}
+		fmt.Fprintf(buf, "%s- %s\n", pad, yamlScalar(e))
+	}
+}
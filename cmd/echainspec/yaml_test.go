@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+	"gopkg.in/yaml.v2"
+)
+
+func TestYAMLMarshal(t *testing.T) {
+	spec := &parity.ParityChainSpec{Name: "test"}
+	spec.Params.ChainID = new(parity.ParityU64)
+	*spec.Params.ChainID = 61
+	n := uint64(1150000)
+	if err := spec.SetEIP155Transition(&n); err != nil {
+		t.Fatal(err)
+	}
+	spec.Genesis.Difficulty = math.NewHexOrDecimal256(0xab)
+	b, err := yamlMarshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"name: \"test\"\n",
+		"  eip155Transition: 1150000\n",
+		"  chainID: \"0x3d\"\n",
+		"  difficulty: \"0xab\"\n",
+	} {
+		if !bytes.Contains(b, []byte(want)) {
+			t.Errorf("missing %q in output:\n%s", want, b)
+		}
+	}
+	var v map[string]interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, b)
+	}
+
+	// Alloc keys are quoted, block style mappings.
+	gen := &genesisT.Genesis{
+		Config: defaultChainspecValues["classic"].(*genesisT.Genesis).Config,
+		Alloc:  genesisT.GenesisAlloc{common.HexToAddress("0x01"): {Balance: big.NewInt(1)}},
+	}
+	b, err = yamlMarshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte("alloc:\n  \"0000000000000000000000000000000000000001\":\n    balance: \"0x1\"\n")) {
+		t.Errorf("unexpected alloc encoding:\n%s", b)
+	}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, b)
+	}
+	if c, err := yamlMarshal(gen); err != nil || !bytes.Equal(b, c) {
+		t.Errorf("output not deterministic")
+	}
+}
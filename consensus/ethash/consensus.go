@@ -383,6 +383,16 @@ func CalcDifficulty(config ctypes.ChainConfigurator, time uint64, parent *types.
 		}
 		exPeriodRef.Set(fakeBlockNumber)
 
+	} else if config.IsForked(config.GetEthashEIP3554Transition, next) {
+		// The calculation uses the Byzantium rules, but with bomb offset 9.7M.
+		// Specification EIP-3554: https://eips.ethereum.org/EIPS/eip-3554
+		fakeBlockNumber := new(big.Int)
+		delayWithOffset := new(big.Int).Sub(vars.EIP3554DifficultyBombDelay, common.Big1)
+		if parent.Number.Cmp(delayWithOffset) >= 0 {
+			fakeBlockNumber = fakeBlockNumber.Sub(parent.Number, delayWithOffset)
+		}
+		exPeriodRef.Set(fakeBlockNumber)
+
 	} else if config.IsForked(config.GetEthashEIP2384Transition, next) {
 		// calcDifficultyEIP2384 is the difficulty adjustment algorithm for Muir Glacier.
 		// The calculation uses the Byzantium rules, but with bomb offset 9M.
@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -166,11 +167,24 @@ func configOrDefault(g *genesisT.Genesis, ghash common.Hash) ctypes.ChainConfigu
 // GenesisToBlock creates the genesis block and writes state of a genesis specification
 // to the given database (or discards it if nil).
 func GenesisToBlock(g *genesisT.Genesis, db ethdb.Database) *types.Block {
+	block, _ := GenesisToBlockContext(context.Background(), g, db)
+	return block
+}
+
+// GenesisToBlockContext is like GenesisToBlock, but stops building the genesis
+// state and returns the context's error if the context is done first.
+func GenesisToBlockContext(ctx context.Context, g *genesisT.Genesis, db ethdb.Database) (*types.Block, error) {
 	if db == nil {
 		db = rawdb.NewMemoryDatabase()
 	}
 	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
 	for addr, account := range g.Alloc {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if account.BuiltinOnly() {
+			continue
+		}
 		statedb.AddBalance(addr, account.Balance)
 		statedb.SetCode(addr, account.Code)
 		statedb.SetNonce(addr, account.Nonce)
@@ -201,7 +215,7 @@ func GenesisToBlock(g *genesisT.Genesis, db ethdb.Database) *types.Block {
 	statedb.Commit(false)
 	statedb.Database().TrieDB().Commit(root, true)
 
-	return types.NewBlock(head, nil, nil, nil)
+	return types.NewBlock(head, nil, nil, nil), nil
 }
 
 // CommitGenesis writes the block and state of a genesis specification to the database.
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/confp"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
 	"github.com/ethereum/go-ethereum/params/types/multigeth"
 	"github.com/ethereum/go-ethereum/params/types/multigethv0"
 )
@@ -66,6 +67,25 @@ func TestSetupGenesisBlock(t *testing.T) {
 	}
 }
 
+// TestGenesisToBlockBuiltinOnly tests that genesis accounts which only declare a builtin
+// are not part of the genesis state, so do not change the genesis hash.
+func TestGenesisToBlockBuiltinOnly(t *testing.T) {
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000100")
+	builtin := json.RawMessage(`{"name":"custom","pricing":{"linear":{"base":15,"word":3}}}`)
+
+	gen := params.DefaultGenesisBlock()
+	gen.Alloc[addr] = genesisT.GenesisAccount{Balance: new(big.Int), Builtin: builtin}
+	if hash := GenesisToBlock(gen, nil).Hash(); hash != params.MainnetGenesisHash {
+		t.Errorf("builtin-only account: mismatch block hash, want: %x, got: %x", params.MainnetGenesisHash, hash)
+	}
+
+	// An account with a builtin and a balance is part of the genesis state.
+	gen.Alloc[addr] = genesisT.GenesisAccount{Balance: big.NewInt(1), Builtin: builtin}
+	if hash := GenesisToBlock(gen, nil).Hash(); hash == params.MainnetGenesisHash {
+		t.Errorf("builtin account with balance: want block hash other than %x", params.MainnetGenesisHash)
+	}
+}
+
 func TestSetupGenesisBlockOldVsNewMultigeth(t *testing.T) {
 	db := rawdb.NewMemoryDatabase()
 
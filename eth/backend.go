@@ -258,7 +258,11 @@ func CreateConsensusEngine(ctx *node.ServiceContext, chainConfig ctypes.ChainCon
 			Epoch:  chainConfig.GetCliqueEpoch(),
 		}, db)
 	}
-	// Otherwise assume proof-of-work
+	// Otherwise assume proof-of-work, unless the chain is configured not to verify it
+	if chainConfig.GetEthashNoProof() {
+		log.Warn("Ethash used in fake mode, as configured by the chain (NoProof)")
+		return ethash.NewFaker()
+	}
 	switch config.PowMode {
 	case ethash.ModeFake:
 		log.Warn("Ethash used in fake mode")
@@ -88,6 +88,30 @@ func (err *ConfigValidError) Error() string {
 	return fmt.Sprintf("%s, %v/%v", err.What, err.A, err.B)
 }
 
+// Explain describes the error in words, naming its A and B values by their labels
+// (eg. "EIP1344 requires EIP155 which activates later (EIP1344: 10, EIP155: 20)") if What has them.
+func (err *ConfigValidError) Explain() string {
+	i := strings.LastIndex(err.What, ". A:")
+	if i < 0 {
+		return err.Error()
+	}
+	labels := strings.SplitN(err.What[i+len(". A:"):], "/B:", 2)
+	if len(labels) != 2 {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s (%s: %s, %s: %s)", err.What[:i], labels[0], explainValue(err.A), labels[1], explainValue(err.B))
+}
+
+func explainValue(v interface{}) string {
+	if n, ok := v.(*uint64); ok {
+		if n == nil {
+			return "not set"
+		}
+		return fmt.Sprint(*n)
+	}
+	return fmt.Sprint(v)
+}
+
 func IsEmpty(anything interface{}) bool {
 	if anything == nil {
 		return true
@@ -96,22 +120,14 @@ func IsEmpty(anything interface{}) bool {
 }
 
 func IsValid(conf ctypes.ChainConfigurator, head *uint64) *ConfigValidError {
-
-	// head-agnostic logic
-	if conf.GetNetworkID() == nil || *conf.GetNetworkID() == 0 {
-		return NewValidErr("NetworkID cannot be empty nor zero", ">=0", conf.GetNetworkID())
-	}
-	if head == nil {
-		return nil
-	}
-
-	// head-full logic
-	var bhead = new(big.Int).SetUint64(*head)
-
-	if conf.IsForked(conf.GetEIP155Transition, bhead) && conf.GetChainID() == nil {
-		return NewValidErr("EIP155 requires ChainID. A:EIP155/B:ChainID", conf.GetEIP155Transition(), conf.GetChainID())
+	for _, v := range []configValidator{
+		validateNetworkID,     // head-agnostic logic
+		validateEIP155ChainID, // head-full logic
+	} {
+		if errs := v(conf, head); len(errs) > 0 {
+			return errs[0]
+		}
 	}
-
 	return nil
 }
 
@@ -191,13 +207,13 @@ func Equivalent(a, b ctypes.ChainConfigurator) error {
 			return *y == math.MaxUint64 ||
 				*y == 0x7FFFFFFFFFFFFFFF ||
 				*y == 0x7FFFFFFFFFFFFFF ||
-				*y == 0x7FFFFFFFFFFFFF
+				*y == ctypes.ParityNeverTransition
 		}
 		if x != nil && y == nil {
 			return *x == math.MaxUint64 ||
 				*x == 0x7FFFFFFFFFFFFFFF ||
 				*x == 0x7FFFFFFFFFFFFFF ||
-				*x == 0x7FFFFFFFFFFFFF
+				*x == ctypes.ParityNeverTransition
 		}
 		return false
 	}
@@ -247,18 +263,85 @@ func Transitions(conf ctypes.ChainConfigurator) (fns []func() *uint64, names []s
 	return fns, names
 }
 
+// TransitionTimes gets all available timestamp transition functions and their names for a ChainConfigurator.
+// Unlike those of Transitions, their values are block timestamps, not block numbers.
+func TransitionTimes(conf ctypes.ChainConfigurator) (fns []func() *uint64, names []string) {
+	names = []string{}
+	fns = []func() *uint64{}
+	k := reflect.TypeOf(conf)
+	for i := 0; i < k.NumMethod(); i++ {
+		method := k.Method(i)
+		if !strings.HasPrefix(method.Name, "Get") || !strings.HasSuffix(method.Name, "TransitionTime") {
+			continue
+		}
+		m := reflect.ValueOf(conf).MethodByName(method.Name).Interface()
+		fns = append(fns, m.(func() *uint64))
+		names = append(names, method.Name)
+	}
+	return fns, names
+}
+
+// EIPActivations returns the activation values of a ChainConfigurator's transitions, by name.
+// A transition is named by its Get...Transition method, eg. "EIP155" for GetEIP155Transition,
+// and a timestamp transition by its Get...TransitionTime method with a Time suffix, eg. "EIP3855Time"
+// for GetEIP3855TransitionTime; the values of those are block timestamps, not block numbers.
+// Unset transitions have nil values.
+func EIPActivations(conf ctypes.ChainConfigurator) map[string]*uint64 {
+	values := make(map[string]*uint64)
+	fns, names := Transitions(conf)
+	for i, fn := range fns {
+		values[strings.TrimSuffix(strings.TrimPrefix(names[i], "Get"), "Transition")] = fn()
+	}
+	fns, names = TransitionTimes(conf)
+	for i, fn := range fns {
+		values[strings.TrimSuffix(strings.TrimPrefix(names[i], "Get"), "TransitionTime")+"Time"] = fn()
+	}
+	return values
+}
+
+// IsTimeActivation reports whether an EIPActivations name is that of a timestamp transition.
+func IsTimeActivation(name string) bool {
+	return strings.HasSuffix(name, "Time")
+}
+
+// ForkBlocks returns non-nil, non <maxUin64>, unique sorted fork blocks for a ChainConfigurator.
+func ForkBlocks(conf ctypes.ChainConfigurator) []uint64 {
+	var values []*uint64
+	for name, v := range EIPActivations(conf) {
+		if !IsTimeActivation(name) {
+			values = append(values, v)
+		}
+	}
+	return uniqueForks(values)
+}
+
 // Forks returns non-nil, non <maxUin64>, unique sorted forks for a ChainConfigurator.
+// It is ForkBlocks.
 func Forks(conf ctypes.ChainConfigurator) []uint64 {
+	return ForkBlocks(conf)
+}
+
+// ForkTimes returns non-nil, non <maxUin64>, unique sorted fork timestamps for a ChainConfigurator.
+// They are kept apart from ForkBlocks, since timestamps and block numbers are not comparable.
+func ForkTimes(conf ctypes.ChainConfigurator) []uint64 {
+	var values []*uint64
+	for name, v := range EIPActivations(conf) {
+		if IsTimeActivation(name) {
+			values = append(values, v)
+		}
+	}
+	return uniqueForks(values)
+}
+
+// uniqueForks returns the unique, sorted fork values of transitions, excluding those which are unset or never activate.
+func uniqueForks(values []*uint64) []uint64 {
 	var forks []uint64
 	var forksM = make(map[uint64]struct{}) // Will key for uniqueness as fork numbers are appended to slice.
 
-	transitions, _ := Transitions(conf)
-	for _, tr := range transitions {
-		// Extract the fork rule block number and aggregate it
-		response := tr()
+	for _, response := range values {
 		if response == nil ||
 			*response == math.MaxUint64 ||
-			*response == 0x7fffffffffffff ||
+			*response == ctypes.ParityNeverTransition ||
 			*response == 0x7FFFFFFFFFFFFFFF {
 			continue
 		}
@@ -17,15 +17,60 @@
 package confp
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/vars"
 )
 
 // Automagically translate between [Must|]Setters and Getters.
 func Convert(from, to interface{}) error {
+	_, err := ConvertWithWarnings(from, to)
+	return err
+}
+
+// Warning describes a source configuration value which a conversion
+// could not faithfully represent in the target.
+type Warning struct {
+	Field  string // Configurator field, named by its Get method suffix.
+	Value  interface{}
+	Reason string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s (value: %v)", w.Field, w.Reason, w.Value)
+}
+
+// ConvertWithWarnings is like Convert, but also returns the source values
+// which were dropped or changed by the conversion.
+// Non-fatal losses do not fail the conversion.
+func ConvertWithWarnings(from, to interface{}) ([]Warning, error) {
+	c := &conversion{unsupported: make(map[string]bool)}
+	if err := c.convertAll(from, to); err != nil {
+		return c.warnings, err
+	}
+	// Setters may be interdependent, so values are compared only once all are set.
+	for _, k := range c.converted {
+		c.checkChanged(k, from, to)
+	}
+	return c.warnings, nil
+}
+
+// conversion accumulates the warnings of a conversion.
+type conversion struct {
+	warnings    []Warning
+	unsupported map[string]bool // Fields whose values the target did not set.
+	converted   []reflect.Type  // Interfaces by which values were converted.
+}
+
+func (c *conversion) convertAll(from, to interface{}) error {
 	// Interfaces must be either ChainConfigurator or GenesisBlocker.
 	for i, v := range []interface{}{
 		from, to,
@@ -49,7 +94,7 @@ func Convert(from, to interface{}) error {
 		switch et {
 		case ctypes.BlockSealing_Ethereum:
 			k := reflect.TypeOf((*ctypes.GenesisBlocker)(nil)).Elem()
-			if err := convert(k, fromGener, toGener); err != nil {
+			if err := c.convert(k, fromGener, toGener); err != nil {
 				return err
 			}
 		default:
@@ -57,7 +102,14 @@ func Convert(from, to interface{}) error {
 		}
 
 		// Set accounts (genesis).
-		if err := fromGener.ForEachAccount(toGener.UpdateAccount); err != nil {
+		if err := fromGener.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+			return toGener.UpdateAccount(address, bal, nonce, code, storage)
+		}); err != nil {
+			return err
+		}
+
+		// Set builtins which are not modeled as precompile transitions, eg. a chain's custom precompiles.
+		if err := c.convertBuiltins(from, to); err != nil {
 			return err
 		}
 	}
@@ -71,7 +123,7 @@ func Convert(from, to interface{}) error {
 
 	// Set general chain parameters.
 	k := reflect.TypeOf((*ctypes.CatHerder)(nil)).Elem()
-	if err := convert(k, fromChainer, toChainer); err != nil {
+	if err := c.convert(k, fromChainer, toChainer); err != nil {
 		return err
 	}
 
@@ -79,6 +131,12 @@ func Convert(from, to interface{}) error {
 	for f, h := range fromChainer.GetForkCanonHashes() {
 		if err := toChainer.SetForkCanonHash(f, h); ctypes.IsFatalUnsupportedErr(err) {
 			return err
+		} else if err != nil {
+			c.warnings = append(c.warnings, Warning{
+				Field:  fmt.Sprintf("ForkCanonHash(%d)", f),
+				Value:  h.Hex(),
+				Reason: "not supported by target",
+			})
 		}
 	}
 
@@ -90,12 +148,12 @@ func Convert(from, to interface{}) error {
 	switch engineType {
 	case ctypes.ConsensusEngineT_Ethash:
 		k := reflect.TypeOf((*ctypes.EthashConfigurator)(nil)).Elem()
-		if err := convert(k, fromChainer, toChainer); err != nil {
+		if err := c.convert(k, fromChainer, toChainer); err != nil {
 			return err
 		}
 	case ctypes.ConsensusEngineT_Clique:
 		k := reflect.TypeOf((*ctypes.CliqueConfigurator)(nil)).Elem()
-		if err := convert(k, fromChainer, toChainer); err != nil {
+		if err := c.convert(k, fromChainer, toChainer); err != nil {
 			return err
 		}
 	default:
@@ -105,7 +163,59 @@ func Convert(from, to interface{}) error {
 	return nil
 }
 
-func convert(k reflect.Type, source, target interface{}) error {
+// builtinDeclarer is implemented by configurations whose genesis accounts may declare builtins
+// (precompiled contract definitions, as Parity's) which are not modeled as precompile transitions.
+type builtinDeclarer interface {
+	GetAccountBuiltins() map[common.Address]json.RawMessage
+}
+
+// builtinKeeper is implemented by configurations which may keep the builtins of genesis accounts.
+type builtinKeeper interface {
+	SetAccountBuiltin(address common.Address, builtin json.RawMessage) error
+}
+
+// convertBuiltins sets the builtins of the source's genesis accounts on the target,
+// warning of each, since the target does not model them, and of those it cannot keep.
+func (c *conversion) convertBuiltins(from, to interface{}) error {
+	declarer, ok := from.(builtinDeclarer)
+	if !ok {
+		return nil
+	}
+	builtins := declarer.GetAccountBuiltins()
+	addresses := make([]common.Address, 0, len(builtins))
+	for a := range builtins {
+		addresses = append(addresses, a)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	keeper, ok := to.(builtinKeeper)
+	for _, a := range addresses {
+		var name struct {
+			Name string `json:"name"`
+		}
+		json.Unmarshal(builtins[a], &name)
+		w := Warning{
+			Field:  fmt.Sprintf("Builtin(%s)", strings.ToLower(a.Hex())),
+			Value:  name.Name,
+			Reason: "not modeled; kept as a genesis account builtin",
+		}
+		if !ok {
+			w.Reason = "not supported by target"
+		} else if err := keeper.SetAccountBuiltin(a, builtins[a]); ctypes.IsFatalUnsupportedErr(err) {
+			return ctypes.UnsupportedConfigError(err, w.Field, name.Name)
+		} else if err == ctypes.ErrUnsupportedConfigNoop {
+			w.Reason = "not supported by target"
+		} else if err != nil {
+			return fmt.Errorf("%s: %v", w.Field, err)
+		}
+		c.warnings = append(c.warnings, w)
+	}
+	return nil
+}
+
+func (c *conversion) convert(k reflect.Type, source, target interface{}) error {
+	c.converted = append(c.converted, k)
 	for i := 0; i < k.NumMethod(); i++ {
 		method := k.Method(i)
 
@@ -124,19 +234,131 @@ func convert(k reflect.Type, source, target interface{}) error {
 		if !setResponse[0].IsNil() {
 			err := setResponse[0].Interface().(error)
 			v := response[0].Interface()
-			if !response[0].IsNil() {
+			if response[0].Kind() == reflect.Ptr && !response[0].IsNil() {
 				v = response[0].Elem().Interface()
 			}
 			e := ctypes.UnsupportedConfigError(err, strings.TrimPrefix(method.Name, "Get"), v)
 			if ctypes.IsFatalUnsupportedErr(err) {
 				return e
 			}
-			//log.Println(e) // FIXME?
+			// The target may still infer an equal value; this is checked once all values are set.
+			c.unsupported[e.Method] = true
 		}
 	}
 	return nil
 }
 
+// checkChanged warns of the source values of the interface's fields which
+// differ in the target, ie. which the target does not support,
+// or which the target changes (eg. by inferring them from other fields).
+func (c *conversion) checkChanged(k reflect.Type, source, target interface{}) {
+	for i := 0; i < k.NumMethod(); i++ {
+		method := k.Method(i)
+		field := strings.TrimPrefix(method.Name, "Get")
+		if field == method.Name {
+			continue
+		}
+		if _, ok := k.MethodByName("Set" + field); !ok {
+			continue
+		}
+		want := reflect.ValueOf(source).MethodByName(method.Name).Call(nil)[0]
+		if isEmptyValue(want) {
+			continue
+		}
+		got := reflect.ValueOf(target).MethodByName(method.Name).Call(nil)[0]
+		if equalValues(want, got) {
+			continue
+		}
+		if effective, ok := effectiveScheduleValues[field]; ok && equalSchedules(want, source, target, effective) {
+			continue
+		}
+		reason := fmt.Sprintf("changed by target to %v", formatValue(got))
+		if c.unsupported[field] {
+			reason = "not supported by target"
+		}
+		c.warnings = append(c.warnings, Warning{Field: field, Value: formatValue(want), Reason: reason})
+	}
+}
+
+// effectiveScheduleValues are the functions returning the effective value at a block
+// of the schedule fields which some formats do not represent, but infer from transitions.
+var effectiveScheduleValues = map[string]func(c ctypes.ChainConfigurator, n uint64) *big.Int{
+	"EthashBlockRewardSchedule": func(c ctypes.ChainConfigurator, n uint64) *big.Int {
+		return ctypes.EthashBlockReward(c, new(big.Int).SetUint64(n))
+	},
+	"EthashDifficultyBombDelaySchedule": ethashBombDelay,
+}
+
+// ethashBombDelay returns the total difficulty bomb delay in effect at a block.
+func ethashBombDelay(c ctypes.ChainConfigurator, n uint64) *big.Int {
+	if schedule := c.GetEthashDifficultyBombDelaySchedule(); len(schedule) > 0 {
+		sum := new(big.Int)
+		for activation, delay := range schedule {
+			if activation <= n {
+				sum.Add(sum, delay)
+			}
+		}
+		return sum
+	}
+	switch nn := new(big.Int).SetUint64(n); {
+	case c.IsForked(c.GetEthashEIP3554Transition, nn):
+		return vars.EIP3554DifficultyBombDelay
+	case c.IsForked(c.GetEthashEIP2384Transition, nn):
+		return vars.EIP2384DifficultyBombDelay
+	case c.IsForked(c.GetEthashEIP1234Transition, nn):
+		return vars.EIP1234DifficultyBombDelay
+	case c.IsForked(c.GetEthashEIP649Transition, nn):
+		return vars.EIP649DifficultyBombDelay
+	}
+	return new(big.Int)
+}
+
+// equalSchedules reports whether the source and target have equal effective values
+// at each activation block of the source schedule.
+func equalSchedules(schedule reflect.Value, source, target interface{}, effective func(c ctypes.ChainConfigurator, n uint64) *big.Int) bool {
+	sc, ok := source.(ctypes.ChainConfigurator)
+	if !ok {
+		return false
+	}
+	tc, ok := target.(ctypes.ChainConfigurator)
+	if !ok {
+		return false
+	}
+	for n := range schedule.Interface().(ctypes.Uint64BigMapEncodesHex) {
+		if effective(sc, n).Cmp(effective(tc, n)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyValue reports whether a getter value is nil or empty, ie. there is nothing to lose.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// equalValues compares getter values by their formatted representations,
+// since equal big.Ints, for example, may not be deeply equal.
+func equalValues(a, b reflect.Value) bool {
+	if isEmptyValue(a) || isEmptyValue(b) {
+		return isEmptyValue(a) && isEmptyValue(b)
+	}
+	return fmt.Sprint(formatValue(a)) == fmt.Sprint(formatValue(b))
+}
+
+func formatValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+	return v.Interface()
+}
+
 type DiffT struct {
 	Field string
 	A     interface{}
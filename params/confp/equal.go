@@ -0,0 +1,152 @@
+// Copyright 2020 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package confp
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// EqualConfigs reports whether two configurations are semantically equal, ie. whether they
+// have equal chain IDs, fork activations, consensus engines and engine parameters,
+// and genesis accounts. Differences of representation (eg. a nil or empty schedule,
+// or a schedule which a format infers from transitions, or ethash transitions of a chain of
+// another engine) are ignored.
+// If the configurations are not equivalent, the paths of the differing fields are
+// returned in sorted order, eg. "EIP155Transition", "CliquePeriod", or "GenesisAlloc.<address>".
+// Unlike Equivalent, which compares fork compatibility only, all of these fields are compared.
+func EqualConfigs(a, b ctypes.Configurator) (bool, []string) {
+	diffs := []string{}
+	for _, d := range ConfigDifferences(a, b) {
+		diffs = append(diffs, d.Field)
+	}
+	return len(diffs) == 0, diffs
+}
+
+// ConfigDifferences returns the fields which EqualConfigs finds to differ, with their values (see Differences).
+func ConfigDifferences(a, b ctypes.Configurator) []DiffT {
+	return Differences(a, b, func(field string) bool {
+		switch field {
+		case "ChainID", "ConsensusEngineType", "GenesisAlloc":
+			return true
+		}
+		return isTransition(field) || strings.HasPrefix(field, "Ethash") || strings.HasPrefix(field, "Clique")
+	})
+}
+
+// Differences returns the fields of a and b, selected by name, whose values differ semantically, in sorted order.
+// Fields are named by their getters without the Get prefix, eg. "EIP155Transition" or "CliquePeriod",
+// and genesis accounts as "GenesisAlloc.<address>", which are compared if "GenesisAlloc" is selected.
+// The values are those of the getters, or for an account, a summary of it (nil if there is none).
+// As for EqualConfigs, empty values are equal, a schedule is equal to one which a format infers from
+// transitions if their effective values are, and the parameters of a consensus engine are compared only
+// if both configurations use it, and its transitions only if either does.
+func Differences(a, b ctypes.Configurator, selected func(field string) bool) []DiffT {
+	engines := map[string]func(ctypes.ConsensusEngineT) bool{
+		"Ethash": ctypes.ConsensusEngineT.IsEthash,
+		"Clique": ctypes.ConsensusEngineT.IsClique,
+	}
+	compared := func(field string) bool {
+		for prefix, uses := range engines {
+			if !strings.HasPrefix(field, prefix) {
+				continue
+			}
+			if isTransition(field) {
+				return uses(a.GetConsensusEngineType()) || uses(b.GetConsensusEngineType())
+			}
+			return uses(a.GetConsensusEngineType()) && uses(b.GetConsensusEngineType())
+		}
+		return true
+	}
+	diffs := []DiffT{}
+	k := reflect.TypeOf((*ctypes.Configurator)(nil)).Elem()
+	for i := 0; i < k.NumMethod(); i++ {
+		method := k.Method(i)
+		field := strings.TrimPrefix(method.Name, "Get")
+		if field == method.Name || method.Type.NumIn() != 0 || method.Type.NumOut() != 1 || !selected(field) || !compared(field) {
+			continue
+		}
+		if !equalField(a, b, field) {
+			diffs = append(diffs, DiffT{
+				Field: field,
+				A:     reflect.ValueOf(a).MethodByName(method.Name).Call(nil)[0].Interface(),
+				B:     reflect.ValueOf(b).MethodByName(method.Name).Call(nil)[0].Interface(),
+			})
+		}
+	}
+	if selected("GenesisAlloc") {
+		am, bm := accountSummaries(a), accountSummaries(b)
+		for addr, s := range am {
+			if bs, ok := bm[addr]; !ok {
+				diffs = append(diffs, DiffT{Field: "GenesisAlloc." + addr.Hex(), A: s})
+			} else if bs != s {
+				diffs = append(diffs, DiffT{Field: "GenesisAlloc." + addr.Hex(), A: s, B: bs})
+			}
+		}
+		for addr, s := range bm {
+			if _, ok := am[addr]; !ok {
+				diffs = append(diffs, DiffT{Field: "GenesisAlloc." + addr.Hex(), B: s})
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs
+}
+
+// isTransition reports whether a field is a transition, by block or timestamp.
+func isTransition(field string) bool {
+	return strings.HasSuffix(field, "Transition") || strings.HasSuffix(field, "TransitionTime")
+}
+
+// equalField reports whether the values of a field, named by its getter without the Get prefix
+// (eg. "EIP155Transition"), are equal in a and b: empty values are equal, and a schedule is equal
+// to one which a format infers from transitions, if their effective values are.
+func equalField(a, b interface{}, field string) bool {
+	av := reflect.ValueOf(a).MethodByName("Get" + field).Call(nil)[0]
+	bv := reflect.ValueOf(b).MethodByName("Get" + field).Call(nil)[0]
+	if equalValues(av, bv) {
+		return true
+	}
+	effective, ok := effectiveScheduleValues[field]
+	return ok && equalSchedules(av, a, b, effective) && equalSchedules(bv, b, a, effective)
+}
+
+// accountSummaries returns a comparable summary of each genesis account.
+// A nil balance is equivalent to a zero balance.
+func accountSummaries(c ctypes.GenesisBlocker) map[common.Address]string {
+	m := make(map[common.Address]string)
+	c.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		if bal == nil {
+			bal = new(big.Int)
+		}
+		s := fmt.Sprintf("balance=%v nonce=%d code=%x", bal, nonce, code)
+		if len(storage) > 0 {
+			s += fmt.Sprintf(" storage=%v", storage)
+		}
+		m[address] = s
+		return nil
+	})
+	return m
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/aleth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+// TestAlethRoundTrip tests that converting the foundation configuration to aleth,
+// and back to multigeth, preserves all fork activation blocks.
+func TestAlethRoundTrip(t *testing.T) {
+	foundation := params.DefaultGenesisBlock()
+
+	spec := &aleth.AlethGenesisSpec{}
+	if err := confp.Convert(foundation, spec); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+
+	str := func(u *uint64) string {
+		if u == nil {
+			return "nil"
+		}
+		return fmt.Sprint(*u)
+	}
+	wantFns, names := confp.Transitions(foundation)
+	gotFns, _ := confp.Transitions(mg)
+	for i := range wantFns {
+		want, got := wantFns[i](), gotFns[i]()
+		if (want == nil) != (got == nil) || (want != nil && *want != *got) {
+			t.Errorf("%s: want: %v, got: %v", names[i], str(want), str(got))
+		}
+	}
+	if err := confp.Equivalent(foundation, mg); err != nil {
+		t.Error(err)
+	}
+	if len(mg.Alloc) != len(foundation.Alloc) {
+		t.Errorf("alloc: want: %d accounts, got: %d", len(foundation.Alloc), len(mg.Alloc))
+	}
+}
+
+// TestAlethSturebyRead tests that the aleth stureby spec is read equivalently to the geth stureby genesis.
+func TestAlethSturebyRead(t *testing.T) {
+	spec := &aleth.AlethGenesisSpec{}
+	mustOpenF(t, "aleth", spec)
+	gen := &genesisT.Genesis{}
+	mustOpenF(t, "geth", gen)
+
+	if err := confp.Equivalent(gen, spec); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestAlethNoProofConvert tests that a test chain of Aleth's NoProof seal engine keeps it
+// through conversion, rather than becoming a proof-of-work chain.
+func TestAlethNoProofConvert(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "noproof_aleth.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := echainspec.ReadStrict("aleth", bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.GetEthashNoProof() {
+		t.Fatal("aleth: NoProof not read")
+	}
+	mg, err := echainspec.Convert(conf, "multigeth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mg.GetEthashNoProof() {
+		t.Fatal("multigeth: NoProof lost")
+	}
+	back, err := echainspec.Convert(mg, "aleth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back.GetEthashNoProof() {
+		t.Error("aleth round trip: NoProof lost")
+	}
+
+	// Formats which cannot represent it refuse the conversion.
+	for _, format := range []string{"geth", "parity"} {
+		_, err := echainspec.Convert(conf, format)
+		e, ok := err.(ctypes.ErrUnsupportedConfig)
+		if !ok || !ctypes.IsFatalUnsupportedErr(e.Err) {
+			t.Errorf("%s: want fatal unsupported error, got: %v", format, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+package convert_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// mixedCaseAccounts returns a JSON object of accounts keyed by the given address strings.
+func mixedCaseAccounts(keys ...string) string {
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		entries[i] = `"` + k + `": {"balance": "0x1"}`
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+func TestAccountKeyDuplicates(t *testing.T) {
+	const (
+		checksum = "0x8A8eAFb1cf62BfBeb1741769DAE1a9dd47996192"
+		lower    = "8a8eafb1cf62bfbeb1741769dae1a9dd47996192"
+		other    = "0X0000000000000000000000000000000000000001"
+	)
+	for _, keys := range [][]string{
+		{checksum, lower},
+		{lower, "0x" + lower},
+		{other, checksum, "0x" + strings.ToUpper(lower)},
+	} {
+		accounts := mixedCaseAccounts(keys...)
+
+		var alloc genesisT.GenesisAlloc
+		if err := json.Unmarshal([]byte(accounts), &alloc); !errors.Is(err, ctypes.ErrDuplicateAccount) {
+			t.Errorf("genesis %v: got %v, want %v", keys, err, ctypes.ErrDuplicateAccount)
+		} else if !strings.Contains(err.Error(), "0x"+lower) {
+			t.Errorf("genesis %v: error %q does not name the canonical address", keys, err)
+		}
+		if err := json.Unmarshal([]byte(`{"accounts": `+accounts+`}`), &parity.ParityChainSpec{}); !errors.Is(err, ctypes.ErrDuplicateAccount) {
+			t.Errorf("parity %v: got %v, want %v", keys, err, ctypes.ErrDuplicateAccount)
+		}
+		// Genesis files of each format decode their alloc without GenesisAlloc.
+		gen := `{"config": {"chainId": 1, "homesteadBlock": 0}, "difficulty": "0x1", "gasLimit": "0x1388", "alloc": ` + accounts + `}`
+		if err := json.Unmarshal([]byte(gen), &genesisT.Genesis{}); !errors.Is(err, ctypes.ErrDuplicateAccount) {
+			t.Errorf("genesis file %v: got %v, want %v", keys, err, ctypes.ErrDuplicateAccount)
+		}
+		for _, format := range []string{"geth", "multigeth", "besu"} {
+			if _, err := echainspec.Read(format, strings.NewReader(gen)); !errors.Is(err, ctypes.ErrDuplicateAccount) {
+				t.Errorf("%s %v: got %v, want %v", format, keys, err, ctypes.ErrDuplicateAccount)
+			}
+		}
+	}
+
+	// Distinct addresses in mixed forms convert to the same canonical accounts.
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(params.DefaultGoerliGenesisBlock(), spec); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	m["accounts"] = json.RawMessage(mixedCaseAccounts(checksum, other))
+	if b, err = json.Marshal(m); err != nil {
+		t.Fatal(err)
+	}
+	spec = &parity.ParityChainSpec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		t.Fatal(err)
+	}
+	gen := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, gen); err != nil {
+		t.Fatal(err)
+	}
+	if len(gen.Alloc) != 2 {
+		t.Fatalf("got %d accounts, want 2", len(gen.Alloc))
+	}
+	for _, k := range []string{lower, "0x01"} {
+		if _, ok := gen.Alloc[common.HexToAddress(k)]; !ok {
+			t.Errorf("missing account %s", k)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// TestBerlinConvert tests that a London-era go-ethereum configuration's Berlin
+// (EIP-2929 and EIP-2930) and London blocks survive conversion to multigeth and Parity, and back.
+func TestBerlinConvert(t *testing.T) {
+	geth := &genesisT.Genesis{
+		Config: &goethereum.ChainConfig{
+			ChainID:        big.NewInt(1),
+			HomesteadBlock: big.NewInt(0),
+			EIP150Block:    big.NewInt(0),
+			EIP155Block:    big.NewInt(0),
+			EIP158Block:    big.NewInt(0),
+			ByzantiumBlock: big.NewInt(0),
+			IstanbulBlock:  big.NewInt(0),
+			BerlinBlock:    big.NewInt(12244000),
+			LondonBlock:    big.NewInt(12965000),
+			Ethash:         new(ctypes.EthashConfig),
+		},
+		Difficulty: big.NewInt(1),
+	}
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}, Alloc: genesisT.GenesisAlloc{}}
+	if err := confp.Convert(geth, mg); err != nil {
+		t.Fatal(err)
+	}
+	for name, got := range map[string]*uint64{
+		"EIP2718": mg.GetEIP2718Transition(),
+		"EIP2929": mg.GetEIP2929Transition(),
+		"EIP2930": mg.GetEIP2930Transition(),
+	} {
+		if got == nil || *got != 12244000 {
+			t.Errorf("multigeth %s: got %v, want 12244000", name, got)
+		}
+	}
+	// Reread, so that only the written fields are converted back.
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, mg); err != nil {
+		t.Fatal(err)
+	}
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.Params.EIP2930Transition; got == nil || uint64(*got) != 12244000 {
+		t.Errorf("parity EIP2930: got %v, want 12244000", got)
+	}
+
+	for name, src := range map[string]ctypes.Configurator{"multigeth": mg, "parity": spec} {
+		back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+		if err := confp.Convert(src, back); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		c := back.Config.(*goethereum.ChainConfig)
+		if c.BerlinBlock == nil || c.BerlinBlock.Uint64() != 12244000 {
+			t.Errorf("%s: berlin block: got %v, want 12244000", name, c.BerlinBlock)
+		}
+		if c.LondonBlock == nil || c.LondonBlock.Uint64() != 12965000 {
+			t.Errorf("%s: london block: got %v, want 12965000", name, c.LondonBlock)
+		}
+	}
+}
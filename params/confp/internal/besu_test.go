@@ -0,0 +1,78 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+// TestBesuRoundTrip tests that converting the foundation configuration to Besu,
+// and back to multigeth, preserves the configuration.
+func TestBesuRoundTrip(t *testing.T) {
+	foundation := params.DefaultGenesisBlock()
+
+	gen := &genesisT.Genesis{Config: &besu.BesuChainConfig{}}
+	if err := confp.Convert(foundation, gen); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(gen, mg); err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Equivalent(foundation, mg); err != nil {
+		t.Error(err)
+	}
+	if len(mg.Alloc) != len(foundation.Alloc) {
+		t.Errorf("alloc: want: %d accounts, got: %d", len(foundation.Alloc), len(mg.Alloc))
+	}
+}
+
+// TestBesuClique tests that clique settings are mapped between go-ethereum's Clique
+// configuration and Besu's clique block.
+func TestBesuClique(t *testing.T) {
+	gen := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	mustOpenF(t, "geth", gen)
+	if err := gen.MustSetConsensusEngineType(ctypes.ConsensusEngineT_Clique); err != nil {
+		t.Fatal(err)
+	}
+	gen.SetCliquePeriod(15)
+	gen.SetCliqueEpoch(30000)
+
+	bg := &genesisT.Genesis{Config: &besu.BesuChainConfig{}}
+	if err := confp.Convert(gen, bg); err != nil {
+		t.Fatal(err)
+	}
+	clique := bg.Config.(*besu.BesuChainConfig).Clique
+	if clique == nil || clique.BlockPeriodSeconds != 15 || clique.EpochLength != 30000 {
+		t.Fatalf("got clique config %+v, want period 15, epoch 30000", clique)
+	}
+	back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(bg, back); err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Equivalent(gen, back); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// effectiveBombDelay returns the difficulty bomb delay at block n,
+// as the ethash difficulty calculation determines it.
+func effectiveBombDelay(c ctypes.ChainConfigurator, n uint64) *big.Int {
+	if schedule := c.GetEthashDifficultyBombDelaySchedule(); len(schedule) > 0 {
+		delay := new(big.Int)
+		for k, v := range schedule {
+			if k <= n {
+				delay.Add(delay, v)
+			}
+		}
+		return delay
+	}
+	bn := new(big.Int).SetUint64(n)
+	switch {
+	case c.IsForked(c.GetEthashEIP2384Transition, bn):
+		return vars.EIP2384DifficultyBombDelay
+	case c.IsForked(c.GetEthashEIP1234Transition, bn):
+		return vars.EIP1234DifficultyBombDelay
+	case c.IsForked(c.GetEthashEIP649Transition, bn):
+		return vars.EIP649DifficultyBombDelay
+	}
+	return new(big.Int)
+}
+
+// TestBombDelayConvert tests that the difficulty bomb delay in effect is preserved
+// when converting between go-ethereum's fork blocks and the multigeth and Parity delay schedules,
+// including when the delay forks coincide.
+func TestBombDelayConvert(t *testing.T) {
+	cases := []struct {
+		byzantium, constantinople, muirGlacier int64
+	}{
+		{4370000, 7280000, 9200000}, // mainnet
+		{0, 0, 5},
+		{0, 0, 0},
+		{0, 10, 10},
+	}
+	for i, c := range cases {
+		src := &genesisT.Genesis{
+			Difficulty: big.NewInt(1),
+			Config: &goethereum.ChainConfig{
+				ChainID:             big.NewInt(1),
+				HomesteadBlock:      big.NewInt(0),
+				EIP150Block:         big.NewInt(0),
+				EIP155Block:         big.NewInt(0),
+				EIP158Block:         big.NewInt(0),
+				ByzantiumBlock:      big.NewInt(c.byzantium),
+				ConstantinopleBlock: big.NewInt(c.constantinople),
+				PetersburgBlock:     big.NewInt(c.constantinople),
+				MuirGlacierBlock:    big.NewInt(c.muirGlacier),
+				Ethash:              new(ctypes.EthashConfig),
+			},
+		}
+
+		mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}, Alloc: genesisT.GenesisAlloc{}}
+		if err := confp.Convert(src, mg); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		// Reread, so that transitions are inferred from the schedules.
+		b, err := json.Marshal(mg)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+		if err := json.Unmarshal(b, mg); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		p := &parity.ParityChainSpec{}
+		if err := confp.Convert(src, p); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+		if err := confp.Convert(mg, back); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+
+		for _, n := range []uint64{0, uint64(c.byzantium), uint64(c.constantinople), uint64(c.muirGlacier), uint64(c.muirGlacier) + 1, 20000000} {
+			want := effectiveBombDelay(src.Config, n)
+			for name, conf := range map[string]ctypes.ChainConfigurator{
+				"multigeth":         mg.Config,
+				"parity":            p,
+				"geth (round trip)": back.Config,
+			} {
+				if got := effectiveBombDelay(conf, n); got.Cmp(want) != 0 {
+					t.Errorf("case %d: %s: block %d: got delay %v, want %v", i, name, n, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// TestCancunConvert tests that a Cancun activation time and its blob parameters
+// round-trip through formats which can configure them.
+func TestCancunConvert(t *testing.T) {
+	geth := &genesisT.Genesis{}
+	if err := json.Unmarshal([]byte(`{
+		"config": {"chainId": 1, "londonBlock": 0, "shanghaiTime": 100, "cancunTime": 200,
+			"blobSchedule": {"cancun": {"target": 4, "max": 8, "baseFeeUpdateFraction": 5007716}}, "ethash": {}},
+		"difficulty": "0x1", "gasLimit": "0x1000000", "alloc": {}
+	}`), geth); err != nil {
+		t.Fatal(err)
+	}
+	cancun := func(conf ctypes.ChainConfigurator) []*uint64 {
+		return []*uint64{
+			conf.GetEIP1153TransitionTime(),
+			conf.GetEIP4788TransitionTime(),
+			conf.GetEIP4844TransitionTime(),
+			conf.GetEIP5656TransitionTime(),
+			conf.GetEIP6780TransitionTime(),
+			conf.GetEIP7516TransitionTime(),
+		}
+	}
+	blobs := func(conf ctypes.ChainConfigurator) []uint64 {
+		return []uint64{
+			*conf.GetEIP4844TargetBlobsPerBlock(),
+			*conf.GetEIP4844MaxBlobsPerBlock(),
+			*conf.GetEIP4844BlobBaseFeeUpdateFraction(),
+		}
+	}
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(geth, mg); err != nil {
+		t.Fatal(err)
+	}
+	bs := &genesisT.Genesis{Config: &besu.BesuChainConfig{}}
+	if err := confp.Convert(mg, bs); err != nil {
+		t.Fatal(err)
+	}
+	back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(bs, back); err != nil {
+		t.Fatal(err)
+	}
+	for format, conf := range map[string]ctypes.ChainConfigurator{
+		"multigeth": mg,
+		"besu":      bs,
+		"geth":      back,
+	} {
+		for i, got := range cancun(conf) {
+			if got == nil || *got != 200 {
+				t.Errorf("%s Cancun EIP %d: got %v, want 200", format, i, got)
+			}
+		}
+		if got, want := blobs(conf), []uint64{4, 8, 5007716}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s blob parameters: got %v, want %v", format, got, want)
+		}
+	}
+	if ok, diffs := confp.EqualConfigs(geth, back); !ok {
+		t.Errorf("round trip differs: %v", diffs)
+	}
+	if b, err := json.Marshal(back.Config); err != nil {
+		t.Fatal(err)
+	} else if want := `"blobSchedule":{"cancun":{"target":4,"max":8,"baseFeeUpdateFraction":5007716}}`; !strings.Contains(string(b), want) {
+		t.Errorf("geth: got %s, want %s", b, want)
+	}
+
+	// A Cancun EIP may activate separately; the others default to EIP-4844's activation.
+	mgc := mg.Config.(*multigeth.MultiGethChainConfig)
+	mgc.EIP1153FTime, mgc.EIP5656FTime = nil, u64(201)
+	if got := mg.GetEIP1153TransitionTime(); got == nil || *got != 200 {
+		t.Errorf("multigeth EIP1153 default: got %v, want 200", got)
+	}
+
+	// Nethermind activates Cancun by timestamp, but its blob parameters are the defaults.
+	if err := confp.Convert(geth, &nethermind.NethermindChainSpec{}); err == nil {
+		t.Error("nethermind blob parameters: want error, got nil")
+	}
+	noBlobs := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := json.Unmarshal([]byte(`{
+		"config": {"chainId": 1, "londonBlock": 0, "shanghaiTime": 100, "cancunTime": 200, "ethash": {}},
+		"difficulty": "0x1", "gasLimit": "0x1000000", "alloc": {}
+	}`), noBlobs); err != nil {
+		t.Fatal(err)
+	}
+	nm := &nethermind.NethermindChainSpec{}
+	if err := confp.Convert(noBlobs, nm); err != nil {
+		t.Fatal(err)
+	}
+	for i, got := range cancun(nm) {
+		if got == nil || *got != 200 {
+			t.Errorf("nethermind Cancun EIP %d: got %v, want 200", i, got)
+		}
+	}
+	// Converting to geth writes the default blob schedule, which go-ethereum requires with Cancun.
+	withBlobs := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(nm, withBlobs); err != nil {
+		t.Fatal(err)
+	}
+	if got := withBlobs.Config.(*goethereum.ChainConfig).BlobScheduleConfig; got == nil || got.Cancun == nil || got.Cancun.Max != 6 {
+		t.Errorf("geth default blob schedule: got %+v", got)
+	}
+
+	// Parity cannot activate by timestamp.
+	if err := confp.Convert(noBlobs, &parity.ParityChainSpec{}); err == nil {
+		t.Error("parity: want error, got nil")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/echainspec"
+)
+
+// TestCliqueExtraDataConvert tests that a clique genesis's extra data, which holds
+// the initial signers, survives conversion between formats byte for byte.
+func TestCliqueExtraDataConvert(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "goerli_clique_geth.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := echainspec.ReadStrict("geth", bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := conf.GetGenesisExtraData()
+	if len(want) != 32+20+65 {
+		t.Fatalf("fixture extra data length: got %d, want %d", len(want), 32+20+65)
+	}
+	for _, path := range [][]string{
+		{"parity", "multigeth", "geth"},
+		{"multigeth", "parity", "geth"},
+		{"nethermind", "besu", "geth"},
+	} {
+		c := conf
+		for _, format := range path {
+			if c, err = echainspec.Convert(c, format); err != nil {
+				t.Fatalf("%v: %v", path, err)
+			}
+			c, err = reread(c, format)
+			if err != nil {
+				t.Fatalf("%v: %v", path, err)
+			}
+			if got := c.GetGenesisExtraData(); !bytes.Equal(got, want) {
+				t.Errorf("%v: %s extra data: got %x, want %x", path, format, got, want)
+			}
+		}
+	}
+}
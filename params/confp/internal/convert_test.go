@@ -25,14 +25,19 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/confp"
 	"github.com/ethereum/go-ethereum/params/confp/tconvert"
 	"github.com/ethereum/go-ethereum/params/types/aleth"
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
 	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
 	"github.com/ethereum/go-ethereum/params/types/parity"
+	"github.com/ethereum/go-ethereum/params/types/retesteth"
 )
 
 func mustOpenF(t *testing.T, fabbrev string, into interface{}) {
@@ -46,6 +51,22 @@ func mustOpenF(t *testing.T, fabbrev string, into interface{}) {
 	}
 }
 
+// defaultGenesisBlocks returns the built-in genesis configurations of the supported networks, by name.
+func defaultGenesisBlocks() map[string]*genesisT.Genesis {
+	return map[string]*genesisT.Genesis{
+		"classic":     params.DefaultClassicGenesisBlock(),
+		"kotti":       params.DefaultKottiGenesisBlock(),
+		"mordor":      params.DefaultMordorGenesisBlock(),
+		"foundation":  params.DefaultGenesisBlock(),
+		"ropsten":     params.DefaultTestnetGenesisBlock(),
+		"rinkeby":     params.DefaultRinkebyGenesisBlock(),
+		"goerli":      params.DefaultGoerliGenesisBlock(),
+		"social":      params.DefaultSocialGenesisBlock(),
+		"ethersocial": params.DefaultEthersocialGenesisBlock(),
+		"mix":         params.DefaultMixGenesisBlock(),
+	}
+}
+
 func Test_UnmarshalJSON(t *testing.T) {
 	for _, f := range []string{
 		"geth", "parity", "aleth",
@@ -124,6 +145,9 @@ func TestIdentical(t *testing.T) {
 func TestConfiguratorImplementationsSatisfied(t *testing.T) {
 	for _, ty := range []interface{}{
 		&parity.ParityChainSpec{},
+		&aleth.AlethGenesisSpec{},
+		&retesteth.RetestethGenesisSpec{},
+		&nethermind.NethermindChainSpec{},
 	} {
 		_ = ty.(ctypes.Configurator)
 	}
@@ -131,6 +155,7 @@ func TestConfiguratorImplementationsSatisfied(t *testing.T) {
 	for _, ty := range []interface{}{
 		&goethereum.ChainConfig{},
 		&multigeth.MultiGethChainConfig{},
+		&besu.BesuChainConfig{},
 	} {
 		_ = ty.(ctypes.ChainConfigurator)
 	}
@@ -150,3 +175,108 @@ func TestCompatible(t *testing.T) {
 	}
 	t.Log(fns)
 }
+
+func TestConvertWithWarnings(t *testing.T) {
+	newSource := func(reward int64) *genesisT.Genesis {
+		return &genesisT.Genesis{
+			Config: &multigeth.MultiGethChainConfig{
+				NetworkID:           1,
+				ChainID:             big.NewInt(1),
+				Ethash:              new(ctypes.EthashConfig),
+				BlockRewardSchedule: ctypes.Uint64BigMapEncodesHex{0: new(big.Int).Mul(big.NewInt(reward), big.NewInt(1e18))},
+			},
+			Difficulty: big.NewInt(1),
+		}
+	}
+
+	// go-ethereum infers the Frontier block reward, so an equal schedule is not lost.
+	warnings, err := confp.ConvertWithWarnings(newSource(5), &genesisT.Genesis{Config: &goethereum.ChainConfig{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	warnings, err = confp.ConvertWithWarnings(newSource(50), &genesisT.Genesis{Config: &goethereum.ChainConfig{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || warnings[0].Field != "EthashBlockRewardSchedule" {
+		t.Errorf("want block reward schedule warning, got: %v", warnings)
+	}
+}
+
+func TestRequireBlockHashesRoundTrip(t *testing.T) {
+	hashes := map[uint64]common.Hash{
+		1920000: common.HexToHash("0x94365e3a8c0b35089c1d1195081fe7489b528a84b22199c916180db8b28ade7f"),
+		2500000: common.HexToHash("0xca12c63534f565899681965528d536c52cb05b7c48e269c2a6cb77ad864d878a"),
+	}
+	mg := &genesisT.Genesis{
+		Config: &multigeth.MultiGethChainConfig{
+			NetworkID:          1,
+			ChainID:            big.NewInt(1),
+			RequireBlockHashes: hashes,
+			Ethash:             new(ctypes.EthashConfig),
+		},
+		Difficulty: big.NewInt(1),
+		Alloc:      genesisT.GenesisAlloc{},
+	}
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, read); err != nil {
+		t.Fatal(err)
+	}
+	back := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(read, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.GetForkCanonHashes(); !reflect.DeepEqual(got, hashes) {
+		t.Errorf("got %v, want %v", got, hashes)
+	}
+
+	// Formats without required block hashes drop them without failing.
+	geth := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	warnings, err := confp.ConvertWithWarnings(read, geth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) == 0 {
+		t.Error("want warnings for dropped required block hashes")
+	}
+}
+
+func TestEqualConfigs(t *testing.T) {
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(params.DefaultClassicGenesisBlock(), mg); err != nil {
+		t.Fatal(err)
+	}
+	if ok, diffs := confp.EqualConfigs(params.DefaultClassicGenesisBlock(), mg); !ok {
+		t.Errorf("converted classic: got diffs %v", diffs)
+	}
+
+	n := uint64(42)
+	mg.SetEIP155Transition(&n)
+	mg.SetEthashECIP1017EraRounds(&n)
+	mg.UpdateAccount(common.HexToAddress("0xabc"), big.NewInt(1), 0, nil, nil)
+	ok, diffs := confp.EqualConfigs(params.DefaultClassicGenesisBlock(), mg)
+	want := []string{"EIP155Transition", "EthashECIP1017EraRounds", "GenesisAlloc." + common.HexToAddress("0xabc").Hex()}
+	if ok || !reflect.DeepEqual(diffs, want) {
+		t.Errorf("got %v %v, want false %v", ok, diffs, want)
+	}
+
+	// The differences carry the values of each configuration.
+	d := confp.ConfigDifferences(params.DefaultClassicGenesisBlock(), mg)
+	if len(d) != len(want) {
+		t.Fatalf("got %v, want fields %v", d, want)
+	}
+	if a, b := d[0].A.(*uint64), d[0].B.(*uint64); *a != 3000000 || *b != n {
+		t.Errorf("%s: got %d -> %d, want 3000000 -> %d", d[0].Field, *a, *b, n)
+	}
+	if d[2].A != nil || d[2].B != "balance=1 nonce=0 code=" {
+		t.Errorf("%s: got %v -> %v", d[2].Field, d[2].A, d[2].B)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+func u64(n uint64) *uint64 {
+	return &n
+}
+
+// TestEIP1559Convert tests that the EIP-1559 activation and base fee parameters
+// survive conversion between formats, and default when a format omits them.
+func TestEIP1559Convert(t *testing.T) {
+	mg := &genesisT.Genesis{
+		Config: &multigeth.MultiGethChainConfig{
+			ChainID:                  big.NewInt(1),
+			EIP1559FBlock:            big.NewInt(100),
+			BaseFeeChangeDenominator: u64(16),
+			Ethash:                   new(ctypes.EthashConfig),
+		},
+		Difficulty: big.NewInt(1),
+	}
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.GetEIP1559Transition(); got == nil || *got != 100 {
+		t.Errorf("parity transition: got %v, want 100", got)
+	}
+	if got := spec.GetEIP1559BaseFeeChangeDenominator(); *got != 16 {
+		t.Errorf("parity denominator: got %d, want 16", *got)
+	}
+	if got := spec.GetEIP1559ElasticityMultiplier(); *got != 2 {
+		t.Errorf("parity elasticity multiplier: got %d, want 2", *got)
+	}
+	if spec.Params.EIP1559ElasticityMultiplier != nil {
+		t.Error("default elasticity multiplier written")
+	}
+
+	back := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, back); err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Equivalent(mg, back); err != nil {
+		t.Error(err)
+	}
+	if got := back.GetEIP1559BaseFeeChangeDenominator(); *got != 16 {
+		t.Errorf("round trip denominator: got %d, want 16", *got)
+	}
+
+	// Go-ethereum cannot represent a non-default denominator.
+	geth := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	err := confp.Convert(mg, geth)
+	if uerr, ok := err.(ctypes.ErrUnsupportedConfig); !ok || uerr.Method != "EIP1559BaseFeeChangeDenominator" {
+		t.Errorf("want unsupported denominator error, got %v", err)
+	}
+	if err := mg.SetEIP1559BaseFeeChangeDenominator(nil); err != nil {
+		t.Fatal(err)
+	}
+	geth = &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(mg, geth); err != nil {
+		t.Fatal(err)
+	}
+	if got := geth.Config.(*goethereum.ChainConfig).LondonBlock; got == nil || got.Uint64() != 100 {
+		t.Errorf("geth london block: got %v, want 100", got)
+	}
+	if got := geth.GetEIP1559BaseFeeChangeDenominator(); *got != 8 {
+		t.Errorf("geth denominator: got %d, want 8", *got)
+	}
+}
+
+// TestLondonEIPsConvert tests that the London EIPs bundled with EIP-1559 default to
+// its activation, and that individually configured activations survive conversion.
+func TestLondonEIPsConvert(t *testing.T) {
+	geth := &genesisT.Genesis{
+		Config: &goethereum.ChainConfig{
+			ChainID:     big.NewInt(1),
+			BerlinBlock: big.NewInt(100),
+			LondonBlock: big.NewInt(100),
+			Ethash:      new(ctypes.EthashConfig),
+		},
+		Difficulty: big.NewInt(1),
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(geth, mg); err != nil {
+		t.Fatal(err)
+	}
+	for name, got := range map[string]*uint64{
+		"EIP3529":       mg.GetEIP3529Transition(),
+		"EIP3541":       mg.GetEIP3541Transition(),
+		"EthashEIP3554": mg.GetEthashEIP3554Transition(),
+	} {
+		if got == nil || *got != 100 {
+			t.Errorf("multigeth %s: got %v, want 100", name, got)
+		}
+	}
+
+	// A London EIP may activate separately; the others default to EIP-1559's activation.
+	if err := mg.SetEIP3529Transition(u64(200)); err != nil {
+		t.Fatal(err)
+	}
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.GetEIP3529Transition(); got == nil || *got != 200 {
+		t.Errorf("parity EIP3529: got %v, want 200", got)
+	}
+	if got := spec.GetEIP3541Transition(); got == nil || *got != 100 {
+		t.Errorf("parity EIP3541: got %v, want 100", got)
+	}
+	if got := spec.GetEthashEIP3554Transition(); got == nil || *got != 100 {
+		t.Errorf("parity EIP3554: got %v, want 100", got)
+	}
+
+	// Without EIP-1559, the London EIPs are not active.
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{Ethash: new(ctypes.EthashConfig)}}
+	if got := mg.GetEIP3541Transition(); got != nil {
+		t.Errorf("multigeth EIP3541 without EIP1559: got %d, want nil", *got)
+	}
+}
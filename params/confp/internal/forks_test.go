@@ -0,0 +1,65 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+)
+
+func TestEIPActivations(t *testing.T) {
+	gen := params.DefaultGenesisBlock()
+	acts := confp.EIPActivations(gen)
+	if v := acts["EIP155"]; v == nil || *v != 2675000 {
+		t.Errorf("EIP155: got %v, want 2675000", v)
+	}
+	if v, ok := acts["EIP3855Time"]; !ok || v != nil {
+		t.Errorf("EIP3855Time: got %v (present: %v), want unset", v, ok)
+	}
+	timeActivations := map[string]bool{
+		// Shanghai
+		"EIP3651Time": true, "EIP3855Time": true, "EIP3860Time": true, "EIP4895Time": true,
+		// Cancun
+		"EIP1153Time": true, "EIP4788Time": true, "EIP4844Time": true, "EIP5656Time": true, "EIP6780Time": true, "EIP7516Time": true,
+	}
+	for name := range acts {
+		if confp.IsTimeActivation(name) != timeActivations[name] {
+			t.Errorf("%s: unexpected timestamp activation: %v", name, confp.IsTimeActivation(name))
+		}
+	}
+
+	// Each fork block is the activation of some transition.
+	blocks := make(map[uint64]bool)
+	for name, v := range acts {
+		if v != nil && !confp.IsTimeActivation(name) {
+			blocks[*v] = true
+		}
+	}
+	forks := confp.ForkBlocks(gen)
+	if len(forks) == 0 {
+		t.Fatal("no fork blocks")
+	}
+	for i, f := range forks {
+		if !blocks[f] {
+			t.Errorf("fork block %d is not an activation", f)
+		}
+		if i > 0 && forks[i-1] >= f {
+			t.Errorf("fork blocks not unique and sorted: %v", forks)
+		}
+	}
+}
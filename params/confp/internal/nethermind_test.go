@@ -0,0 +1,73 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+)
+
+// TestNethermindRoundTrip tests that converting the foundation configuration to Nethermind's
+// format, through JSON, and back to multigeth, preserves all fork activation blocks.
+func TestNethermindRoundTrip(t *testing.T) {
+	foundation := params.DefaultGenesisBlock()
+
+	spec := &nethermind.NethermindChainSpec{}
+	if err := confp.Convert(foundation, spec); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := &nethermind.NethermindChainSpec{}
+	if err := json.Unmarshal(b, read); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(read, mg); err != nil {
+		t.Fatal(err)
+	}
+
+	str := func(u *uint64) string {
+		if u == nil {
+			return "nil"
+		}
+		return fmt.Sprint(*u)
+	}
+	wantFns, names := confp.Transitions(foundation)
+	gotFns, _ := confp.Transitions(mg)
+	for i := range wantFns {
+		want, got := wantFns[i](), gotFns[i]()
+		if (want == nil) != (got == nil) || (want != nil && *want != *got) {
+			t.Errorf("%s: want: %v, got: %v", names[i], str(want), str(got))
+		}
+	}
+	if err := confp.Equivalent(foundation, mg); err != nil {
+		t.Error(err)
+	}
+	if len(mg.Alloc) != len(foundation.Alloc) {
+		t.Errorf("alloc: want: %d accounts, got: %d", len(foundation.Alloc), len(mg.Alloc))
+	}
+}
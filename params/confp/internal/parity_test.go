@@ -17,13 +17,26 @@
 package convert_test
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
 	"github.com/ethereum/go-ethereum/params/confp/tconvert"
+	"github.com/ethereum/go-ethereum/params/types/besu"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
 	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+	"github.com/ethereum/go-ethereum/params/vars"
 )
 
 func TestBlockConfig(t *testing.T) {
@@ -42,3 +55,586 @@ func TestBlockConfig(t *testing.T) {
 		t.Errorf("nonnil parity homestead")
 	}
 }
+
+func TestParityAccountStorageRoundTrip(t *testing.T) {
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+
+	var accounts map[common.UnprefixedAddress]*parity.ParityChainSpecAccount
+	err := json.Unmarshal([]byte(`{
+		"00000000000000000000000000000000000000aa": {
+			"balance": "0x3635c9adc5dea00000",
+			"nonce": "0x1",
+			"code": "0x6001600055",
+			"storage": {
+				"0x00": "0x01",
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000000",
+				"0x2": "0xdeadbeef",
+				"0x0000000000000000000000000000000000000000000000000000000000000abc": "0x00"
+			}
+		}
+	}`), &accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := common.HexToAddress("0xaa")
+	for k, v := range accounts {
+		spec.Accounts[k] = v
+	}
+
+	want := map[common.Hash]common.Hash{
+		common.HexToHash("0x00"):  common.HexToHash("0x01"),
+		common.HexToHash("0x01"):  {},
+		common.HexToHash("0x02"):  common.HexToHash("0xdeadbeef"),
+		common.HexToHash("0xabc"): {},
+	}
+	if got := map[common.Hash]common.Hash(spec.Accounts[common.UnprefixedAddress(addr)].Storage); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parsed storage: got %v, want %v", got, want)
+	}
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	if got := mg.Alloc[addr].Storage; !reflect.DeepEqual(got, want) {
+		t.Errorf("multigeth storage: got %v, want %v", got, want)
+	}
+
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	acc := back.Accounts[common.UnprefixedAddress(addr)]
+	if acc == nil {
+		t.Fatal("missing account after round trip")
+	}
+	if got := map[common.Hash]common.Hash(acc.Storage); !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip storage: got %v, want %v", got, want)
+	}
+	if got, want := acc.Balance.ToInt(), accounts[common.UnprefixedAddress(addr)].Balance.ToInt(); got.Cmp(want) != 0 {
+		t.Errorf("round trip balance: got %v, want %v", got, want)
+	}
+	if got, want := common.Bytes2Hex(acc.Code), "6001600055"; got != want {
+		t.Errorf("round trip code: got %s, want %s", got, want)
+	}
+}
+
+// TestParityBuiltinActivationForms tests that each encoding of a builtin's
+// activation block converts to the same multigeth transition.
+func TestParityBuiltinActivationForms(t *testing.T) {
+	for _, c := range []struct {
+		fixture string
+		want    uint64
+	}{
+		{"activate_at_hex", 4370000},
+		{"activate_at_decimal", 4370000},
+		{"activate_at_int", 4370000},
+		{"pricing_nested", 4370000},
+		{"pricing_map", 4370000},
+		{"genesis", 0},
+	} {
+		b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "parity_builtin_modexp_"+c.fixture+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		builtin := &parity.ParityChainSpecBuiltin{}
+		if err := json.Unmarshal(b, builtin); err != nil {
+			t.Fatalf("%s: %v", c.fixture, err)
+		}
+		spec := &parity.ParityChainSpec{}
+		mustOpenF(t, "parity", spec)
+		spec.SetPrecompile(5, builtin)
+
+		mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+		if err := confp.Convert(spec, mg); err != nil {
+			t.Fatalf("%s: %v", c.fixture, err)
+		}
+		if got := mg.GetEIP198Transition(); got == nil || *got != c.want {
+			t.Errorf("%s: got %v, want %d", c.fixture, got, c.want)
+		}
+	}
+}
+
+// TestParityModExpEIP2565 tests that a second modexp pricing entry, EIP-2565's,
+// converts to the EIP-2565 transition, and back.
+func TestParityModExpEIP2565(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "parity_builtin_modexp_eip2565.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	builtin := &parity.ParityChainSpecBuiltin{}
+	if err := json.Unmarshal(b, builtin); err != nil {
+		t.Fatal(err)
+	}
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+	spec.SetPrecompile(5, builtin)
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	if got := mg.GetEIP198Transition(); got == nil || *got != 4370000 {
+		t.Errorf("multigeth EIP198: got %v, want 4370000", got)
+	}
+	if got := mg.GetEIP2565Transition(); got == nil || *got != 12244000 {
+		t.Errorf("multigeth EIP2565: got %v, want 12244000", got)
+	}
+
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.GetEIP198Transition(); got == nil || *got != 4370000 {
+		t.Errorf("parity EIP198: got %v, want 4370000", got)
+	}
+	if got := back.GetEIP2565Transition(); got == nil || *got != 12244000 {
+		t.Errorf("parity EIP2565: got %v, want 12244000", got)
+	}
+
+	// The repricing may be added to a builtin with a single pricing.
+	b, err = ioutil.ReadFile(filepath.Join("..", "testdata", "parity_builtin_modexp_activate_at_hex.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	builtin = &parity.ParityChainSpecBuiltin{}
+	if err := json.Unmarshal(b, builtin); err != nil {
+		t.Fatal(err)
+	}
+	spec.SetPrecompile(5, builtin)
+	n := uint64(12244000)
+	if err := spec.SetEIP2565Transition(&n); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.GetEIP198Transition(); got == nil || *got != 4370000 {
+		t.Errorf("parity EIP198 after repricing: got %v, want 4370000", got)
+	}
+	if got := spec.GetEIP2565Transition(); got == nil || *got != n {
+		t.Errorf("parity EIP2565: got %v, want %d", got, n)
+	}
+}
+
+// TestParityChainIDWithoutEIP155 tests that a chain ID survives conversion
+// independently of EIP155, which may be configured as not (yet) activated.
+func TestParityChainIDWithoutEIP155(t *testing.T) {
+	geth := &genesisT.Genesis{
+		Config: &goethereum.ChainConfig{
+			ChainID:        big.NewInt(1337),
+			HomesteadBlock: big.NewInt(0),
+			EIP150Block:    big.NewInt(0),
+			Ethash:         new(ctypes.EthashConfig),
+		},
+		Difficulty: big.NewInt(1),
+		GasLimit:   5000,
+	}
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(geth, spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.GetChainID(); got == nil || got.Uint64() != 1337 {
+		t.Errorf("parity chain ID: got %v, want 1337", got)
+	}
+	if got := spec.GetEIP155Transition(); got != nil {
+		t.Errorf("parity EIP155: got %d, want nil", *got)
+	}
+	// Parity enables EIP155 from genesis unless the transition is given.
+	if spec.Params.EIP155Transition == nil {
+		t.Error("parity EIP155 transition not written")
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec = &parity.ParityChainSpec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		t.Fatal(err)
+	}
+	back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(spec, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.GetChainID(); got == nil || got.Uint64() != 1337 {
+		t.Errorf("geth chain ID: got %v, want 1337", got)
+	}
+	if got := back.GetEIP155Transition(); got != nil {
+		t.Errorf("geth EIP155: got %d, want nil", *got)
+	}
+
+	// A Parity spec without an EIP155 transition has EIP155 from genesis.
+	spec.Params.EIP155Transition = nil
+	back = &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(spec, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.GetEIP155Transition(); got == nil || *got != 0 {
+		t.Errorf("geth EIP155 from parity default: got %v, want 0", got)
+	}
+	if got := back.GetChainID(); got == nil || got.Uint64() != 1337 {
+		t.Errorf("geth chain ID: got %v, want 1337", got)
+	}
+}
+
+func TestParityBootnodesRoundTrip(t *testing.T) {
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+	bootnodes := []string{
+		"enode://a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c@52.16.188.185:30303",
+		"enode://3f1d12044546b76342d59d4a05532c14b85aa669704bfe1f864fe079415aa2c02d743e03218e57a33fb94523adb54032871a6c51b2cc5514cb7c7e35b3ed0a99@13.93.211.84:30303",
+		"enode://78de8a0916848093c73790ead81d1928bec737d565119932b98c6b100d944b7a95e94f847f689fc723399d2e31129d182f7ef3863f2b4c820abbf3ab2722344d@191.235.84.50:30303",
+	}
+	spec.Nodes = bootnodes
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, mg); err != nil {
+		t.Fatal(err)
+	}
+	if got := mg.GetBootnodes(); !reflect.DeepEqual(got, bootnodes) {
+		t.Errorf("multigeth bootnodes: got %v, want %v", got, bootnodes)
+	}
+
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back.Nodes, bootnodes) {
+		t.Errorf("parity bootnodes: got %v, want %v", back.Nodes, bootnodes)
+	}
+}
+
+// TestParityEngineTransitions tests that transitions configured in Parity's
+// engine-level ethash params (rather than the spec's params) survive conversion.
+func TestParityEngineTransitions(t *testing.T) {
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, mg); err != nil {
+		t.Fatal(err)
+	}
+	for name, c := range map[string]struct {
+		got  *uint64
+		want uint64
+	}{
+		"EthashHomestead": {mg.GetEthashHomesteadTransition(), 0x2710},
+		"EthashEIP100B":   {mg.GetEthashEIP100BTransition(), 0x7530},
+	} {
+		if c.got == nil || *c.got != c.want {
+			t.Errorf("multigeth %s: got %v, want %d", name, c.got, c.want)
+		}
+	}
+
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Engine.Ethash.Params.HomesteadTransition.Uint64P(); got == nil || *got != 0x2710 {
+		t.Errorf("parity homesteadTransition: got %v, want %d", got, 0x2710)
+	}
+	if got := back.Engine.Ethash.Params.EIP100bTransition.Uint64P(); got == nil || *got != 0x7530 {
+		t.Errorf("parity eip100bTransition: got %v, want %d", got, 0x7530)
+	}
+}
+
+// TestParityDAOForkRefund tests that the DAO hard fork transition, refund contract,
+// and drained accounts survive conversion between multigeth and Parity.
+func TestParityDAOForkRefund(t *testing.T) {
+	foundation := params.DefaultGenesisBlock()
+	spec := &parity.ParityChainSpec{}
+	if err := confp.Convert(foundation, spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.Engine.Ethash.Params.DaoHardforkAccounts; len(got) != len(vars.DAODrainList()) {
+		t.Errorf("parity daoHardforkAccounts: got %d, want %d", len(got), len(vars.DAODrainList()))
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	if got := mg.GetEthashEIP779Transition(); got == nil || *got != 1920000 {
+		t.Errorf("multigeth EIP779: got %v, want %d", got, 1920000)
+	}
+	if got := mg.GetEthashDAOForkBeneficiary(); got == nil || *got != vars.DAORefundContract {
+		t.Errorf("multigeth DAO beneficiary: got %v, want %v", got, vars.DAORefundContract.Hex())
+	}
+	if got := mg.GetEthashDAOForkAccounts(); !reflect.DeepEqual(got, vars.DAODrainList()) {
+		t.Errorf("multigeth DAO accounts: got %d, want %d", len(got), len(vars.DAODrainList()))
+	}
+
+	// A refund other than the mainnet DAO fork's is carried by multigeth, but not go-ethereum.
+	beneficiary := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	accounts := []common.Address{common.HexToAddress("0x00000000000000000000000000000000000000bb")}
+	spec.Engine.Ethash.Params.DaoHardforkBeneficiary = &beneficiary
+	spec.Engine.Ethash.Params.DaoHardforkAccounts = accounts
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, mg); err != nil {
+		t.Fatal(err)
+	}
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Engine.Ethash.Params.DaoHardforkBeneficiary; got == nil || *got != beneficiary {
+		t.Errorf("parity daoHardforkBeneficiary: got %v, want %v", got, beneficiary.Hex())
+	}
+	if got := back.Engine.Ethash.Params.DaoHardforkAccounts; !reflect.DeepEqual(got, accounts) {
+		t.Errorf("parity daoHardforkAccounts: got %v, want %v", got, accounts)
+	}
+	gg := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err, ok := confp.Convert(mg, gg).(ctypes.ErrUnsupportedConfig); !ok || !ctypes.IsFatalUnsupportedErr(err.Err) {
+		t.Errorf("go-ethereum: got %v, want fatal unsupported config error", err)
+	}
+}
+
+// TestParityBlockValidityParams tests that Parity's block validity params
+// survive conversion to and from multigeth, rather than falling back to the defaults.
+func TestParityBlockValidityParams(t *testing.T) {
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+	want := map[string]uint64{
+		"maximumExtraDataSize": 64,
+		"minGasLimit":          0x2fefd8,
+		"gasLimitBoundDivisor": 2048,
+		"networkID":            0x3f,
+	}
+	spec.Params.MaximumExtraDataSize = new(parity.ParityU64).SetUint64(u64(want["maximumExtraDataSize"]))
+	spec.Params.MinGasLimit = new(parity.ParityU64).SetUint64(u64(want["minGasLimit"]))
+	spec.Params.GasLimitBoundDivisor = new(parity.ParityU64).SetUint64(u64(want["gasLimitBoundDivisor"]))
+	spec.Params.NetworkID = new(parity.ParityU64).SetUint64(u64(want["networkID"]))
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	if vars.GasLimitBoundDivisor != 1024 {
+		t.Errorf("global gas limit bound divisor: got %d, want 1024", vars.GasLimitBoundDivisor)
+	}
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mg = &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, mg); err != nil {
+		t.Fatal(err)
+	}
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	for name, got := range map[string]*uint64{
+		"maximumExtraDataSize": back.GetMaximumExtraDataSize(),
+		"minGasLimit":          back.GetMinGasLimit(),
+		"gasLimitBoundDivisor": back.GetGasLimitBoundDivisor(),
+		"networkID":            back.GetNetworkID(),
+	} {
+		if got == nil || *got != want[name] {
+			t.Errorf("parity %s: got %v, want %d", name, got, want[name])
+		}
+	}
+}
+
+// TestParityAccountBalanceForms tests that each encoding of a genesis account's
+// balance and nonce converts to the same multigeth value.
+func TestParityAccountBalanceForms(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "parity_accounts_mixed_balances.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+	spec.Accounts = nil
+	if err := json.Unmarshal(b, &spec.Accounts); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	if len(mg.Alloc) != 4 {
+		t.Fatalf("got %d accounts, want 4", len(mg.Alloc))
+	}
+	want := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	for addr, acc := range mg.Alloc {
+		if acc.Balance.Cmp(want) != 0 {
+			t.Errorf("%x balance: got %v, want %v", addr, acc.Balance, want)
+		}
+		wantNonce := uint64(0)
+		if addr == common.HexToAddress("0xa03") || addr == common.HexToAddress("0xa04") {
+			wantNonce = 1
+		}
+		if acc.Nonce != wantNonce {
+			t.Errorf("%x nonce: got %d, want %d", addr, acc.Nonce, wantNonce)
+		}
+	}
+}
+
+// TestParityAccountStartNonce tests that a non-zero account start nonce survives
+// conversion through multigeth.
+func TestParityAccountStartNonce(t *testing.T) {
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+	startNonce := uint64(1 << 20)
+	if err := spec.SetAccountStartNonce(&startNonce); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(spec, mg); err != nil {
+		t.Fatal(err)
+	}
+	if got := mg.GetAccountStartNonce(); got == nil || *got != startNonce {
+		t.Errorf("multigeth: got %v, want %d", got, startNonce)
+	}
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.GetAccountStartNonce(); got == nil || *got != startNonce {
+		t.Errorf("parity: got %v, want %d", got, startNonce)
+	}
+
+	// A zero start nonce is the default, so is not written.
+	zero := uint64(0)
+	if err := mg.SetAccountStartNonce(&zero); err != nil {
+		t.Fatal(err)
+	}
+	if mg.Config.(*multigeth.MultiGethChainConfig).AccountStartNonce != nil {
+		t.Error("multigeth: zero start nonce is set")
+	}
+	if got := mg.GetAccountStartNonce(); got == nil || *got != 0 {
+		t.Errorf("multigeth default: got %v, want 0", got)
+	}
+}
+
+// TestParityWASMActivation tests that Parity's eWASM activation transition
+// converts to the multigeth and geth ewasmBlock, and back.
+func TestParityWASMActivation(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "parity_wasm.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := &parity.ParityChainSpec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.GetEWASMTransition(); got == nil || *got != 60000 {
+		t.Fatalf("parity EWASM: got %v, want 60000", got)
+	}
+	for _, c := range []ctypes.ChainConfigurator{&multigeth.MultiGethChainConfig{}, &goethereum.ChainConfig{}} {
+		gen := &genesisT.Genesis{Config: c}
+		if err := confp.Convert(spec, gen); err != nil {
+			t.Fatal(err)
+		}
+		out, err := json.Marshal(gen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(out), `"ewasmBlock":60000`) {
+			t.Errorf("%T: want ewasmBlock 60000, got %s", c, out)
+		}
+
+		back := &parity.ParityChainSpec{}
+		if err := confp.Convert(gen, back); err != nil {
+			t.Fatal(err)
+		}
+		if got := back.GetEWASMTransition(); got == nil || *got != 60000 {
+			t.Errorf("%T: parity EWASM: got %v, want 60000", c, got)
+		}
+	}
+
+	// Formats without eWASM refuse the transition rather than drop it.
+	err = confp.Convert(spec, &genesisT.Genesis{Config: &besu.BesuChainConfig{}})
+	if e, ok := err.(ctypes.ErrUnsupportedConfig); !ok || !ctypes.IsFatalUnsupportedErr(e.Err) {
+		t.Errorf("besu: want fatal unsupported error, got %v", err)
+	}
+}
+
+// TestParityCustomBuiltin tests that a builtin which configurations do not model
+// is kept by multigeth as a genesis account builtin, without changing the genesis state,
+// and that formats which cannot keep it drop it with a warning.
+func TestParityCustomBuiltin(t *testing.T) {
+	spec := &parity.ParityChainSpec{}
+	mustOpenF(t, "parity", spec)
+	addr := common.BytesToAddress([]byte{9})
+	delete(spec.Accounts, common.UnprefixedAddress(addr))
+	builtin := json.RawMessage(`{"name":"my_precompile","pricing":{"linear":{"base":100,"word":4}}}`)
+	if err := spec.SetAccountBuiltin(addr, builtin); err != nil {
+		t.Fatal(err)
+	}
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	warnings, err := confp.ConvertWithWarnings(spec, mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantWarning := confp.Warning{Field: "Builtin(0x0000000000000000000000000000000000000009)", Value: "my_precompile", Reason: "not modeled; kept as a genesis account builtin"}
+	if len(warnings) != 1 || warnings[0] != wantWarning {
+		t.Errorf("multigeth: got warnings %v, want %v", warnings, wantWarning)
+	}
+	if acc, ok := mg.Alloc[addr]; !ok || !acc.BuiltinOnly() {
+		t.Fatalf("multigeth: want builtin-only account, got %v", acc)
+	}
+
+	// The builtin survives the multigeth encoding and conversion back to parity.
+	b, err := json.Marshal(mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal(b, read); err != nil {
+		t.Fatal(err)
+	}
+	back := &parity.ParityChainSpec{}
+	if err := confp.Convert(read, back); err != nil {
+		t.Fatal(err)
+	}
+	acc := back.Accounts[common.UnprefixedAddress(addr)]
+	if acc == nil || acc.Builtin == nil || acc.Builtin.Name != "my_precompile" || acc.Builtin.Pricing.Pricing.Linear.Base != 100 {
+		t.Errorf("parity: got account %+v, want my_precompile builtin", acc)
+	}
+
+	geth := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	warnings, err = confp.ConvertWithWarnings(spec, geth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantWarning.Reason = "not supported by target"
+	found := false
+	for _, w := range warnings {
+		found = found || w == wantWarning
+	}
+	if !found {
+		t.Errorf("geth: got warnings %v, want %v", warnings, wantWarning)
+	}
+	if _, ok := geth.Alloc[addr]; ok {
+		t.Error("geth: want no builtin account")
+	}
+
+	// The kept builtin is not part of the genesis state.
+	if got, want := core.GenesisToBlock(read, nil).Hash(), core.GenesisToBlock(geth, nil).Hash(); got != want {
+		t.Errorf("genesis hash: got %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/retesteth"
+)
+
+// TestRetestethRoundTrip tests that the foundation configuration survives
+// conversion to retesteth's format, through JSON, and back.
+func TestRetestethRoundTrip(t *testing.T) {
+	foundation := params.DefaultGenesisBlock()
+
+	spec := &retesteth.RetestethGenesisSpec{}
+	if err := confp.Convert(foundation, spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.SealEngine != retesteth.SealEngineNoProof {
+		t.Errorf("seal engine: want: %s, got: %s", retesteth.SealEngineNoProof, spec.SealEngine)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := &retesteth.RetestethGenesisSpec{}
+	if err := json.Unmarshal(b, read); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(read, mg); err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Equivalent(foundation, mg); err != nil {
+		t.Error(err)
+	}
+	if len(mg.Alloc) != len(foundation.Alloc) {
+		t.Errorf("alloc: want: %d accounts, got: %d", len(foundation.Alloc), len(mg.Alloc))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/echainspec"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+)
+
+// AssertRoundTrip reads a configuration of the given format, converts it to every
+// other registered format and back, through their JSON encodings, and asserts that
+// the configuration is unchanged (see confp.EqualConfigs).
+// The formats to which the configuration cannot be converted must be given as skip;
+// conversion to a skipped format is asserted to fail, and to any other to succeed.
+// Differences in fields which a format cannot represent (see echainspec.Unrepresentable) are expected.
+func AssertRoundTrip(t *testing.T, format string, data []byte, skip ...string) {
+	t.Helper()
+	orig, err := echainspec.Read(format, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("read %s: %v", format, err)
+	}
+	for _, via := range echainspec.Formats() {
+		if via == format {
+			continue
+		}
+		mid, err := echainspec.Convert(orig, via)
+		if skipped(skip, via) {
+			if err == nil {
+				t.Errorf("%s -> %s: converted, want skipped", format, via)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s -> %s: %v", format, via, err)
+			continue
+		}
+		back, err := reread(mid, via)
+		if err == nil {
+			back, err = echainspec.Convert(back, format)
+		}
+		if err == nil {
+			back, err = reread(back, format)
+		}
+		if err != nil {
+			t.Errorf("%s -> %s -> %s: %v", format, via, format, err)
+			continue
+		}
+		if ok, diffs := confp.EqualConfigs(orig, back); !ok {
+			for _, field := range diffs {
+				if echainspec.Unrepresentable(via, orig, field) {
+					continue
+				}
+				t.Errorf("%s -> %s -> %s: %s differs", format, via, format, field)
+			}
+		}
+	}
+}
+
+// reread writes a configuration as JSON and reads it again.
+func reread(c ctypes.Configurator, format string) (ctypes.Configurator, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return echainspec.Read(format, bytes.NewReader(b))
+}
+
+func skipped(skip []string, format string) bool {
+	for _, s := range skip {
+		if s == format {
+			return true
+		}
+	}
+	return false
+}
+
+// notConvertibleDefaults are the formats to which each default configuration cannot be converted.
+var notConvertibleDefaults = map[string][]string{
+	"classic": {"aleth", "besu", "geth", "nethermind", "parity", "retesteth"},
+	"goerli":  {"aleth", "retesteth"},
+	"kotti":   {"aleth", "besu", "geth", "nethermind", "parity", "retesteth"},
+	"mordor":  {"aleth", "besu", "geth", "nethermind", "parity", "retesteth"},
+	"rinkeby": {"aleth", "retesteth"},
+	"social":  {"aleth", "geth", "retesteth"},
+}
+
+// TestRoundTripDefaults round trips the default configurations of the echainspec command.
+func TestRoundTripDefaults(t *testing.T) {
+	for name, conf := range defaultGenesisBlocks() {
+		format := "multigeth"
+		if _, ok := conf.Config.(*goethereum.ChainConfig); ok {
+			format = "geth"
+		}
+		data, err := json.Marshal(conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(name, func(t *testing.T) {
+			AssertRoundTrip(t, format, data, notConvertibleDefaults[name]...)
+		})
+	}
+}
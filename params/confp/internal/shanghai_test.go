@@ -0,0 +1,136 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// TestShanghaiTimeConvert tests that a Shanghai activation time survives conversion
+// to formats which activate by timestamp, and is fatal for those which cannot.
+func TestShanghaiTimeConvert(t *testing.T) {
+	geth := &genesisT.Genesis{}
+	if err := json.Unmarshal([]byte(`{
+		"config": {"chainId": 1, "berlinBlock": 0, "londonBlock": 0, "shanghaiTime": 1681338455, "ethash": {}},
+		"difficulty": "0x1", "gasLimit": "0x1000000", "alloc": {}
+	}`), geth); err != nil {
+		t.Fatal(err)
+	}
+	shanghai := func(conf ctypes.ChainConfigurator) map[string]*uint64 {
+		return map[string]*uint64{
+			"EIP3651": conf.GetEIP3651TransitionTime(),
+			"EIP3855": conf.GetEIP3855TransitionTime(),
+			"EIP3860": conf.GetEIP3860TransitionTime(),
+			"EIP4895": conf.GetEIP4895TransitionTime(),
+		}
+	}
+
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(geth, mg); err != nil {
+		t.Fatal(err)
+	}
+	nm := &nethermind.NethermindChainSpec{}
+	if err := confp.Convert(geth, nm); err != nil {
+		t.Fatal(err)
+	}
+	// Nethermind's timestamps are read back from JSON.
+	b, err := json.Marshal(nm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nm = &nethermind.NethermindChainSpec{}
+	if err := json.Unmarshal(b, nm); err != nil {
+		t.Fatal(err)
+	}
+	bs := &genesisT.Genesis{Config: &besu.BesuChainConfig{}}
+	if err := confp.Convert(mg, bs); err != nil {
+		t.Fatal(err)
+	}
+	back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(nm, back); err != nil {
+		t.Fatal(err)
+	}
+	for format, conf := range map[string]ctypes.ChainConfigurator{
+		"multigeth":  mg,
+		"nethermind": nm,
+		"besu":       bs,
+		"geth":       back,
+	} {
+		for name, got := range shanghai(conf) {
+			if got == nil || *got != 1681338455 {
+				t.Errorf("%s %s: got %v, want 1681338455", format, name, got)
+			}
+		}
+	}
+
+	// A Shanghai EIP may activate separately; the others default to EIP-4895's activation.
+	if err := mg.SetEIP3860TransitionTime(u64(1681338456)); err != nil {
+		t.Fatal(err)
+	}
+	mg.Config.(*multigeth.MultiGethChainConfig).EIP3855FTime = nil
+	if got := mg.GetEIP3855TransitionTime(); got == nil || *got != 1681338455 {
+		t.Errorf("multigeth EIP3855 default: got %v, want 1681338455", got)
+	}
+	if got := mg.GetEIP3860TransitionTime(); got == nil || *got != 1681338456 {
+		t.Errorf("multigeth EIP3860: got %v, want 1681338456", got)
+	}
+
+	// Parity cannot activate by timestamp.
+	if err := confp.Convert(geth, &parity.ParityChainSpec{}); err == nil {
+		t.Error("parity: want error, got nil")
+	}
+}
+
+// TestTimeForksRoundTrip tests that block and timestamp activations round-trip
+// between geth and multigeth, without one being taken for the other.
+func TestTimeForksRoundTrip(t *testing.T) {
+	geth := &genesisT.Genesis{}
+	if err := json.Unmarshal([]byte(`{
+		"config": {"chainId": 1, "berlinBlock": 10, "londonBlock": 20, "shanghaiTime": 30, "ethash": {}},
+		"difficulty": "0x1", "gasLimit": "0x1000000", "alloc": {}
+	}`), geth); err != nil {
+		t.Fatal(err)
+	}
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := confp.Convert(geth, mg); err != nil {
+		t.Fatal(err)
+	}
+	back := &genesisT.Genesis{Config: &goethereum.ChainConfig{}}
+	if err := confp.Convert(mg, back); err != nil {
+		t.Fatal(err)
+	}
+	if ok, diffs := confp.EqualConfigs(geth, back); !ok {
+		t.Errorf("round trip differs: %v", diffs)
+	}
+	if got, want := confp.Forks(back), []uint64{10, 20}; !reflect.DeepEqual(got, want) {
+		t.Errorf("forks: got %v, want %v", got, want)
+	}
+	if got, want := confp.ForkTimes(back), []uint64{30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fork times: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// TestValidateDefaults tests that the built-in configurations of all networks are valid.
+func TestValidateDefaults(t *testing.T) {
+	for name, gen := range defaultGenesisBlocks() {
+		head := uint64(10000000)
+		if err := confp.Validate(gen, &head); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if err := confp.Validate(gen, nil); err != nil {
+			t.Errorf("%s (no head): %v", name, err)
+		}
+	}
+}
+
+func TestValidateListsAllErrors(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		EIP155Block: big.NewInt(10),
+	}
+	head := uint64(10)
+	err := confp.Validate(c, &head)
+	if err == nil {
+		t.Fatal("want error")
+	}
+	verr, ok := err.(*confp.ValidationError)
+	if !ok {
+		t.Fatalf("want *ValidationError, got: %T", err)
+	}
+	// Missing network id, and missing chain id with EIP155.
+	if len(verr.Errs) != 2 {
+		t.Errorf("want 2 errors, got: %v", verr.Errs)
+	}
+}
+
+func TestValidateTransitionOrder(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID:            1,
+		ChainID:              big.NewInt(1),
+		EIP2200FBlock:        big.NewInt(100),
+		EIP2200DisableFBlock: big.NewInt(99),
+	}
+	if err := confp.Validate(c, nil); err == nil {
+		t.Error("want error for disable transition preceding enable transition")
+	}
+	c.EIP2200DisableFBlock = big.NewInt(100)
+	if err := confp.Validate(c, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestValidateForkOrder tests that hard fork transitions may activate out of
+// their historical order, as on Ethersocial, where only EIP dependencies are enforced.
+func TestValidateForkOrder(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID:    1,
+		ChainID:      big.NewInt(1),
+		EIP150Block:  big.NewInt(20),
+		EIP155Block:  big.NewInt(10),
+		EIP140FBlock: big.NewInt(5),
+	}
+	if err := confp.Validate(c, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateClassicForkBundles(t *testing.T) {
+	gen := params.DefaultClassicGenesisBlock()
+	if err := confp.Validate(gen, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conf := &multigeth.MultiGethChainConfig{}
+	if err := confp.Convert(gen.Config, conf); err != nil {
+		t.Fatal(err)
+	}
+	// Move a single Atlantis EIP.
+	n := uint64(8772001)
+	if err := conf.SetEIP198Transition(&n); err != nil {
+		t.Fatal(err)
+	}
+	err := confp.Validate(conf, nil)
+	if err == nil {
+		t.Fatal("want error for partially applied fork bundle")
+	}
+	if !strings.Contains(err.Error(), "Atlantis") || !strings.Contains(err.Error(), "EIP198=8772001") {
+		t.Errorf("want error naming Atlantis and EIP198, got: %v", err)
+	}
+	// Other chains are not subject to Classic fork bundles.
+	conf.SetChainID(big.NewInt(1337))
+	if err := confp.Validate(conf, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateEIPDependencies(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID:     1,
+		ChainID:       big.NewInt(1),
+		EIP1344FBlock: big.NewInt(100),
+	}
+	err := confp.Validate(c, nil)
+	if err == nil || !strings.Contains(err.Error(), "EIP1344 requires EIP155 which is not active") {
+		t.Errorf("want missing dependency error, got: %v", err)
+	}
+	c.EIP155Block = big.NewInt(101)
+	err = confp.Validate(c, nil)
+	if err == nil || !strings.Contains(err.Error(), "EIP1344 requires EIP155 which activates later") {
+		t.Errorf("want late dependency error, got: %v", err)
+	}
+	c.EIP155Block = big.NewInt(100)
+	if err := confp.Validate(c, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Parity configures EIP161 parts separately.
+	n := uint64(10)
+	p := &parity.ParityChainSpec{}
+	p.SetNetworkID(&n)
+	p.SetEIP161dTransition(&n)
+	err = confp.Validate(p, nil)
+	if err == nil || !strings.Contains(err.Error(), "EIP161d requires EIP161abc which is not active") {
+		t.Errorf("want missing dependency error, got: %v", err)
+	}
+	p.SetEIP161abcTransition(&n)
+	if err := confp.Validate(p, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestValidateEIPDependencyRules tests that each EIP dependency is reported
+// when the dependency is not active.
+func TestValidateEIPDependencyRules(t *testing.T) {
+	cancun, n := uint64(1700000000), uint64(10)
+	// Multigeth configures the EIP161 parts together, Parity separately.
+	p := &parity.ParityChainSpec{}
+	p.SetEIP161dTransition(&n)
+	if err := confp.Validate(p, nil); err == nil || !strings.Contains(err.Error(), "EIP161d requires EIP161abc which is not active") {
+		t.Errorf("want %q, got: %v", "EIP161d requires EIP161abc", err)
+	}
+	cases := []struct {
+		conf *multigeth.MultiGethChainConfig
+		want string
+	}{
+		{&multigeth.MultiGethChainConfig{EIP1344FBlock: big.NewInt(10)}, "EIP1344 requires EIP155"},
+		{&multigeth.MultiGethChainConfig{EIP1559FBlock: big.NewInt(10), EIP2718FBlock: big.NewInt(10)}, "EIP1559 requires EIP2930"},
+		{&multigeth.MultiGethChainConfig{EIP1559FBlock: big.NewInt(10), EIP2930FBlock: big.NewInt(10)}, "EIP1559 requires EIP2718"},
+		{&multigeth.MultiGethChainConfig{EIP2930FBlock: big.NewInt(10)}, "EIP2930 requires EIP2718"},
+		{&multigeth.MultiGethChainConfig{EIP4844FTime: &cancun}, "EIP4844Time requires EIP1559"},
+	}
+	for _, c := range cases {
+		c.conf.NetworkID = 1
+		c.conf.ChainID = big.NewInt(1)
+		err := confp.Validate(c.conf, nil)
+		if err == nil || !strings.Contains(err.Error(), c.want+" which is not active") {
+			t.Errorf("want %q, got: %v", c.want, err)
+		}
+	}
+
+	// Timestamp forks follow block forks, whatever their values.
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID:     1,
+		ChainID:       big.NewInt(1),
+		EIP2718FBlock: big.NewInt(int64(cancun) + 1),
+		EIP2930FBlock: big.NewInt(int64(cancun) + 1),
+		EIP1559FBlock: big.NewInt(int64(cancun) + 1),
+		EIP4844FTime:  &cancun,
+	}
+	if err := confp.Validate(c, nil); err != nil && strings.Contains(err.Error(), "EIP4844Time requires") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestValidateTypedTransactions tests that the typed transaction EIPs require
+// the EIP-2718 envelope to be active at or before their own activation.
+func TestValidateTypedTransactions(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID:     1,
+		ChainID:       big.NewInt(1),
+		EIP2930FBlock: big.NewInt(100),
+		EIP1559FBlock: big.NewInt(200),
+	}
+	err := confp.Validate(c, nil)
+	for _, want := range []string{"EIP2930 requires EIP2718 which is not active", "EIP1559 requires EIP2718 which is not active"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("want %q, got: %v", want, err)
+		}
+	}
+	c.EIP2718FBlock = big.NewInt(150)
+	err = confp.Validate(c, nil)
+	if err == nil || !strings.Contains(err.Error(), "EIP2930 requires EIP2718 which activates later") {
+		t.Errorf("want late dependency error, got: %v", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "EIP1559 requires EIP2718") {
+		t.Errorf("unexpected EIP1559 error: %v", err)
+	}
+	c.EIP2718FBlock = big.NewInt(100)
+	if err := confp.Validate(c, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateForkCanonHashes(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID: 1,
+		RequireBlockHashes: map[uint64]common.Hash{
+			1920000: common.HexToHash("0x94365e3a8c0b35089c1d1195081fe7489b528a84b22199c916180db8b28ade7f"),
+			2500000: {},
+		},
+	}
+	err := confp.Validate(c, nil)
+	if err == nil || !strings.Contains(err.Error(), "Required block hash cannot be empty") {
+		t.Errorf("want empty hash error, got: %v", err)
+	}
+	delete(c.RequireBlockHashes, 2500000)
+	if err := confp.Validate(c, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Hashes which are not 32 bytes cannot be read.
+	mg := &genesisT.Genesis{Config: &multigeth.MultiGethChainConfig{}}
+	if err := json.Unmarshal([]byte(`{"config":{"requireBlockHashes":{"1920000":"0x9436"}},"difficulty":"0x1","gasLimit":"0x1388","alloc":{}}`), mg); err == nil {
+		t.Error("want error reading short required block hash")
+	}
+}
+
+func TestValidateCliqueExtraData(t *testing.T) {
+	gen := params.DefaultGoerliGenesisBlock()
+	if err := confp.Validate(gen, nil); err != nil {
+		t.Fatalf("goerli: %v", err)
+	}
+	extra := gen.ExtraData
+	for _, n := range []int{0, 32, 32 + 65 + 19, len(extra) - 1} {
+		gen.ExtraData = extra[:n]
+		err := confp.Validate(gen, nil)
+		if err == nil || !strings.Contains(err.Error(), "Clique genesis extra data") {
+			t.Errorf("length %d: want clique extra data error, got: %v", n, err)
+		}
+	}
+	// No signers is structurally valid.
+	gen.ExtraData = make([]byte, 32+65)
+	if err := confp.Validate(gen, nil); err != nil {
+		t.Errorf("no signers: %v", err)
+	}
+}
+
+func TestValidateGenesisCodeHashes(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("..", "testdata", "code_hash_multigeth.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen := &genesisT.Genesis{}
+	if err := json.Unmarshal(b, gen); err != nil {
+		t.Fatal(err)
+	}
+	err = confp.Validate(gen, nil)
+	verr, ok := err.(*confp.ValidationError)
+	if !ok || len(verr.Errs) != 1 {
+		t.Fatalf("want one error, got: %v", err)
+	}
+	if msg := verr.Errs[0].Error(); !strings.Contains(msg, "Genesis account 0x0000000000000000000000000000000000001001 code does not match its declared code hash") {
+		t.Errorf("want mismatch error naming the account, got: %s", msg)
+	}
+
+	// Declared hashes are kept when written.
+	out, err := json.Marshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen2 := &genesisT.Genesis{}
+	if err := json.Unmarshal(out, gen2); err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Validate(gen2, nil); err == nil {
+		t.Error("want mismatch error after round trip")
+	}
+
+	acc := gen.Alloc[common.HexToAddress("0x1001")]
+	h := common.HexToHash("0xc1d5b4ce3e2a6227293fccce2904121c8647bbe16c1216340b851bf12d12560e")
+	acc.CodeHash = &h
+	gen.Alloc[common.HexToAddress("0x1001")] = acc
+	if err := confp.Validate(gen, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		NetworkID:     1,
+		ChainID:       big.NewInt(1),
+		EIP155Block:   big.NewInt(5000000),
+		EIP1344FBlock: big.NewInt(100),
+	}
+	head := uint64(3000000)
+	for _, tt := range []struct {
+		head *uint64
+		want string
+	}{
+		{&head, "EIP1344 active but EIP155 prerequisite not activated at block 3000000"},
+		{nil, "EIP1344 requires EIP155 which activates later (EIP1344: 100, EIP155: 5000000)"},
+	} {
+		if got := confp.Explain(c, tt.head); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+	c.EIP155Block = nil
+	if got, want := confp.Explain(c, &head), "EIP1344 active but EIP155 prerequisite not activated at block 3000000"; got != want {
+		t.Errorf("unset prerequisite: got %q, want %q", got, want)
+	}
+	c.EIP155Block = big.NewInt(100)
+	if got := confp.Explain(c, &head); got != "" {
+		t.Errorf("valid: got %q", got)
+	}
+}
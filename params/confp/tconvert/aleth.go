@@ -93,6 +93,9 @@ func NewAlethGenesisSpec(network string, genesis *genesisT.Genesis) (*aleth.Alet
 	spec.Genesis.GasLimit = (hexutil.Uint64)(genesis.GasLimit)
 
 	for address, account := range genesis.Alloc {
+		if account.BuiltinOnly() {
+			continue
+		}
 		spec.SetAccount(address, account)
 	}
 
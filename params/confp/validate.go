@@ -0,0 +1,311 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package confp
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// ValidationError lists all of the reasons a configuration is invalid.
+type ValidationError struct {
+	Errs []*ConfigValidError
+}
+
+func (err *ValidationError) Error() string {
+	msgs := make([]string, len(err.Errs))
+	for i, e := range err.Errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// configValidator checks a configuration for a single class of problem.
+// The head argument may be nil, in which case only head-agnostic checks should run.
+type configValidator func(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError
+
+var configValidators = []configValidator{
+	validateNetworkID,
+	validateEIP155ChainID,
+	validateTransitionOrder,
+	validateEIPDependencies,
+	validateGenesis,
+	validateGenesisCodeHashes,
+	validateClassicForkBundles,
+	validateForkCanonHashes,
+}
+
+// Validate checks a configuration, returning a *ValidationError listing
+// all failed checks, or nil if the configuration is valid.
+// If head is nil, only head-agnostic checks are run.
+func Validate(conf ctypes.ChainConfigurator, head *uint64) error {
+	var errs []*ConfigValidError
+	for _, v := range configValidators {
+		errs = append(errs, v(conf, head)...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errs: errs}
+}
+
+// Explain describes the first condition for which a configuration is invalid, or returns "" if it is valid.
+// If head is not nil, a transition which is active at head while a prerequisite of it is not
+// is described in preference, since that is what makes the configuration invalid at head.
+func Explain(conf ctypes.ChainConfigurator, head *uint64) string {
+	err := Validate(conf, head)
+	if err == nil {
+		return ""
+	}
+	if head != nil {
+		if s := explainPrerequisites(conf, *head); s != "" {
+			return s
+		}
+	}
+	return err.(*ValidationError).Errs[0].Explain()
+}
+
+// prerequisite is an edge of the transition prerequisite graph: Dep cannot be active unless Pre is.
+// If Optional, Dep may be active if Pre is not set at all.
+type prerequisite struct {
+	Dep, Pre string
+	Optional bool
+}
+
+// prerequisites returns the transition prerequisite graph checked by the structural validators:
+// transitionPrerequisites and eipDependencies, in that order.
+func prerequisites() []prerequisite {
+	var ps []prerequisite
+	for _, p := range transitionPrerequisites {
+		ps = append(ps, prerequisite{Dep: p[1], Pre: p[0], Optional: true})
+	}
+	for _, d := range eipDependencies {
+		ps = append(ps, prerequisite{Dep: d[0], Pre: d[1]})
+	}
+	return ps
+}
+
+// explainPrerequisites describes the first transition which is active at head while a prerequisite
+// of it is not, or returns "" if there is none.
+func explainPrerequisites(conf ctypes.ChainConfigurator, head uint64) string {
+	values := EIPActivations(conf)
+	for _, p := range prerequisites() {
+		if IsTimeActivation(p.Dep) || IsTimeActivation(p.Pre) {
+			continue // Timestamp transitions are not activated by block.
+		}
+		dep, okDep := values[p.Dep]
+		pre, okPre := values[p.Pre]
+		if !okDep || !okPre || dep == nil || *dep > head {
+			continue
+		}
+		if (pre == nil && !p.Optional) || (pre != nil && *pre > head) {
+			return fmt.Sprintf("%s active but %s prerequisite not activated at block %d", p.Dep, p.Pre, head)
+		}
+	}
+	return ""
+}
+
+func validateNetworkID(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	if conf.GetNetworkID() == nil || *conf.GetNetworkID() == 0 {
+		return []*ConfigValidError{NewValidErr("NetworkID cannot be empty nor zero", ">=0", conf.GetNetworkID())}
+	}
+	return nil
+}
+
+func validateEIP155ChainID(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	if head == nil {
+		return nil
+	}
+	if conf.IsForked(conf.GetEIP155Transition, new(big.Int).SetUint64(*head)) && conf.GetChainID() == nil {
+		return []*ConfigValidError{NewValidErr("EIP155 requires ChainID. A:EIP155/B:ChainID", conf.GetEIP155Transition(), conf.GetChainID())}
+	}
+	return nil
+}
+
+// transitionPrerequisites pairs transitions which undo or resume another transition
+// (second) with that transition (first); the second cannot activate before the first.
+var transitionPrerequisites = [][2]string{
+	{"EIP1283", "EIP1283Disable"},
+	{"EIP2200", "EIP2200Disable"},
+	{"EthashECIP1010Pause", "EthashECIP1010Continue"},
+}
+
+func validateTransitionOrder(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	values := EIPActivations(conf)
+	var errs []*ConfigValidError
+	for _, p := range transitionPrerequisites {
+		pre, dep := values[p[0]], values[p[1]]
+		if pre == nil || dep == nil {
+			continue
+		}
+		if *dep < *pre {
+			errs = append(errs, NewValidErr(p[1]+" activates before "+p[0]+". A:"+p[1]+"/B:"+p[0], *dep, *pre))
+		}
+	}
+	return errs
+}
+
+// eipDependencies pairs EIPs (first) with an EIP they depend on (second), named as by EIPActivations;
+// the first cannot be active unless the second is.
+// A timestamp transition may depend on a block transition, which it follows whatever their values,
+// since timestamp forks follow all block forks.
+// Pairs naming transitions a configurator does not have are ignored.
+var eipDependencies = [][2]string{
+	{"EIP161d", "EIP161abc"},   // Touched account deletion uses the EIP161abc definition of empty.
+	{"EIP1344", "EIP155"},      // CHAINID returns the EIP155 chain ID.
+	{"EIP1559", "EIP2930"},     // Dynamic fee transactions include access lists.
+	{"EIP1559", "EIP2718"},     // Dynamic fee transactions are typed transactions.
+	{"EIP2930", "EIP2718"},     // Access list transactions are typed transactions.
+	{"EIP4844Time", "EIP1559"}, // Blob transactions are dynamic fee transactions.
+}
+
+func validateEIPDependencies(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	values := EIPActivations(conf)
+	var errs []*ConfigValidError
+	for _, d := range eipDependencies {
+		dep, okDep := values[d[0]]
+		pre, okPre := values[d[1]]
+		if !okDep || !okPre || dep == nil {
+			continue
+		}
+		what := d[0] + " requires " + d[1]
+		if pre == nil {
+			errs = append(errs, NewValidErr(what+" which is not active. A:"+d[0]+"/B:"+d[1], *dep, pre))
+		} else if IsTimeActivation(d[0]) == IsTimeActivation(d[1]) && *pre > *dep {
+			errs = append(errs, NewValidErr(what+" which activates later. A:"+d[0]+"/B:"+d[1], *dep, *pre))
+		}
+	}
+	return errs
+}
+
+// EIP161Split describes the split of the EIP-161 sub-parts of a configuration, or returns nil if
+// they activate at the same block. go-ethereum activates the sub-parts together (at its EIP158 block);
+// formats which configure them separately (eg. Parity) may split them. A split is valid (unless EIP161d
+// precedes EIP161abc, an EIP dependency error), so is a warning rather than a validation error.
+func EIP161Split(conf ctypes.ChainConfigurator) *ConfigValidError {
+	values := EIPActivations(conf)
+	abc, d := values["EIP161abc"], values["EIP161d"]
+	if abc == nil {
+		return nil // EIP161d alone is an EIP dependency error.
+	}
+	what := "EIP-161 sub-parts are split: EIP161abc and EIP161d activate at different blocks. A:EIP161abc/B:EIP161d"
+	if d == nil {
+		return NewValidErr(what, *abc, d)
+	}
+	if *abc != *d {
+		return NewValidErr(what, *abc, *d)
+	}
+	return nil
+}
+
+// validateGenesis checks genesis block fields, if the configuration has them.
+// Zero values are allowed, since they are filled with defaults on genesis block creation.
+func validateGenesis(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	gen, ok := conf.(ctypes.GenesisBlocker)
+	if !ok {
+		return nil
+	}
+	var errs []*ConfigValidError
+	if gl := gen.GetGenesisGasLimit(); gl != 0 && gl < vars.MinGasLimit {
+		errs = append(errs, NewValidErr("Genesis gas limit below minimum. A:GasLimit/B:MinGasLimit", gl, vars.MinGasLimit))
+	}
+	if d := gen.GetGenesisDifficulty(); d != nil && d.Sign() < 0 {
+		errs = append(errs, NewValidErr("Genesis difficulty cannot be negative", ">=0", d))
+	}
+	// Clique uses the genesis extra data field for its signer list.
+	if !conf.GetConsensusEngineType().IsClique() {
+		if max := conf.GetMaximumExtraDataSize(); max != nil && uint64(len(gen.GetGenesisExtraData())) > *max {
+			errs = append(errs, NewValidErr("Genesis extra data exceeds maximum size. A:ExtraData/B:MaximumExtraDataSize", len(gen.GetGenesisExtraData()), *max))
+		}
+	} else if n := len(gen.GetGenesisExtraData()); n < CliqueExtraVanity+CliqueExtraSeal || (n-CliqueExtraVanity-CliqueExtraSeal)%common.AddressLength != 0 {
+		errs = append(errs, NewValidErr("Clique genesis extra data must be 32 bytes vanity, 20 bytes per signer, and 65 bytes seal. A:ExtraDataLength/B:Want", n, "32+20*N+65"))
+	}
+	return errs
+}
+
+// codeHashDeclarer is implemented by configurations whose genesis accounts may declare the hash of their code.
+type codeHashDeclarer interface {
+	GetAccountCodeHashes() map[common.Address]common.Hash
+}
+
+// validateGenesisCodeHashes checks that the genesis accounts which have both code and a declared code hash
+// have the hash of their code, in ascending address order.
+func validateGenesisCodeHashes(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	gen, ok := conf.(ctypes.GenesisBlocker)
+	if !ok {
+		return nil
+	}
+	declarer, ok := conf.(codeHashDeclarer)
+	if !ok {
+		return nil
+	}
+	declared := declarer.GetAccountCodeHashes()
+	var addrs []common.Address
+	codes := make(map[common.Address][]byte)
+	gen.ForEachAccount(func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+		if _, ok := declared[address]; ok && len(code) > 0 {
+			addrs = append(addrs, address)
+			codes[address] = code
+		}
+		return nil
+	})
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	var errs []*ConfigValidError
+	for _, addr := range addrs {
+		if h := crypto.Keccak256Hash(codes[addr]); h != declared[addr] {
+			errs = append(errs, NewValidErr(fmt.Sprintf("Genesis account %s code does not match its declared code hash. A:CodeHash/B:Keccak256(Code)", addr.Hex()), declared[addr].Hex(), h.Hex()))
+		}
+	}
+	return errs
+}
+
+// Clique genesis extra data lengths, as in consensus/clique.
+const (
+	CliqueExtraVanity = 32 // Fixed number of extra-data prefix bytes reserved for signer vanity
+	CliqueExtraSeal   = 65 // Fixed number of extra-data suffix bytes reserved for signer seal
+)
+
+// validateForkCanonHashes checks the required block hashes (checkpoints) of a configuration,
+// in ascending block order. Hashes are read as 32 bytes, so only the empty hash,
+// which cannot match a block, is invalid.
+func validateForkCanonHashes(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	hashes := conf.GetForkCanonHashes()
+	blocks := make([]uint64, 0, len(hashes))
+	for n := range hashes {
+		blocks = append(blocks, n)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i] < blocks[j]
+	})
+	var errs []*ConfigValidError
+	for _, n := range blocks {
+		if hashes[n] == (common.Hash{}) {
+			errs = append(errs, NewValidErr("Required block hash cannot be empty. A:Block/B:Hash", n, hashes[n].Hex()))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package confp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// classicChainID is the Ethereum Classic mainnet chain id.
+const classicChainID = 61
+
+// classicForkBundle is an Ethereum Classic hard fork which activates a set
+// of transitions (mostly mapping Ethereum EIP bundles) at a single block.
+type classicForkBundle struct {
+	Name        string
+	Transitions []string
+}
+
+var classicForkBundles = []classicForkBundle{
+	{"Die Hard (ECIP-1010)", []string{
+		"EIP155", "EIP160", "EthashECIP1010Pause",
+	}},
+	{"Atlantis (ECIP-1054)", []string{
+		"EIP161abc", "EIP161d", "EIP170", "EthashEIP100B",
+		"EIP140", "EIP198", "EIP211", "EIP212", "EIP213", "EIP214", "EIP658",
+	}},
+	{"Agharta (ECIP-1056)", []string{
+		"EIP145", "EIP1014", "EIP1052",
+	}},
+	{"Aztlan (ECIP-1061)", []string{
+		"EIP152", "EIP1108", "EIP1344", "EIP2028", "EIP2200",
+	}},
+	{"Phoenix (ECIP-1078)", []string{
+		"EIP2200Disable", "EIP1283", "EIP1706", "ECIP1080",
+	}},
+}
+
+// validateClassicForkBundles checks that each Ethereum Classic hard fork
+// activates all of its transitions together; a bundle which is only partially applied is invalid.
+// The check only applies to the Ethereum Classic chain id.
+func validateClassicForkBundles(conf ctypes.ChainConfigurator, head *uint64) []*ConfigValidError {
+	if conf.GetChainID() == nil || conf.GetChainID().Uint64() != classicChainID {
+		return nil
+	}
+	values := EIPActivations(conf)
+	var errs []*ConfigValidError
+	for _, bundle := range classicForkBundles {
+		blocks := make(map[string][]string) // block: transition names
+		for _, name := range bundle.Transitions {
+			b := "nil"
+			if v := values[name]; v != nil {
+				b = fmt.Sprintf("%d", *v)
+			}
+			blocks[b] = append(blocks[b], name)
+		}
+		if len(blocks) <= 1 {
+			continue
+		}
+		parts := []string{}
+		for b, names := range blocks {
+			parts = append(parts, fmt.Sprintf("%s=%s", strings.Join(names, ","), b))
+		}
+		sort.Strings(parts)
+		errs = append(errs, NewValidErr(bundle.Name+" fork bundle partially applied. A:ChainID/B:Transitions", classicChainID, strings.Join(parts, " ")))
+	}
+	return errs
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// ErrNoFormatGuessed is returned by GuessFormat if no format reads the data losslessly.
+var ErrNoFormatGuessed = errors.New("no chain configuration format fits the data")
+
+// Candidate is a format which a configuration was able to be read as.
+// Score is the fraction (0-1) of the input's fields which survive a read/write
+// round trip with the format's data type, other than as preserved unknown fields.
+// Lossless is true if all of the input's fields survive the round trip.
+type Candidate struct {
+	Format   string
+	Conf     ctypes.Configurator
+	Score    float64
+	Lossless bool
+}
+
+// formatTieRanks order formats which fit an input equally well; lower ranks are preferred.
+// Besu genesis files are a superset of go-ethereum's, so go-ethereum's format
+// is preferred for inputs which fit both. Likewise, retesteth's format is Aleth's,
+// and Nethermind's is Parity's.
+var formatTieRanks = map[string]int{
+	"besu":       1,
+	"nethermind": 1,
+	"retesteth":  1,
+}
+
+// Detect attempts to read the data as each known format, returning
+// all candidates which parse, ordered from best to worst match.
+func Detect(data []byte) []Candidate {
+	scored := data
+	if IsBareConfig(data) {
+		// A bare chain config is read (by the geth format) as the config of a genesis.
+		scored = append(append([]byte(`{"config":`), data...), '}')
+	}
+	want, err := jsonKeyPaths(scored)
+	if err != nil || len(want) == 0 {
+		return nil
+	}
+	candidates := []Candidate{}
+	for _, name := range Formats() {
+		conf, err := Read(name, bytes.NewReader(data))
+		if err != nil {
+			continue
+		}
+		b, err := marshalKnownFields(conf)
+		if err != nil {
+			continue
+		}
+		got, err := jsonKeyPaths(b)
+		if err != nil {
+			continue
+		}
+		matched := 0
+		for p := range want {
+			if _, ok := got[p]; ok {
+				matched++
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+		lossless := matched == len(want)
+		if !lossless {
+			if b, err = json.Marshal(conf); err != nil {
+				continue
+			}
+			if got, err = jsonKeyPaths(b); err != nil {
+				continue
+			}
+			lossless = true
+			for p := range want {
+				if _, ok := got[p]; !ok {
+					lossless = false
+					break
+				}
+			}
+		}
+		candidates = append(candidates, Candidate{
+			Format:   name,
+			Conf:     conf,
+			Score:    float64(matched) / float64(len(want)),
+			Lossless: lossless,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score == candidates[j].Score {
+			ri, rj := formatTieRanks[candidates[i].Format], formatTieRanks[candidates[j].Format]
+			if ri != rj {
+				return ri < rj
+			}
+			return candidates[i].Format < candidates[j].Format
+		}
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// GuessFormat returns the format which the data parses as and round-trips
+// cleanly with, ie. all of the input's fields are preserved when the parsed
+// value is written again (if only as unknown fields).
+// Formats are tried in order of the fraction of fields they recognize, then in tie rank,
+// then name order, so the result is deterministic.
+func GuessFormat(data []byte) (string, ctypes.Configurator, error) {
+	for _, c := range Detect(data) {
+		if c.Lossless {
+			return c.Format, c.Conf, nil
+		}
+	}
+	return "", nil, ErrNoFormatGuessed
+}
+
+// marshalKnownFields marshals a configuration without the fields which its data type
+// preserves without knowing them, since those do not show that the format fits the data.
+func marshalKnownFields(conf ctypes.Configurator) ([]byte, error) {
+	ef, ok := conf.(ctypes.ExtraFieldsConfigurator)
+	if !ok || len(ef.GetExtraFields()) == 0 {
+		return json.Marshal(conf)
+	}
+	extra := ef.GetExtraFields()
+	if err := ef.SetExtraFields(nil); err != nil {
+		return nil, err
+	}
+	defer ef.SetExtraFields(extra)
+	return json.Marshal(conf)
+}
+
+// addressKeyRe matches object keys which are account addresses.
+// These are collapsed so that large allocs do not dominate format scoring.
+var addressKeyRe = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{40}$`)
+
+// jsonKeyPaths returns the set of dot-delimited object key paths in a JSON document.
+func jsonKeyPaths(data []byte) (map[string]struct{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	paths := make(map[string]struct{})
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, vv := range m {
+			if addressKeyRe.MatchString(k) {
+				k = "*"
+			}
+			p := k
+			if prefix != "" {
+				p = prefix + "." + k
+			}
+			paths[p] = struct{}{}
+			walk(p, vv)
+		}
+	}
+	walk("", v)
+	return paths, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGuessFormat(t *testing.T) {
+	for _, want := range []string{"parity", "geth"} {
+		data, err := ioutil.ReadFile(filepath.Join("..", "confp", "testdata", "stureby_"+want+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, conf, err := GuessFormat(data)
+		if err != nil {
+			t.Fatalf("%s: %v", want, err)
+		}
+		if got != want || conf == nil {
+			t.Errorf("want: %s, got: %s", want, got)
+		}
+	}
+	// A bare geth chain config is read as the config of a genesis.
+	data, err := ioutil.ReadFile(filepath.Join("..", "confp", "testdata", "stureby_geth_config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _, err := GuessFormat(data); err != nil || got != "geth" {
+		t.Errorf("bare config: want: geth, got: %s (%v)", got, err)
+	}
+	if _, _, err := GuessFormat([]byte(`{"foo": "bar"}`)); err != ErrNoFormatGuessed {
+		t.Errorf("unknown format: want: %v, got: %v", ErrNoFormatGuessed, err)
+	}
+}
+
+// TestGuessFormatUnknownFields tests that a configuration with fields unknown to all formats
+// is read as the format which recognizes most of its fields.
+func TestGuessFormatUnknownFields(t *testing.T) {
+	for want, data := range map[string]string{
+		"geth":      `{"config": {"chainId": 1, "berlinBlock": 0, "futureBlock": 10, "ethash": {}}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`,
+		"multigeth": `{"config": {"chainId": 1, "networkId": 1, "eip2FBlock": 0, "futureBlock": 10, "ethash": {}}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`,
+	} {
+		got, _, err := GuessFormat([]byte(data))
+		if err != nil {
+			t.Fatalf("%s: %v", want, err)
+		}
+		if got != want {
+			t.Errorf("want: %s, got: %s", want, got)
+		}
+	}
+}
+
+// TestReadForeignSchemas tests that data types' decoders return errors,
+// rather than panicking, for schemas and values they do not support.
+func TestReadForeignSchemas(t *testing.T) {
+	parity, err := ioutil.ReadFile(filepath.Join("..", "confp", "testdata", "stureby_parity.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for format, data := range map[string]string{
+		"geth":      string(parity),
+		"parity":    `{"accounts": {"0x0000000000000000000000000000000000000001": {"builtin": {"name": "ecrecover", "pricing": {}}}}}`,
+		"multigeth": `{"config": {"chainId": 1, "networkId": 1, "blockReward": {"0x0": true}}}`,
+	} {
+		if _, err := Read(format, bytes.NewReader([]byte(data))); err == nil {
+			t.Errorf("%s: want error", format)
+		}
+		Detect([]byte(data))
+	}
+}
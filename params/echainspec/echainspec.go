@@ -0,0 +1,253 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package echainspec reads, converts, and writes chain configurations
+// in the formats of the supported clients.
+package echainspec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/aleth"
+	"github.com/ethereum/go-ethereum/params/types/besu"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+	"github.com/ethereum/go-ethereum/params/types/nethermind"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+	"github.com/ethereum/go-ethereum/params/types/retesteth"
+)
+
+// ErrUnknownFormat is returned for format names which are not in the registry.
+var ErrUnknownFormat = errors.New("unknown chain configuration format")
+
+// formatTypes maps format names to constructors for their (empty) data types.
+// Genesis allocations are empty rather than nil, so that they are written as {} not null,
+// which would fail to read again.
+var formatTypes = map[string]func() ctypes.Configurator{
+	"parity": func() ctypes.Configurator {
+		return &parity.ParityChainSpec{}
+	},
+	"multigeth": func() ctypes.Configurator {
+		return &genesisT.Genesis{
+			Config: &multigeth.MultiGethChainConfig{},
+			Alloc:  genesisT.GenesisAlloc{},
+		}
+	},
+	"geth": func() ctypes.Configurator {
+		return &genesisT.Genesis{
+			Config: &goethereum.ChainConfig{},
+			Alloc:  genesisT.GenesisAlloc{},
+		}
+	},
+	"aleth": func() ctypes.Configurator {
+		return &aleth.AlethGenesisSpec{}
+	},
+	"besu": func() ctypes.Configurator {
+		return &genesisT.Genesis{
+			Config: &besu.BesuChainConfig{},
+			Alloc:  genesisT.GenesisAlloc{},
+		}
+	},
+	"nethermind": func() ctypes.Configurator {
+		return &nethermind.NethermindChainSpec{}
+	},
+	"retesteth": func() ctypes.Configurator {
+		return &retesteth.RetestethGenesisSpec{}
+	},
+}
+
+// Formats returns the names of the supported formats, sorted.
+func Formats() []string {
+	names := []string{}
+	for k := range formatTypes {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns an empty configuration value of the given format's data type.
+func New(format string) (ctypes.Configurator, error) {
+	newConf, ok := formatTypes[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	return newConf(), nil
+}
+
+// FormatOf returns the name of the format whose data type a configuration is,
+// or false if it is of none.
+func FormatOf(c ctypes.Configurator) (string, bool) {
+	t := configDataType(c)
+	for _, name := range Formats() {
+		if configDataType(formatTypes[name]()) == t {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Read reads a JSON configuration of the given format.
+// Fields which the format's data type does not know are ignored.
+func Read(format string, r io.Reader) (ctypes.Configurator, error) {
+	return read(format, r, false)
+}
+
+// ReadStrict reads a JSON configuration of the given format, like Read,
+// but returns an error naming the first field which the format's data type does not know.
+func ReadStrict(format string, r io.Reader) (ctypes.Configurator, error) {
+	return read(format, r, true)
+}
+
+func read(format string, r io.Reader, strict bool) (ctypes.Configurator, error) {
+	conf, err := New(format)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if format == "geth" && IsBareConfig(data) {
+		return readBareConfig(conf.(*genesisT.Genesis), data, strict)
+	}
+	if err := unmarshal(data, conf, strict); err != nil {
+		return nil, err
+	}
+	g, ok := conf.(*genesisT.Genesis)
+	if !ok {
+		return conf, checkFields(data, conf, strict)
+	}
+	// Logic in params/types/gen_genesis.go already "auto-magically"
+	// handles genesis Config unmarshaling, and IT PREFERS MULTIGETH,
+	// and the genesis config data types are not mutually exclusive (are overlapping).
+	// So we need to redo custom unmarshaling logic to enforce data type
+	// preference based on passed format value.
+	want, _ := New(format)
+	d := struct {
+		Config ctypes.ChainConfigurator `json:"config"`
+	}{Config: want.(*genesisT.Genesis).Config}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	g.Config = d.Config
+	return g, checkFields(data, g, strict)
+}
+
+// IsBareConfig reports whether JSON data is a bare chain config object (as geth's genesis 'config'),
+// rather than a genesis: it has a chain ID, but no config.
+func IsBareConfig(data []byte) bool {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return false
+	}
+	_, hasConfig := m["config"]
+	_, hasChainID := m["chainId"]
+	return !hasConfig && hasChainID
+}
+
+// readBareConfig reads a bare chain config into the config of an empty genesis.
+// The genesis difficulty is set to zero (rather than left nil), since the genesis
+// could not otherwise be read again once written.
+func readBareConfig(g *genesisT.Genesis, data []byte, strict bool) (*genesisT.Genesis, error) {
+	if err := unmarshal(data, g.Config, strict); err != nil {
+		return nil, err
+	}
+	g.Difficulty = new(big.Int)
+	return g, checkFields(data, g.Config, strict)
+}
+
+// Convert converts a configuration to the data type of the given format.
+func Convert(src ctypes.Configurator, dstFormat string) (ctypes.Configurator, error) {
+	dst, _, err := ConvertWithWarnings(src, dstFormat)
+	return dst, err
+}
+
+// ConvertWithWarnings is like Convert, but also returns the source values
+// which the destination format could not faithfully represent.
+func ConvertWithWarnings(src ctypes.Configurator, dstFormat string) (ctypes.Configurator, []confp.Warning, error) {
+	dst, err := New(dstFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings, err := confp.ConvertWithWarnings(src, dst)
+	if err != nil {
+		return nil, warnings, err
+	}
+	return dst, append(warnings, convertExtraFields(src, dst)...), nil
+}
+
+// configDataType returns the data type of a configuration's chain config.
+func configDataType(c ctypes.Configurator) reflect.Type {
+	if g, ok := c.(*genesisT.Genesis); ok {
+		return reflect.TypeOf(g.Config)
+	}
+	return reflect.TypeOf(c)
+}
+
+// convertExtraFields carries the fields which the source data type preserves, but does not know,
+// to a destination of the same data type (format). They are meaningless to other formats,
+// so are otherwise dropped, with a warning for each.
+func convertExtraFields(src, dst ctypes.Configurator) []confp.Warning {
+	from, ok := src.(ctypes.ExtraFieldsConfigurator)
+	if !ok || len(from.GetExtraFields()) == 0 {
+		return nil
+	}
+	extra := from.GetExtraFields()
+	if to, ok := dst.(ctypes.ExtraFieldsConfigurator); ok && configDataType(src) == configDataType(dst) {
+		m := make(map[string]json.RawMessage, len(extra))
+		for k, v := range extra {
+			m[k] = v
+		}
+		if to.SetExtraFields(m) == nil {
+			return nil
+		}
+	}
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	warnings := make([]confp.Warning, 0, len(keys))
+	for _, k := range keys {
+		warnings = append(warnings, confp.Warning{
+			Field:  fmt.Sprintf("ExtraFields(%s)", k),
+			Value:  string(extra[k]),
+			Reason: "unknown field, not converted to another format",
+		})
+	}
+	return warnings
+}
+
+// Write writes a configuration as indented JSON.
+func Write(c ctypes.Configurator, w io.Writer) error {
+	b, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
@@ -0,0 +1,217 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+)
+
+func TestFormats(t *testing.T) {
+	formats := Formats()
+	if len(formats) != len(formatTypes) {
+		t.Fatalf("got %d formats, want %d", len(formats), len(formatTypes))
+	}
+	if !sort.StringsAreSorted(formats) {
+		t.Errorf("formats not sorted: %v", formats)
+	}
+	for _, f := range formats {
+		if _, err := New(f); err != nil {
+			t.Errorf("format %s: %v", f, err)
+		}
+	}
+	if _, err := New("nonesuch"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("got %v, want %v", err, ErrUnknownFormat)
+	}
+}
+
+// TestReadConvertWrite tests that a configuration survives the read/convert/write
+// pipeline between formats.
+func TestReadConvertWrite(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "confp", "testdata", "stureby_parity.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	src, err := Read("parity", f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := Convert(src, "multigeth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := Write(dst, buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read("multigeth", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Equivalent(src, got); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestReadGethPreference tests that the geth format is read as the go-ethereum
+// data type, even though multigeth's genesis decoding would also accept it.
+func TestReadGethPreference(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "confp", "testdata", "stureby_geth.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	conf, err := Read("geth", f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := conf.(*genesisT.Genesis).Config.(*goethereum.ChainConfig); !ok {
+		t.Errorf("got config type %T, want %T", conf.(*genesisT.Genesis).Config, &goethereum.ChainConfig{})
+	}
+}
+
+// TestReadGethBareConfig tests that the geth format reads a bare chain config
+// as the config of a genesis with no accounts, equivalently to a full genesis.
+func TestReadGethBareConfig(t *testing.T) {
+	read := func(name string) *genesisT.Genesis {
+		f, err := os.Open(filepath.Join("..", "confp", "testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		conf, err := ReadStrict("geth", f)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return conf.(*genesisT.Genesis)
+	}
+	full, bare := read("stureby_geth.json"), read("stureby_geth_config.json")
+	if _, ok := bare.Config.(*goethereum.ChainConfig); !ok {
+		t.Errorf("got config type %T, want %T", bare.Config, &goethereum.ChainConfig{})
+	}
+	if err := confp.Equivalent(full, bare); err != nil {
+		t.Error(err)
+	}
+	if bare.Alloc == nil || len(bare.Alloc) != 0 {
+		t.Errorf("want empty alloc, got %v", bare.Alloc)
+	}
+
+	// The genesis is written in full, so can be read again.
+	buf := new(bytes.Buffer)
+	if err := Write(bare, buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read("geth", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := confp.Equivalent(bare, got); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestReadStrict tests that strict reading rejects fields which the format's data type
+// does not know, including those within types which unmarshal themselves.
+func TestReadStrict(t *testing.T) {
+	cases := []struct {
+		format string
+		data   string
+		field  string // unknown field, if any
+	}{
+		{"geth", `{"config": {"chainId": 1, "eip155Block": 0}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`, ""},
+		{"geth", `{"config": {"chainId": 1, "eip155Bloc": 0}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`, "config.eip155Bloc"},
+		{"geth", `{"chainId": 1, "eip155Block": 0}`, ""},
+		{"geth", `{"chainId": 1, "eip155Bloc": 0}`, "eip155Bloc"},
+		{"multigeth", `{"config": {"chainId": 1}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {"0000000000000000000000000000000000000001": {"balance": "0x1", "balanse": "0x1"}}}`, "alloc.0000000000000000000000000000000000000001.balanse"},
+		{"besu", `{"config": {"chainId": 1, "IstanbulBlock": 0}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`, ""},
+		{"besu", `{"config": {"chainId": 1, "evmStackSize": 2048}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`, "config.evmStackSize"},
+		{"parity", `{"name": "test", "engine": {"Clique": {"params": {"period": 15}}}, "params": {"eip155Transition": "0x0"}}`, ""},
+		{"parity", `{"name": "test", "engine": {"Clique": {"params": {"period": 15}}}, "params": {"eip155Transiton": "0x0"}}`, "params.eip155Transiton"},
+		{"nethermind", `{"name": "test", "engine": {"Clique": {"params": {"period": 15}}}, "params": {"eip2200Transition": "0x0"}}`, ""},
+		{"nethermind", `{"name": "test", "engine": {"Clique": {"params": {"period": 15}}}, "params": {"eip2200Transiton": "0x0"}}`, "params.eip2200Transiton"},
+	}
+	for i, c := range cases {
+		if _, err := Read(c.format, strings.NewReader(c.data)); err != nil {
+			t.Errorf("case %d: lenient read: %v", i, err)
+		}
+		_, err := ReadStrict(c.format, strings.NewReader(c.data))
+		if c.field == "" {
+			if err != nil {
+				t.Errorf("case %d: %v", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("%q", c.field)) {
+			t.Errorf("case %d: got error %v, want unknown field %q", i, err, c.field)
+		}
+	}
+}
+
+// TestConvertExtraFields tests that unknown config fields survive conversion to the same format,
+// and are dropped, with warnings, on conversion to another.
+func TestConvertExtraFields(t *testing.T) {
+	data := `{"config": {"chainId": 1, "eip155Block": 0, "futureBlock": 10, "futureConfig": {"a": 1}, "ethash": {}}, "difficulty": "0x1", "gasLimit": "0x1", "alloc": {}}`
+	conf, err := Read("geth", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(conf.(*genesisT.Genesis).GetExtraFields()); got != 2 {
+		t.Fatalf("got %d extra fields, want 2", got)
+	}
+
+	same, warnings, err := ConvertWithWarnings(conf, "geth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("same format: got warnings %v", warnings)
+	}
+	buf := new(bytes.Buffer)
+	if err := Write(same, buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{`"futureBlock": 10`, `"futureConfig": {`} {
+		if !strings.Contains(buf.String(), k) {
+			t.Errorf("same format: missing %s in %s", k, buf)
+		}
+	}
+
+	_, warnings, err = ConvertWithWarnings(conf, "multigeth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := []string{}
+	for _, w := range warnings {
+		if strings.HasPrefix(w.Field, "ExtraFields(") {
+			fields = append(fields, w.Field)
+		}
+	}
+	if want := "ExtraFields(futureBlock) ExtraFields(futureConfig)"; strings.Join(fields, " ") != want {
+		t.Errorf("other format: got warnings %v, want %s", fields, want)
+	}
+}
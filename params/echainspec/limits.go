@@ -0,0 +1,56 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"github.com/ethereum/go-ethereum/params/confp"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// Unrepresentable reports whether a format cannot represent a configuration's value of a field,
+// named as by confp.EqualConfigs (eg. "EIP7Transition"). The value is then expected not to
+// survive conversion to the format and back, though the conversion succeeds.
+// These are the known limitations of the formats; any other difference after a round trip is a fault.
+func Unrepresentable(format string, c ctypes.ChainConfigurator, field string) bool {
+	switch format {
+	case "parity", "nethermind":
+		// Homestead is configured by the Ethash engine, so is active from genesis for other engines.
+		switch field {
+		case "EIP7Transition", "EthashHomesteadTransition", "EthashEIP2Transition":
+			return c.GetConsensusEngineType() != ctypes.ConsensusEngineT_Ethash
+		}
+	case "besu":
+		switch field {
+		case "EthashBlockRewardSchedule":
+			// Block rewards are those of the reward forks (EIP649, EIP1234) only.
+			return true
+		case "EIP160Transition":
+			// EIP160 is activated by EIP158 or DieHard, with the other changes of those forks.
+			activations := confp.EIPActivations(c)
+			n := activations["EIP160"]
+			return !sameActivation(n, activations["EIP161abc"]) && !sameActivation(n, activations["EthashECIP1010Pause"])
+		}
+	}
+	return false
+}
+
+func sameActivation(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
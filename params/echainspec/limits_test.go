@@ -0,0 +1,58 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/multigeth"
+)
+
+func TestUnrepresentable(t *testing.T) {
+	c := &multigeth.MultiGethChainConfig{
+		EIP7FBlock:   big.NewInt(10),
+		EIP160FBlock: big.NewInt(20),
+		EIP161FBlock: big.NewInt(20),
+		Ethash:       &ctypes.EthashConfig{},
+	}
+	for _, tt := range []struct {
+		format, field string
+		want          bool
+	}{
+		{"parity", "EIP7Transition", false},
+		{"besu", "EthashBlockRewardSchedule", true},
+		{"besu", "EIP160Transition", false},
+		{"geth", "EIP7Transition", false},
+	} {
+		if got := Unrepresentable(tt.format, c, tt.field); got != tt.want {
+			t.Errorf("%s %s: got %v, want %v", tt.format, tt.field, got, tt.want)
+		}
+	}
+	// Homestead is active from genesis in Parity's format, but for the Ethash engine.
+	c.Ethash = nil
+	c.Clique = &ctypes.CliqueConfig{Period: 15, Epoch: 30000}
+	if !Unrepresentable("parity", c, "EIP7Transition") {
+		t.Error("parity EIP7Transition of a Clique chain: want unrepresentable")
+	}
+	// EIP160 is activated by EIP158 in Besu's format.
+	c.EIP160FBlock = big.NewInt(30)
+	if !Unrepresentable("besu", c, "EIP160Transition") {
+		t.Error("besu EIP160Transition apart from EIP161: want unrepresentable")
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// jsonSchemaDraft is the JSON Schema version of generated schemas.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var extraFieldsConfiguratorType = reflect.TypeOf((*ctypes.ExtraFieldsConfigurator)(nil)).Elem()
+
+// preservesExtraFields returns true for the chain config data types which decode themselves
+// only to preserve the fields they do not know. (The genesis has the methods of its config.)
+func preservesExtraFields(t reflect.Type) bool {
+	return t != reflect.TypeOf(genesisT.Genesis{}) && reflect.PtrTo(t).Implements(extraFieldsConfiguratorType)
+}
+
+// Schema returns a JSON Schema (draft-07) of the given format's configurations,
+// generated from its data type by reflection.
+//
+// Objects are described by their fields' JSON keys; fields tagged as required
+// (gencodec:"required") are required. Values of types which decode themselves
+// (eg. big integers, hashes, and Parity's hex-or-decimal numbers) may take several forms,
+// so are not constrained, nor are the numbers of types which decode themselves,
+// since their fields may be decoded from other forms (eg. the genesis's hex nonce),
+// unless they only decode themselves to preserve the fields they do not know.
+func Schema(format string) (map[string]interface{}, error) {
+	conf, err := New(format)
+	if err != nil {
+		return nil, err
+	}
+	s := nonNullSchema(reflect.ValueOf(conf), false, map[reflect.Type]bool{})
+	s["$schema"] = jsonSchemaDraft
+	s["title"] = format + " chain configuration"
+	return s, nil
+}
+
+// valueSchema returns the schema of a value. Interfaces are described by their dynamic values,
+// and nil pointers by their element types. Pointers, maps, and slices may be null. If loose, numbers and the values of types
+// which decode themselves are not constrained.
+// Types in seen are being described, and are not described again (as recursive types would be).
+func valueSchema(rv reflect.Value, loose bool, seen map[reflect.Type]bool) map[string]interface{} {
+	s := nonNullSchema(rv, loose, seen)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if typ, ok := s["type"].(string); ok {
+			s["type"] = []string{typ, "null"}
+		}
+	}
+	return s
+}
+
+func nonNullSchema(rv reflect.Value, loose bool, seen map[reflect.Type]bool) map[string]interface{} {
+	rv = indirectValue(rv)
+	if !rv.IsValid() {
+		return map[string]interface{}{}
+	}
+	t := rv.Type()
+	if t.Kind() == reflect.Map {
+		// Maps which decode themselves (eg. the genesis alloc, and block-keyed schedules)
+		// are objects, though their values may be decoded from other forms.
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": valueSchema(reflect.New(t.Elem()).Elem(), loose || decodesItself(t), seen),
+		}
+	}
+	if decodesItself(t) && (t.Kind() != reflect.Struct || !declaresJSONKeys(t)) {
+		if !loose && !reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		fields := jsonStructFields(rv)
+		if ef, ok := rv.Addr().Interface().(extraFielder); ok {
+			fields = append(fields, jsonStructFields(reflect.ValueOf(ef.ExtraJSONFields()))...)
+		}
+		// Types which preserve the fields they do not know decode the others as encoding/json does.
+		fieldsLoose := decodesItself(t) && !preservesExtraFields(t)
+		props := map[string]interface{}{}
+		required := []string{}
+		for _, f := range fields {
+			s := valueSchema(f.Value, fieldsLoose, seen)
+			if prev, ok := props[f.Key]; ok {
+				// An extra field of the same key (as Nethermind's params) declares more of the value's fields.
+				mergeSchemaProperties(prev.(map[string]interface{}), s)
+				continue
+			}
+			props[f.Key] = s
+			if f.Field.Tag.Get("gencodec") == "required" {
+				required = append(required, f.Key)
+			}
+		}
+		s := map[string]interface{}{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{
+			"type":  "array",
+			"items": valueSchema(reflect.New(t.Elem()).Elem(), loose, seen),
+		}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if loose {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		if loose {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"type": "number"}
+	}
+	return map[string]interface{}{}
+}
+
+// mergeSchemaProperties adds the properties of object schema b to those of object schema a
+// which a does not have, merging the properties of those both have.
+func mergeSchemaProperties(a, b map[string]interface{}) {
+	pa, ok := a["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	pb, ok := b["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for k, v := range pb {
+		if prev, ok := pa[k]; ok {
+			mergeSchemaProperties(prev.(map[string]interface{}), v.(map[string]interface{}))
+			continue
+		}
+		pa[k] = v
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// checkSchema returns an error for the first part of the decoded JSON value v which
+// the schema does not accept, supporting the keywords which Schema generates.
+func checkSchema(path string, s map[string]interface{}, v interface{}) error {
+	typ := s["type"]
+	if types, ok := typ.([]string); ok {
+		if v == nil {
+			return nil // nullable
+		}
+		typ = types[0]
+	}
+	switch typ {
+	case "object":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: want object, got %T", path, v)
+		}
+		if req, ok := s["required"].([]string); ok {
+			for _, k := range req {
+				if _, ok := m[k]; !ok {
+					return fmt.Errorf("%s: missing required %s", path, k)
+				}
+			}
+		}
+		props, _ := s["properties"].(map[string]interface{})
+		for k, vv := range m {
+			if ps, ok := props[k]; ok {
+				if err := checkSchema(path+"."+k, ps.(map[string]interface{}), vv); err != nil {
+					return err
+				}
+			} else if as, ok := s["additionalProperties"]; ok {
+				if err := checkSchema(path+"."+k, as.(map[string]interface{}), vv); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		list, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: want array, got %T", path, v)
+		}
+		for i, vv := range list {
+			if err := checkSchema(fmt.Sprintf("%s[%d]", path, i), s["items"].(map[string]interface{}), vv); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: want string, got %T", path, v)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: want boolean, got %T", path, v)
+		}
+	case "integer", "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: want %s, got %T", path, typ, v)
+		}
+	}
+	return nil
+}
+
+// TestSchema tests that each format's schema accepts a configuration written in the format.
+func TestSchema(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "confp", "testdata", "stureby_parity.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	src, err := Read("parity", f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range Formats() {
+		s, err := Schema(format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if s["$schema"] != jsonSchemaDraft || s["type"] != "object" {
+			t.Errorf("%s: got $schema %v, type %v", format, s["$schema"], s["type"])
+		}
+		conf, err := Convert(src, format)
+		if err != nil {
+			continue // eg. the format cannot configure the spec's engine
+		}
+		var buf bytes.Buffer
+		if err := Write(conf, &buf); err != nil {
+			t.Fatal(err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkSchema(format, s, v); err != nil {
+			t.Errorf("%s: %v", format, err)
+		}
+	}
+
+	s, err := Schema("multigeth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{
+		`{"config": {"networkId": "1"}, "gasLimit": 1, "difficulty": 1, "alloc": {}}`,
+		`{"config": {"networkId": 1}, "difficulty": 1, "alloc": {}}`,
+	} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(c), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkSchema("multigeth", s, v); err == nil {
+			t.Errorf("want schema error for %s", c)
+		}
+	}
+	if _, err := Schema("foo"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("want %v, got %v", ErrUnknownFormat, err)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package echainspec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// unmarshal decodes the JSON data into v.
+// If strict, fields which v's type does not know are an error.
+func unmarshal(data []byte, v interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// extraFielder is implemented by data types which read JSON fields, by custom
+// unmarshaling, which their Go fields do not declare.
+// ExtraJSONFields returns a value whose type declares the extra fields.
+type extraFielder interface {
+	ExtraJSONFields() interface{}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// checkFields returns an error for the first object key in the JSON data
+// which the configuration's data type does not know, if strict.
+//
+// The decoder's DisallowUnknownFields does not apply within types which unmarshal
+// themselves (eg. the genesis, and Besu and Nethermind configurations),
+// so the data's keys are checked against those of the data type's fields.
+// As with encoding/json, keys match field names case-insensitively.
+// Types which unmarshal themselves are assumed to read their fields' keys,
+// if they declare any, and are otherwise not checked.
+func checkFields(data []byte, conf interface{}, strict bool) error {
+	if !strict {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return checkValueFields("", v, reflect.ValueOf(conf))
+}
+
+// checkValueFields checks the keys of the decoded JSON value v against the fields of rvs.
+// A key is known if any of the values has a field for it.
+func checkValueFields(path string, v interface{}, rvs ...reflect.Value) error {
+	var (
+		fields   = make(map[string][]reflect.Value)
+		isStruct bool
+	)
+	for _, rv := range rvs {
+		rv = indirectValue(rv)
+		if !rv.IsValid() {
+			// No concrete type to check against.
+			return nil
+		}
+		switch rv.Kind() {
+		case reflect.Map:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			for k, vv := range m {
+				if err := checkValueFields(joinFieldPath(path, k), vv, reflect.New(rv.Type().Elem()).Elem()); err != nil {
+					return err
+				}
+			}
+			return nil
+		case reflect.Slice, reflect.Array:
+			list, ok := v.([]interface{})
+			if !ok || decodesItself(rv.Type()) {
+				return nil
+			}
+			for i, vv := range list {
+				if err := checkValueFields(fmt.Sprintf("%s[%d]", path, i), vv, reflect.New(rv.Type().Elem()).Elem()); err != nil {
+					return err
+				}
+			}
+			return nil
+		case reflect.Struct:
+			if decodesItself(rv.Type()) && !declaresJSONKeys(rv.Type()) {
+				return nil
+			}
+			isStruct = true
+			for k, f := range jsonFields(rv) {
+				fields[k] = append(fields[k], f)
+			}
+			if ef, ok := rv.Addr().Interface().(extraFielder); ok {
+				for k, f := range jsonFields(reflect.ValueOf(ef.ExtraJSONFields())) {
+					fields[k] = append(fields[k], f)
+				}
+			}
+		}
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok || !isStruct {
+		return nil
+	}
+	for k, vv := range m {
+		fs, ok := fields[strings.ToLower(k)]
+		if !ok {
+			return fmt.Errorf("json: unknown field %q", joinFieldPath(path, k))
+		}
+		if err := checkValueFields(joinFieldPath(path, k), vv, fs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indirectValue dereferences pointers and interfaces, allocating for nil pointers.
+// The invalid value is returned for nil interfaces.
+func indirectValue(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			if rv.Kind() == reflect.Interface {
+				return reflect.Value{}
+			}
+			rv = reflect.New(rv.Type().Elem())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct && !rv.CanAddr() {
+		// Make the value addressable, so that methods with pointer receivers are found.
+		cp := reflect.New(rv.Type()).Elem()
+		cp.Set(rv)
+		rv = cp
+	}
+	return rv
+}
+
+// jsonFields returns the struct's fields by their lowercased JSON keys,
+// including the fields of embedded structs.
+func jsonFields(rv reflect.Value) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	for _, f := range jsonStructFields(rv) {
+		k := strings.ToLower(f.Key)
+		if _, ok := fields[k]; !ok {
+			fields[k] = f.Value
+		}
+	}
+	return fields
+}
+
+// jsonField is a struct field, by its JSON key.
+type jsonField struct {
+	Key   string
+	Field reflect.StructField
+	Value reflect.Value
+}
+
+// jsonStructFields returns the struct's fields in order, by their JSON keys,
+// including the fields of embedded structs (after the struct's own fields of the same key).
+func jsonStructFields(rv reflect.Value) []jsonField {
+	rv = indirectValue(rv)
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields, embedded []jsonField
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" && !strings.HasPrefix(tag, "-,") {
+			continue
+		}
+		if f.Anonymous && name == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			embedded = append(embedded, jsonStructFields(rv.Field(i))...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{Key: name, Field: f, Value: rv.Field(i)})
+	}
+	return append(fields, embedded...)
+}
+
+// decodesItself reports whether values of the type are decoded by their own methods.
+func decodesItself(t reflect.Type) bool {
+	p := reflect.PtrTo(t)
+	return p.Implements(jsonUnmarshalerType) || p.Implements(textUnmarshalerType)
+}
+
+// declaresJSONKeys reports whether any of the struct's fields,
+// or those of its embedded structs, have JSON tags.
+func declaresJSONKeys(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("json"); ok {
+			return true
+		}
+		if f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct && declaresJSONKeys(indirectType(f.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -38,6 +38,7 @@ type AlethGenesisSpec struct {
 		ConstantinopleForkBlock    *hexutil.Big          `json:"constantinopleForkBlock,omitempty"`
 		ConstantinopleFixForkBlock *hexutil.Big          `json:"constantinopleFixForkBlock,omitempty"`
 		IstanbulForkBlock          *hexutil.Big          `json:"istanbulForkBlock,omitempty"`
+		MuirGlacierForkBlock       *hexutil.Big          `json:"muirGlacierForkBlock,omitempty"`
 		MinGasLimit                hexutil.Uint64        `json:"minGasLimit"`
 		MaxGasLimit                hexutil.Uint64        `json:"maxGasLimit"`
 		TieBreakingGas             bool                  `json:"tieBreakingGas"`
@@ -67,9 +68,11 @@ type AlethGenesisSpec struct {
 // AlethGenesisSpecAccount is the prefunded genesis account and/or precompiled
 // contract definition.
 type AlethGenesisSpecAccount struct {
-	Balance     *math.HexOrDecimal256    `json:"balance,omitempty"`
-	Nonce       uint64                   `json:"nonce,omitempty"`
-	Precompiled *AlethGenesisSpecBuiltin `json:"precompiled,omitempty"`
+	Balance     *math.HexOrDecimal256       `json:"balance,omitempty"`
+	Nonce       uint64                      `json:"nonce,omitempty"`
+	Code        hexutil.Bytes               `json:"code,omitempty"`
+	Storage     map[common.Hash]common.Hash `json:"storage,omitempty"`
+	Precompiled *AlethGenesisSpecBuiltin    `json:"precompiled,omitempty"`
 }
 
 // AlethGenesisSpecBuiltin is the precompiled contract definition.
@@ -0,0 +1,1108 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package aleth
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/internal"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// File contains the Aleth implementation of the Configurator interface.
+// Like go-ethereum, Aleth configures protocol changes by named fork blocks,
+// so setting any EIP of a fork sets the block of the fork as a whole.
+
+func newU64(u uint64) *uint64 {
+	return &u
+}
+
+func bigNewU64(i *hexutil.Big) *uint64 {
+	if i == nil {
+		return nil
+	}
+	return newU64(i.ToInt().Uint64())
+}
+
+func setBig(u *uint64) *hexutil.Big {
+	if u == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetUint64(*u))
+}
+
+// sealEngineEthash and sealEngineNoProof are Aleth's names for ethash-based seal engines.
+// NoProof follows ethash rules, but does not verify the proof of work.
+const (
+	sealEngineEthash  = "Ethash"
+	sealEngineNoProof = "NoProof"
+)
+
+// setForkPrecompile defines (or, if n is nil, removes) a precompiled contract activated at block n.
+// Aleth hardcodes the gas pricing of these precompiles.
+func (spec *AlethGenesisSpec) setForkPrecompile(address byte, name string, n *uint64) {
+	if n == nil {
+		if a, ok := spec.Accounts[common.UnprefixedAddress(common.BytesToAddress([]byte{address}))]; ok {
+			a.Precompiled = nil
+		}
+		return
+	}
+	spec.SetPrecompile(address, &AlethGenesisSpecBuiltin{Name: name, StartingBlock: setBig(n)})
+}
+
+func (spec *AlethGenesisSpec) GetAccountStartNonce() *uint64 {
+	return newU64(uint64(spec.Params.AccountStartNonce))
+}
+
+func (spec *AlethGenesisSpec) SetAccountStartNonce(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.AccountStartNonce = math.HexOrDecimal64(*n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetMaximumExtraDataSize() *uint64 {
+	if spec.Params.MaximumExtraDataSize == 0 {
+		return internal.GlobalConfigurator().GetMaximumExtraDataSize()
+	}
+	return newU64(uint64(spec.Params.MaximumExtraDataSize))
+}
+
+func (spec *AlethGenesisSpec) SetMaximumExtraDataSize(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.MaximumExtraDataSize = hexutil.Uint64(*n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetMinGasLimit() *uint64 {
+	if spec.Params.MinGasLimit == 0 {
+		return internal.GlobalConfigurator().GetMinGasLimit()
+	}
+	return newU64(uint64(spec.Params.MinGasLimit))
+}
+
+func (spec *AlethGenesisSpec) SetMinGasLimit(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.MinGasLimit = hexutil.Uint64(*n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGasLimitBoundDivisor() *uint64 {
+	if spec.Params.GasLimitBoundDivisor == 0 {
+		return internal.GlobalConfigurator().GetGasLimitBoundDivisor()
+	}
+	return newU64(uint64(spec.Params.GasLimitBoundDivisor))
+}
+
+func (spec *AlethGenesisSpec) SetGasLimitBoundDivisor(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.GasLimitBoundDivisor = math.HexOrDecimal64(*n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetNetworkID() *uint64 {
+	if spec.Params.NetworkID == 0 {
+		return nil
+	}
+	return newU64(uint64(spec.Params.NetworkID))
+}
+
+func (spec *AlethGenesisSpec) SetNetworkID(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.NetworkID = hexutil.Uint64(*n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetChainID() *big.Int {
+	if spec.Params.ChainID == 0 {
+		return nil
+	}
+	return new(big.Int).SetUint64(uint64(spec.Params.ChainID))
+}
+
+func (spec *AlethGenesisSpec) SetChainID(i *big.Int) error {
+	if i == nil {
+		spec.Params.ChainID = 0
+		return nil
+	}
+	spec.Params.ChainID = hexutil.Uint64(i.Uint64())
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetBootnodes() []string {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetBootnodes(b []string) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (spec *AlethGenesisSpec) GetMaxCodeSize() *uint64 {
+	return internal.GlobalConfigurator().GetMaxCodeSize()
+}
+
+func (spec *AlethGenesisSpec) SetMaxCodeSize(n *uint64) error {
+	return internal.GlobalConfigurator().SetMaxCodeSize(n)
+}
+
+func (spec *AlethGenesisSpec) GetEIP7Transition() *uint64 {
+	return bigNewU64(spec.Params.HomesteadForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP7Transition(n *uint64) error {
+	spec.Params.HomesteadForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP150Transition() *uint64 {
+	return bigNewU64(spec.Params.EIP150ForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP150Transition(n *uint64) error {
+	spec.Params.EIP150ForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP152Transition() *uint64 {
+	return bigNewU64(spec.Params.IstanbulForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP152Transition(n *uint64) error {
+	spec.Params.IstanbulForkBlock = setBig(n)
+	spec.setForkPrecompile(9, "blake2_compression", n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP160Transition() *uint64 {
+	return bigNewU64(spec.Params.EIP158ForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP160Transition(n *uint64) error {
+	spec.Params.EIP158ForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP161abcTransition() *uint64 {
+	return bigNewU64(spec.Params.EIP158ForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP161abcTransition(n *uint64) error {
+	spec.Params.EIP158ForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP161dTransition() *uint64 {
+	return bigNewU64(spec.Params.EIP158ForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP161dTransition(n *uint64) error {
+	spec.Params.EIP158ForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP170Transition() *uint64 {
+	return bigNewU64(spec.Params.EIP158ForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP170Transition(n *uint64) error {
+	spec.Params.EIP158ForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP155Transition() *uint64 {
+	return bigNewU64(spec.Params.EIP158ForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP155Transition(n *uint64) error {
+	spec.Params.EIP158ForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP140Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP140Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP198Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP198Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	spec.setForkPrecompile(5, "modexp", n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP211Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP211Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP212Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP212Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	spec.setForkPrecompile(8, "alt_bn128_pairing_product", n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP213Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP213Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	spec.setForkPrecompile(6, "alt_bn128_G1_add", n)
+	spec.setForkPrecompile(7, "alt_bn128_G1_mul", n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP214Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP214Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP658Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP658Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP145Transition() *uint64 {
+	return bigNewU64(spec.Params.ConstantinopleForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP145Transition(n *uint64) error {
+	spec.Params.ConstantinopleForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP1014Transition() *uint64 {
+	return bigNewU64(spec.Params.ConstantinopleForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1014Transition(n *uint64) error {
+	spec.Params.ConstantinopleForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP1052Transition() *uint64 {
+	return bigNewU64(spec.Params.ConstantinopleForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1052Transition(n *uint64) error {
+	spec.Params.ConstantinopleForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP1283Transition() *uint64 {
+	return bigNewU64(spec.Params.ConstantinopleForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1283Transition(n *uint64) error {
+	spec.Params.ConstantinopleForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP1283DisableTransition() *uint64 {
+	return bigNewU64(spec.Params.ConstantinopleFixForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1283DisableTransition(n *uint64) error {
+	spec.Params.ConstantinopleFixForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP1108Transition() *uint64 {
+	return bigNewU64(spec.Params.IstanbulForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1108Transition(n *uint64) error {
+	spec.Params.IstanbulForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP2200Transition() *uint64 {
+	return bigNewU64(spec.Params.IstanbulForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP2200Transition(n *uint64) error {
+	spec.Params.IstanbulForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP2200DisableTransition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP2200DisableTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP1344Transition() *uint64 {
+	return bigNewU64(spec.Params.IstanbulForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1344Transition(n *uint64) error {
+	spec.Params.IstanbulForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP1884Transition() *uint64 {
+	return bigNewU64(spec.Params.IstanbulForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP1884Transition(n *uint64) error {
+	spec.Params.IstanbulForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEIP2028Transition() *uint64 {
+	return bigNewU64(spec.Params.IstanbulForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEIP2028Transition(n *uint64) error {
+	spec.Params.IstanbulForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetECIP1080Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetECIP1080Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP1706Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP1706Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP2718Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP2718Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP2929Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP2929Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP2930Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP2930Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP2565Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP2565Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP1559Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP1559Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	return internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator()
+}
+
+func (spec *AlethGenesisSpec) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP1559BaseFeeChangeDenominator(n)
+}
+
+func (spec *AlethGenesisSpec) GetEIP1559ElasticityMultiplier() *uint64 {
+	return internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier()
+}
+
+func (spec *AlethGenesisSpec) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP1559ElasticityMultiplier(n)
+}
+
+func (spec *AlethGenesisSpec) GetEIP3529Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP3529Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP3541Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP3541Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEWASMTransition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEWASMTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP3651TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP3651TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP3855TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP3855TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP3860TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP3860TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP4895TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP4895TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP1153TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP1153TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP4788TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP4788TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP4844TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP4844TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP5656TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP5656TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP6780TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP6780TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP7516TransitionTime() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEIP7516TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	return internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock()
+}
+
+func (spec *AlethGenesisSpec) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP4844TargetBlobsPerBlock(n)
+}
+
+func (spec *AlethGenesisSpec) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	return internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock()
+}
+
+func (spec *AlethGenesisSpec) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP4844MaxBlobsPerBlock(n)
+}
+
+func (spec *AlethGenesisSpec) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	return internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction()
+}
+
+func (spec *AlethGenesisSpec) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP4844BlobBaseFeeUpdateFraction(n)
+}
+
+func (spec *AlethGenesisSpec) IsForked(fn func() *uint64, n *big.Int) bool {
+	f := fn()
+	if f == nil || n == nil {
+		return false
+	}
+	return big.NewInt(int64(*f)).Cmp(n) <= 0
+}
+
+// Aleth has no fork canon hashes.
+
+func (spec *AlethGenesisSpec) GetForkCanonHash(n uint64) common.Hash {
+	return common.Hash{}
+}
+
+func (spec *AlethGenesisSpec) SetForkCanonHash(n uint64, h common.Hash) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (spec *AlethGenesisSpec) GetForkCanonHashes() map[uint64]common.Hash {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetConsensusEngineType() ctypes.ConsensusEngineT {
+	switch spec.SealEngine {
+	case sealEngineEthash, sealEngineNoProof:
+		return ctypes.ConsensusEngineT_Ethash
+	}
+	return ctypes.ConsensusEngineT_Unknown
+}
+
+// MustSetConsensusEngineType sets the seal engine.
+// Since Aleth requires them, unset ethash-only parameters are given their defaults.
+func (spec *AlethGenesisSpec) MustSetConsensusEngineType(t ctypes.ConsensusEngineT) error {
+	switch t {
+	case ctypes.ConsensusEngineT_Ethash:
+		if spec.SealEngine != sealEngineNoProof {
+			spec.SealEngine = sealEngineEthash
+		}
+		if spec.Params.BlockReward == nil {
+			spec.Params.BlockReward = (*hexutil.Big)(vars.FrontierBlockReward)
+		}
+		if spec.Params.MaxGasLimit == 0 {
+			spec.Params.MaxGasLimit = hexutil.Uint64(math.MaxInt64)
+		}
+		spec.setFrontierPrecompiles()
+		return nil
+	default:
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+}
+
+// setFrontierPrecompiles defines the precompiled contracts active since genesis, if they are not yet defined.
+func (spec *AlethGenesisSpec) setFrontierPrecompiles() {
+	for address, builtin := range map[byte]*AlethGenesisSpecBuiltin{
+		1: {Name: "ecrecover", Linear: &AlethGenesisSpecLinearPricing{Base: 3000}},
+		2: {Name: "sha256", Linear: &AlethGenesisSpecLinearPricing{Base: 60, Word: 12}},
+		3: {Name: "ripemd160", Linear: &AlethGenesisSpecLinearPricing{Base: 600, Word: 120}},
+		4: {Name: "identity", Linear: &AlethGenesisSpecLinearPricing{Base: 15, Word: 3}},
+	} {
+		if a, ok := spec.Accounts[common.UnprefixedAddress(common.BytesToAddress([]byte{address}))]; ok && a.Precompiled != nil {
+			continue
+		}
+		spec.SetPrecompile(address, builtin)
+	}
+}
+
+func (spec *AlethGenesisSpec) GetEthashMinimumDifficulty() *big.Int {
+	if spec.Params.MinimumDifficulty == nil {
+		return internal.GlobalConfigurator().GetEthashMinimumDifficulty()
+	}
+	return spec.Params.MinimumDifficulty.ToInt()
+}
+
+func (spec *AlethGenesisSpec) SetEthashMinimumDifficulty(i *big.Int) error {
+	if i == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.MinimumDifficulty = (*hexutil.Big)(i)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashDifficultyBoundDivisor() *big.Int {
+	if spec.Params.DifficultyBoundDivisor == nil {
+		return internal.GlobalConfigurator().GetEthashDifficultyBoundDivisor()
+	}
+	return (*big.Int)(spec.Params.DifficultyBoundDivisor)
+}
+
+func (spec *AlethGenesisSpec) SetEthashDifficultyBoundDivisor(i *big.Int) error {
+	if i == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.DifficultyBoundDivisor = (*math.HexOrDecimal256)(i)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashDurationLimit() *big.Int {
+	if spec.Params.DurationLimit == nil {
+		return internal.GlobalConfigurator().GetEthashDurationLimit()
+	}
+	return (*big.Int)(spec.Params.DurationLimit)
+}
+
+func (spec *AlethGenesisSpec) SetEthashDurationLimit(i *big.Int) error {
+	if i == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	spec.Params.DurationLimit = (*math.HexOrDecimal256)(i)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashHomesteadTransition() *uint64 {
+	return bigNewU64(spec.Params.HomesteadForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEthashHomesteadTransition(n *uint64) error {
+	spec.Params.HomesteadForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashEIP2Transition() *uint64 {
+	return bigNewU64(spec.Params.HomesteadForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP2Transition(n *uint64) error {
+	spec.Params.HomesteadForkBlock = setBig(n)
+	return nil
+}
+
+// The DAO hard fork block is unset when zero.
+
+func (spec *AlethGenesisSpec) GetEthashEIP779Transition() *uint64 {
+	if spec.Params.DaoHardforkBlock == 0 {
+		return nil
+	}
+	return newU64(uint64(spec.Params.DaoHardforkBlock))
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP779Transition(n *uint64) error {
+	if n == nil {
+		spec.Params.DaoHardforkBlock = 0
+		return nil
+	}
+	spec.Params.DaoHardforkBlock = math.HexOrDecimal64(*n)
+	return nil
+}
+
+// The DAO hard fork refund is that of the Ethereum mainnet DAO fork.
+
+func (spec *AlethGenesisSpec) GetEthashDAOForkBeneficiary() *common.Address {
+	return internal.DAOForkBeneficiary(spec.GetEthashEIP779Transition())
+}
+
+func (spec *AlethGenesisSpec) SetEthashDAOForkBeneficiary(a *common.Address) error {
+	return internal.SetDAOForkBeneficiary(a)
+}
+
+func (spec *AlethGenesisSpec) GetEthashDAOForkAccounts() []common.Address {
+	return internal.DAOForkAccounts(spec.GetEthashEIP779Transition())
+}
+
+func (spec *AlethGenesisSpec) SetEthashDAOForkAccounts(addrs []common.Address) error {
+	return internal.SetDAOForkAccounts(addrs)
+}
+
+func (spec *AlethGenesisSpec) GetEthashEIP649Transition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP649Transition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashEIP1234Transition() *uint64 {
+	return bigNewU64(spec.Params.ConstantinopleForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP1234Transition(n *uint64) error {
+	spec.Params.ConstantinopleForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashEIP2384Transition() *uint64 {
+	return bigNewU64(spec.Params.MuirGlacierForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP2384Transition(n *uint64) error {
+	spec.Params.MuirGlacierForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashEIP3554Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP3554Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEthashECIP1010PauseTransition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashECIP1010PauseTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEthashECIP1010ContinueTransition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashECIP1010ContinueTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEthashECIP1017Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashECIP1017Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEthashECIP1017EraRounds() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashECIP1017EraRounds(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetEthashEIP100BTransition() *uint64 {
+	return bigNewU64(spec.Params.ByzantiumForkBlock)
+}
+
+func (spec *AlethGenesisSpec) SetEthashEIP100BTransition(n *uint64) error {
+	spec.Params.ByzantiumForkBlock = setBig(n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetEthashECIP1041Transition() *uint64 {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashECIP1041Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+// Aleth hardcodes the difficulty bomb delays and block rewards of its forks.
+
+func (spec *AlethGenesisSpec) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashDifficultyBombDelaySchedule(m ctypes.Uint64BigMapEncodesHex) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (spec *AlethGenesisSpec) GetEthashBlockRewardSchedule() ctypes.Uint64BigMapEncodesHex {
+	return nil
+}
+
+func (spec *AlethGenesisSpec) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesHex) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (spec *AlethGenesisSpec) GetEthashNoProof() bool {
+	return spec.SealEngine == sealEngineNoProof
+}
+
+// SetEthashNoProof sets the NoProof or Ethash seal engine.
+func (spec *AlethGenesisSpec) SetEthashNoProof(b bool) error {
+	if b {
+		spec.SealEngine = sealEngineNoProof
+	} else if spec.SealEngine == sealEngineNoProof {
+		spec.SealEngine = sealEngineEthash
+	}
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetCliquePeriod() uint64 {
+	return 0
+}
+
+func (spec *AlethGenesisSpec) SetCliquePeriod(n uint64) error {
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetCliqueEpoch() uint64 {
+	return 0
+}
+
+func (spec *AlethGenesisSpec) SetCliqueEpoch(n uint64) error {
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetSealingType() ctypes.BlockSealingT {
+	return ctypes.BlockSealing_Ethereum
+}
+
+func (spec *AlethGenesisSpec) SetSealingType(in ctypes.BlockSealingT) error {
+	if in == ctypes.BlockSealing_Ethereum {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (spec *AlethGenesisSpec) GetGenesisSealerEthereumNonce() uint64 {
+	if len(spec.Genesis.Nonce) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(spec.Genesis.Nonce)
+}
+
+func (spec *AlethGenesisSpec) SetGenesisSealerEthereumNonce(n uint64) error {
+	spec.Genesis.Nonce = make(hexutil.Bytes, 8)
+	binary.BigEndian.PutUint64(spec.Genesis.Nonce, n)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisSealerEthereumMixHash() common.Hash {
+	return spec.Genesis.MixHash
+}
+
+func (spec *AlethGenesisSpec) SetGenesisSealerEthereumMixHash(h common.Hash) error {
+	spec.Genesis.MixHash = h
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisDifficulty() *big.Int {
+	return spec.Genesis.Difficulty.ToInt()
+}
+
+func (spec *AlethGenesisSpec) SetGenesisDifficulty(i *big.Int) error {
+	spec.Genesis.Difficulty = (*hexutil.Big)(i)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisAuthor() common.Address {
+	return spec.Genesis.Author
+}
+
+func (spec *AlethGenesisSpec) SetGenesisAuthor(a common.Address) error {
+	spec.Genesis.Author = a
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisTimestamp() uint64 {
+	return uint64(spec.Genesis.Timestamp)
+}
+
+func (spec *AlethGenesisSpec) SetGenesisTimestamp(u uint64) error {
+	spec.Genesis.Timestamp = hexutil.Uint64(u)
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisParentHash() common.Hash {
+	return spec.Genesis.ParentHash
+}
+
+func (spec *AlethGenesisSpec) SetGenesisParentHash(h common.Hash) error {
+	spec.Genesis.ParentHash = h
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisExtraData() []byte {
+	return spec.Genesis.ExtraData
+}
+
+func (spec *AlethGenesisSpec) SetGenesisExtraData(b []byte) error {
+	spec.Genesis.ExtraData = b
+	return nil
+}
+
+func (spec *AlethGenesisSpec) GetGenesisGasLimit() uint64 {
+	return uint64(spec.Genesis.GasLimit)
+}
+
+func (spec *AlethGenesisSpec) SetGenesisGasLimit(u uint64) error {
+	spec.Genesis.GasLimit = hexutil.Uint64(u)
+	return nil
+}
+
+// ForEachAccount iterates the genesis accounts, skipping accounts which only define
+// a precompiled contract.
+func (spec *AlethGenesisSpec) ForEachAccount(fn func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error) error {
+	for k, v := range spec.Accounts {
+		bal := (*big.Int)(v.Balance)
+		if v.Precompiled != nil && (bal == nil || bal.Sign() == 0) {
+			continue
+		}
+		if err := fn(common.Address(k), bal, v.Nonce, v.Code, v.Storage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (spec *AlethGenesisSpec) UpdateAccount(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error {
+	if spec.Accounts == nil {
+		spec.Accounts = make(map[common.UnprefixedAddress]*AlethGenesisSpecAccount)
+	}
+	a, ok := spec.Accounts[common.UnprefixedAddress(address)]
+	if !ok {
+		a = &AlethGenesisSpecAccount{}
+		spec.Accounts[common.UnprefixedAddress(address)] = a
+	}
+	a.Balance = (*math.HexOrDecimal256)(bal)
+	a.Nonce = nonce
+	a.Code = code
+	a.Storage = storage
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package besu
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// BesuChainConfig is the 'config' object of the genesis file format used by Hyperledger Besu.
+// Besu genesis files are otherwise like go-ethereum's.
+type BesuChainConfig struct {
+	NetworkID uint64   `json:"-"`
+	ChainID   *big.Int `json:"chainId"`
+
+	HomesteadBlock         *big.Int    `json:"homesteadBlock,omitempty"`
+	DAOForkBlock           *big.Int    `json:"daoForkBlock,omitempty"` // DAO support is implied by the block
+	EIP150Block            *big.Int    `json:"eip150Block,omitempty"`
+	EIP150Hash             common.Hash `json:"eip150Hash,omitempty"`
+	EIP155Block            *big.Int    `json:"eip155Block,omitempty"`
+	EIP158Block            *big.Int    `json:"eip158Block,omitempty"`
+	ByzantiumBlock         *big.Int    `json:"byzantiumBlock,omitempty"`
+	ConstantinopleBlock    *big.Int    `json:"constantinopleBlock,omitempty"`
+	PetersburgBlock        *big.Int    `json:"petersburgBlock,omitempty"`
+	ConstantinopleFixBlock *big.Int    `json:"constantinopleFixBlock,omitempty"` // Alias of petersburgBlock
+	IstanbulBlock          *big.Int    `json:"istanbulBlock,omitempty"`
+	MuirGlacierBlock       *big.Int    `json:"muirGlacierBlock,omitempty"`
+	BerlinBlock            *big.Int    `json:"berlinBlock,omitempty"`
+	LondonBlock            *big.Int    `json:"londonBlock,omitempty"`
+	ShanghaiTime           *uint64     `json:"shanghaiTime,omitempty"`
+	CancunTime             *uint64     `json:"cancunTime,omitempty"`
+
+	BlobScheduleConfig *ctypes.BlobScheduleConfig `json:"blobSchedule,omitempty"` // EIP-4844 blob parameters by fork
+
+	// Ethereum Classic hard forks.
+	ECIP1015Block     *big.Int `json:"ecip1015Block,omitempty"` // Tangerine Whistle gas repricing
+	DieHardBlock      *big.Int `json:"dieHardBlock,omitempty"`  // EIP155, EIP160, and difficulty bomb pause (ECIP1010)
+	GothamBlock       *big.Int `json:"gothamBlock,omitempty"`   // Monetary policy (ECIP1017), and difficulty bomb continuation
+	ECIP1041Block     *big.Int `json:"ecip1041Block,omitempty"` // Difficulty bomb removal
+	AtlantisBlock     *big.Int `json:"atlantisBlock,omitempty"` // Byzantium and Spurious Dragon, excluding EIP649
+	AghartaBlock      *big.Int `json:"aghartaBlock,omitempty"`  // Constantinople and Petersburg, excluding EIP1234
+	PhoenixBlock      *big.Int `json:"phoenixBlock,omitempty"`  // Istanbul
+	ECIP1017EraRounds *big.Int `json:"ecip1017EraRounds,omitempty"`
+
+	ContractSizeLimit *uint64 `json:"contractSizeLimit,omitempty"`
+
+	// Consensus engines.
+	Ethash *EthashConfig   `json:"ethash,omitempty"`
+	Clique *CliqueConfig   `json:"clique,omitempty"`
+	IBFT2  json.RawMessage `json:"ibft2,omitempty"`
+
+	Discovery *DiscoveryConfig `json:"discovery,omitempty"`
+
+	// Extra holds any other (Besu-only) fields, so that they are
+	// preserved when a configuration is read and written again.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// EthashConfig is Besu's ethash consensus engine configuration.
+type EthashConfig struct {
+	FixedDifficulty *big.Int `json:"fixeddifficulty,omitempty"`
+}
+
+// DiscoveryConfig is Besu's network discovery configuration.
+type DiscoveryConfig struct {
+	Bootnodes []string `json:"bootnodes,omitempty"`
+	DNS       string   `json:"dns,omitempty"`
+}
+
+// CliqueConfig is Besu's clique consensus engine configuration.
+type CliqueConfig struct {
+	BlockPeriodSeconds uint64 `json:"blockperiodseconds"`
+	EpochLength        uint64 `json:"epochlength"`
+}
+
+// knownKeys are the (lowercased) JSON keys of the BesuChainConfig fields.
+// Besu reads configuration keys case-insensitively.
+var knownKeys = ctypes.JSONFieldKeys(reflect.TypeOf(BesuChainConfig{}))
+
+func (c *BesuChainConfig) UnmarshalJSON(input []byte) error {
+	type config BesuChainConfig
+	var dec config
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	extra, err := ctypes.UnmarshalExtraFields(input, knownKeys)
+	if err != nil {
+		return err
+	}
+	dec.Extra = extra
+	*c = BesuChainConfig(dec)
+	return nil
+}
+
+func (c *BesuChainConfig) MarshalJSON() ([]byte, error) {
+	type config BesuChainConfig
+	b, err := json.Marshal((*config)(c))
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.MarshalExtraFields(b, c.Extra)
+}
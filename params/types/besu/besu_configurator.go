@@ -0,0 +1,996 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package besu
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/internal"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// File contains the Besu implementation of the Configurator interface.
+// Transitions are read from either the Ethereum hard fork fields or the Ethereum Classic ones,
+// whichever activates first. Setters write the Ethereum fields, except for
+// transitions which only Ethereum Classic forks define.
+// As with go-ethereum, transitions grouped in a hard fork share a field, so
+// setting them to different values leaves the fork's value undetermined.
+
+func newU64(u uint64) *uint64 {
+	return &u
+}
+
+func bigNewU64(i *big.Int) *uint64 {
+	if i == nil {
+		return nil
+	}
+	return newU64(i.Uint64())
+}
+
+func setBig(i *big.Int, u *uint64) *big.Int {
+	if u == nil {
+		return nil
+	}
+	i = big.NewInt(int64(*u))
+	return i
+}
+
+// earliest returns the lowest of the given (nilable) fork blocks.
+func earliest(blocks ...*big.Int) *uint64 {
+	var min *big.Int
+	for _, b := range blocks {
+		if b != nil && (min == nil || b.Cmp(min) < 0) {
+			min = b
+		}
+	}
+	return bigNewU64(min)
+}
+
+func (c *BesuChainConfig) GetAccountStartNonce() *uint64 {
+	return internal.GlobalConfigurator().GetAccountStartNonce()
+}
+
+func (c *BesuChainConfig) SetAccountStartNonce(n *uint64) error {
+	return internal.GlobalConfigurator().SetAccountStartNonce(n)
+}
+
+func (c *BesuChainConfig) GetMaximumExtraDataSize() *uint64 {
+	return internal.GlobalConfigurator().GetMaximumExtraDataSize()
+}
+
+func (c *BesuChainConfig) SetMaximumExtraDataSize(n *uint64) error {
+	return internal.GlobalConfigurator().SetMaximumExtraDataSize(n)
+}
+
+func (c *BesuChainConfig) GetMinGasLimit() *uint64 {
+	return internal.GlobalConfigurator().GetMinGasLimit()
+}
+
+func (c *BesuChainConfig) SetMinGasLimit(n *uint64) error {
+	return internal.GlobalConfigurator().SetMinGasLimit(n)
+}
+
+func (c *BesuChainConfig) GetGasLimitBoundDivisor() *uint64 {
+	return internal.GlobalConfigurator().GetGasLimitBoundDivisor()
+}
+
+func (c *BesuChainConfig) SetGasLimitBoundDivisor(n *uint64) error {
+	return internal.GlobalConfigurator().SetGasLimitBoundDivisor(n)
+}
+
+// GetNetworkID and SetNetworkID follow the go-ethereum implementation, since
+// Besu genesis files, like go-ethereum's, do not define a network id.
+func (c *BesuChainConfig) GetNetworkID() *uint64 {
+	if c.NetworkID != 0 {
+		return &c.NetworkID
+	}
+	if c.ChainID != nil {
+		return newU64(c.ChainID.Uint64())
+	}
+	return newU64(vars.DefaultNetworkID)
+}
+
+func (c *BesuChainConfig) SetNetworkID(n *uint64) error {
+	if n == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	if c.ChainID == nil {
+		c.ChainID = new(big.Int).SetUint64(*n)
+	}
+	c.NetworkID = *n
+	return nil
+}
+
+func (c *BesuChainConfig) GetChainID() *big.Int {
+	return c.ChainID
+}
+
+func (c *BesuChainConfig) SetChainID(n *big.Int) error {
+	c.ChainID = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetBootnodes() []string {
+	if c.Discovery == nil {
+		return nil
+	}
+	return c.Discovery.Bootnodes
+}
+
+func (c *BesuChainConfig) SetBootnodes(b []string) error {
+	if c.Discovery == nil {
+		if len(b) == 0 {
+			return nil
+		}
+		c.Discovery = &DiscoveryConfig{}
+	}
+	c.Discovery.Bootnodes = b
+	return nil
+}
+
+func (c *BesuChainConfig) GetMaxCodeSize() *uint64 {
+	if c.ContractSizeLimit != nil {
+		return c.ContractSizeLimit
+	}
+	return internal.GlobalConfigurator().GetMaxCodeSize()
+}
+
+func (c *BesuChainConfig) SetMaxCodeSize(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetMaxCodeSize() {
+		n = nil
+	}
+	c.ContractSizeLimit = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP7Transition() *uint64 {
+	return bigNewU64(c.HomesteadBlock)
+}
+
+func (c *BesuChainConfig) SetEIP7Transition(n *uint64) error {
+	c.HomesteadBlock = setBig(c.HomesteadBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP150Transition() *uint64 {
+	return earliest(c.EIP150Block, c.ECIP1015Block)
+}
+
+func (c *BesuChainConfig) SetEIP150Transition(n *uint64) error {
+	c.EIP150Block = setBig(c.EIP150Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP152Transition() *uint64 {
+	return earliest(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP152Transition(n *uint64) error {
+	c.IstanbulBlock = setBig(c.IstanbulBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP160Transition() *uint64 {
+	return earliest(c.EIP158Block, c.DieHardBlock)
+}
+
+func (c *BesuChainConfig) SetEIP160Transition(n *uint64) error {
+	c.EIP158Block = setBig(c.EIP158Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP161abcTransition() *uint64 {
+	return earliest(c.EIP158Block, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP161abcTransition(n *uint64) error {
+	c.EIP158Block = setBig(c.EIP158Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP161dTransition() *uint64 {
+	return earliest(c.EIP158Block, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP161dTransition(n *uint64) error {
+	c.EIP158Block = setBig(c.EIP158Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP170Transition() *uint64 {
+	return earliest(c.EIP158Block, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP170Transition(n *uint64) error {
+	c.EIP158Block = setBig(c.EIP158Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP155Transition() *uint64 {
+	return earliest(c.EIP155Block, c.DieHardBlock)
+}
+
+func (c *BesuChainConfig) SetEIP155Transition(n *uint64) error {
+	c.EIP155Block = setBig(c.EIP155Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP140Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP140Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP198Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP198Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP211Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP211Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP212Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP212Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP213Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP213Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP214Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP214Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP658Transition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEIP658Transition(n *uint64) error {
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP145Transition() *uint64 {
+	return earliest(c.ConstantinopleBlock, c.AghartaBlock)
+}
+
+func (c *BesuChainConfig) SetEIP145Transition(n *uint64) error {
+	c.ConstantinopleBlock = setBig(c.ConstantinopleBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1014Transition() *uint64 {
+	return earliest(c.ConstantinopleBlock, c.AghartaBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1014Transition(n *uint64) error {
+	c.ConstantinopleBlock = setBig(c.ConstantinopleBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1052Transition() *uint64 {
+	return earliest(c.ConstantinopleBlock, c.AghartaBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1052Transition(n *uint64) error {
+	c.ConstantinopleBlock = setBig(c.ConstantinopleBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1283Transition() *uint64 {
+	return bigNewU64(c.ConstantinopleBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1283Transition(n *uint64) error {
+	c.ConstantinopleBlock = setBig(c.ConstantinopleBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1283DisableTransition() *uint64 {
+	return earliest(c.PetersburgBlock, c.ConstantinopleFixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1283DisableTransition(n *uint64) error {
+	c.PetersburgBlock = setBig(c.PetersburgBlock, n)
+	c.ConstantinopleFixBlock = nil
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1108Transition() *uint64 {
+	return earliest(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1108Transition(n *uint64) error {
+	c.IstanbulBlock = setBig(c.IstanbulBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP2200Transition() *uint64 {
+	return earliest(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP2200Transition(n *uint64) error {
+	c.IstanbulBlock = setBig(c.IstanbulBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP2200DisableTransition() *uint64 {
+	return nil
+}
+
+func (c *BesuChainConfig) SetEIP2200DisableTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuChainConfig) GetEIP1344Transition() *uint64 {
+	return earliest(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1344Transition(n *uint64) error {
+	c.IstanbulBlock = setBig(c.IstanbulBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1884Transition() *uint64 {
+	return earliest(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1884Transition(n *uint64) error {
+	c.IstanbulBlock = setBig(c.IstanbulBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP2028Transition() *uint64 {
+	return earliest(c.IstanbulBlock, c.PhoenixBlock)
+}
+
+func (c *BesuChainConfig) SetEIP2028Transition(n *uint64) error {
+	c.IstanbulBlock = setBig(c.IstanbulBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetECIP1080Transition() *uint64 {
+	return nil
+}
+
+func (c *BesuChainConfig) SetECIP1080Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuChainConfig) GetEIP1706Transition() *uint64 {
+	return nil
+}
+
+func (c *BesuChainConfig) SetEIP1706Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuChainConfig) GetEIP2718Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *BesuChainConfig) SetEIP2718Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP2929Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *BesuChainConfig) SetEIP2929Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP2930Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *BesuChainConfig) SetEIP2930Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP2565Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *BesuChainConfig) SetEIP2565Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1559Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *BesuChainConfig) SetEIP1559Transition(n *uint64) error {
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+// Besu does not allow the EIP-1559 parameters to be configured.
+
+func (c *BesuChainConfig) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	return internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator()
+}
+
+func (c *BesuChainConfig) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP1559BaseFeeChangeDenominator(n)
+}
+
+func (c *BesuChainConfig) GetEIP1559ElasticityMultiplier() *uint64 {
+	return internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier()
+}
+
+func (c *BesuChainConfig) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP1559ElasticityMultiplier(n)
+}
+
+func (c *BesuChainConfig) GetEIP3529Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *BesuChainConfig) SetEIP3529Transition(n *uint64) error {
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP3541Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *BesuChainConfig) SetEIP3541Transition(n *uint64) error {
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEWASMTransition() *uint64 {
+	return nil
+}
+
+func (c *BesuChainConfig) SetEWASMTransition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuChainConfig) GetEIP3651TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *BesuChainConfig) SetEIP3651TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP3855TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *BesuChainConfig) SetEIP3855TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP3860TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *BesuChainConfig) SetEIP3860TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP4895TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *BesuChainConfig) SetEIP4895TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP1153TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *BesuChainConfig) SetEIP1153TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP4788TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *BesuChainConfig) SetEIP4788TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP4844TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+// SetEIP4844TransitionTime also writes the (default) Cancun blob parameters, if they are not configured,
+// since a Cancun configuration must have them.
+func (c *BesuChainConfig) SetEIP4844TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	if n != nil {
+		c.cancunBlobConfig()
+	}
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP5656TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *BesuChainConfig) SetEIP5656TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP6780TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *BesuChainConfig) SetEIP6780TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP7516TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *BesuChainConfig) SetEIP7516TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+// hasCancunBlobConfig returns true if the Cancun blob parameters are configured.
+func (c *BesuChainConfig) hasCancunBlobConfig() bool {
+	return c.BlobScheduleConfig != nil && c.BlobScheduleConfig.Cancun != nil
+}
+
+// cancunBlobConfig returns the Cancun blob parameters, adding them (with the default values)
+// if they are not configured.
+func (c *BesuChainConfig) cancunBlobConfig() *ctypes.BlobConfig {
+	if c.BlobScheduleConfig == nil {
+		c.BlobScheduleConfig = &ctypes.BlobScheduleConfig{}
+	}
+	if c.BlobScheduleConfig.Cancun == nil {
+		c.BlobScheduleConfig.Cancun = &ctypes.BlobConfig{
+			Target:         *internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock(),
+			Max:            *internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock(),
+			UpdateFraction: *internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction(),
+		}
+	}
+	return c.BlobScheduleConfig.Cancun
+}
+
+func (c *BesuChainConfig) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	if c.hasCancunBlobConfig() {
+		n := c.BlobScheduleConfig.Cancun.Target
+		return &n
+	}
+	return internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock()
+}
+
+func (c *BesuChainConfig) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	if n == nil || !c.hasCancunBlobConfig() && *n == *internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock() {
+		return nil
+	}
+	c.cancunBlobConfig().Target = *n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	if c.hasCancunBlobConfig() {
+		n := c.BlobScheduleConfig.Cancun.Max
+		return &n
+	}
+	return internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock()
+}
+
+func (c *BesuChainConfig) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	if n == nil || !c.hasCancunBlobConfig() && *n == *internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock() {
+		return nil
+	}
+	c.cancunBlobConfig().Max = *n
+	return nil
+}
+
+func (c *BesuChainConfig) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	if c.hasCancunBlobConfig() {
+		n := c.BlobScheduleConfig.Cancun.UpdateFraction
+		return &n
+	}
+	return internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction()
+}
+
+func (c *BesuChainConfig) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	if n == nil || !c.hasCancunBlobConfig() && *n == *internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction() {
+		return nil
+	}
+	c.cancunBlobConfig().UpdateFraction = *n
+	return nil
+}
+
+func (c *BesuChainConfig) IsForked(fn func() *uint64, n *big.Int) bool {
+	f := fn()
+	if f == nil || n == nil {
+		return false
+	}
+	return big.NewInt(int64(*f)).Cmp(n) <= 0
+}
+
+func (c *BesuChainConfig) GetForkCanonHash(n uint64) common.Hash {
+	if c.EIP150Block != nil && c.EIP150Block.Uint64() == n {
+		return c.EIP150Hash
+	}
+	return common.Hash{}
+}
+
+func (c *BesuChainConfig) SetForkCanonHash(n uint64, h common.Hash) error {
+	if c.EIP150Block != nil && c.EIP150Block.Uint64() == n {
+		c.EIP150Hash = h
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (c *BesuChainConfig) GetForkCanonHashes() map[uint64]common.Hash {
+	if c.EIP150Block == nil || c.EIP150Hash == (common.Hash{}) {
+		return nil
+	}
+	return map[uint64]common.Hash{
+		c.EIP150Block.Uint64(): c.EIP150Hash,
+	}
+}
+
+// GetConsensusEngineType returns the unknown engine type for IBFT 2.0 configurations,
+// which have no equivalent in other formats.
+func (c *BesuChainConfig) GetConsensusEngineType() ctypes.ConsensusEngineT {
+	switch {
+	case c.Clique != nil:
+		return ctypes.ConsensusEngineT_Clique
+	case len(c.IBFT2) > 0:
+		return ctypes.ConsensusEngineT_Unknown
+	}
+	return ctypes.ConsensusEngineT_Ethash
+}
+
+func (c *BesuChainConfig) MustSetConsensusEngineType(t ctypes.ConsensusEngineT) error {
+	switch t {
+	case ctypes.ConsensusEngineT_Ethash:
+		c.Ethash = new(EthashConfig)
+		c.Clique = nil
+		c.IBFT2 = nil
+		return nil
+	case ctypes.ConsensusEngineT_Clique:
+		c.Clique = new(CliqueConfig)
+		c.Ethash = nil
+		c.IBFT2 = nil
+		return nil
+	default:
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+}
+
+func (c *BesuChainConfig) GetEthashMinimumDifficulty() *big.Int {
+	return internal.GlobalConfigurator().GetEthashMinimumDifficulty()
+}
+
+func (c *BesuChainConfig) SetEthashMinimumDifficulty(i *big.Int) error {
+	return internal.GlobalConfigurator().SetEthashMinimumDifficulty(i)
+}
+
+func (c *BesuChainConfig) GetEthashDifficultyBoundDivisor() *big.Int {
+	return internal.GlobalConfigurator().GetEthashDifficultyBoundDivisor()
+}
+
+func (c *BesuChainConfig) SetEthashDifficultyBoundDivisor(i *big.Int) error {
+	return internal.GlobalConfigurator().SetEthashDifficultyBoundDivisor(i)
+}
+
+func (c *BesuChainConfig) GetEthashDurationLimit() *big.Int {
+	return internal.GlobalConfigurator().GetEthashDurationLimit()
+}
+
+func (c *BesuChainConfig) SetEthashDurationLimit(i *big.Int) error {
+	return internal.GlobalConfigurator().SetEthashDurationLimit(i)
+}
+
+func (c *BesuChainConfig) GetEthashHomesteadTransition() *uint64 {
+	return bigNewU64(c.HomesteadBlock)
+}
+
+func (c *BesuChainConfig) SetEthashHomesteadTransition(n *uint64) error {
+	c.HomesteadBlock = setBig(c.HomesteadBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashEIP2Transition() *uint64 {
+	return bigNewU64(c.HomesteadBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP2Transition(n *uint64) error {
+	c.HomesteadBlock = setBig(c.HomesteadBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashEIP779Transition() *uint64 {
+	return bigNewU64(c.DAOForkBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP779Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.DAOForkBlock = setBig(c.DAOForkBlock, n)
+	return nil
+}
+
+// The DAO hard fork refund is that of the Ethereum mainnet DAO fork.
+
+func (c *BesuChainConfig) GetEthashDAOForkBeneficiary() *common.Address {
+	return internal.DAOForkBeneficiary(c.GetEthashEIP779Transition())
+}
+
+func (c *BesuChainConfig) SetEthashDAOForkBeneficiary(a *common.Address) error {
+	return internal.SetDAOForkBeneficiary(a)
+}
+
+func (c *BesuChainConfig) GetEthashDAOForkAccounts() []common.Address {
+	return internal.DAOForkAccounts(c.GetEthashEIP779Transition())
+}
+
+func (c *BesuChainConfig) SetEthashDAOForkAccounts(addrs []common.Address) error {
+	return internal.SetDAOForkAccounts(addrs)
+}
+
+func (c *BesuChainConfig) GetEthashEIP649Transition() *uint64 {
+	return bigNewU64(c.ByzantiumBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP649Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashEIP1234Transition() *uint64 {
+	return bigNewU64(c.ConstantinopleBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP1234Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ConstantinopleBlock = setBig(c.ConstantinopleBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashEIP2384Transition() *uint64 {
+	return bigNewU64(c.MuirGlacierBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP2384Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.MuirGlacierBlock = setBig(c.MuirGlacierBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashEIP3554Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP3554Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashECIP1010PauseTransition() *uint64 {
+	return bigNewU64(c.DieHardBlock)
+}
+
+func (c *BesuChainConfig) SetEthashECIP1010PauseTransition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.DieHardBlock = setBig(c.DieHardBlock, n)
+	return nil
+}
+
+// The difficulty bomb pause (ECIP1010) ends at Gotham.
+func (c *BesuChainConfig) GetEthashECIP1010ContinueTransition() *uint64 {
+	return bigNewU64(c.GothamBlock)
+}
+
+func (c *BesuChainConfig) SetEthashECIP1010ContinueTransition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.GothamBlock = setBig(c.GothamBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashECIP1017Transition() *uint64 {
+	return bigNewU64(c.GothamBlock)
+}
+
+func (c *BesuChainConfig) SetEthashECIP1017Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.GothamBlock = setBig(c.GothamBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashECIP1017EraRounds() *uint64 {
+	return bigNewU64(c.ECIP1017EraRounds)
+}
+
+func (c *BesuChainConfig) SetEthashECIP1017EraRounds(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ECIP1017EraRounds = setBig(c.ECIP1017EraRounds, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashEIP100BTransition() *uint64 {
+	return earliest(c.ByzantiumBlock, c.AtlantisBlock)
+}
+
+func (c *BesuChainConfig) SetEthashEIP100BTransition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ByzantiumBlock = setBig(c.ByzantiumBlock, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashECIP1041Transition() *uint64 {
+	return bigNewU64(c.ECIP1041Block)
+}
+
+func (c *BesuChainConfig) SetEthashECIP1041Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.ECIP1041Block = setBig(c.ECIP1041Block, n)
+	return nil
+}
+
+func (c *BesuChainConfig) GetEthashDifficultyBombDelaySchedule() ctypes.Uint64BigMapEncodesHex {
+	return nil
+}
+
+func (c *BesuChainConfig) SetEthashDifficultyBombDelaySchedule(m ctypes.Uint64BigMapEncodesHex) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (c *BesuChainConfig) GetEthashBlockRewardSchedule() ctypes.Uint64BigMapEncodesHex {
+	return nil
+}
+
+func (c *BesuChainConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesHex) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
+func (c *BesuChainConfig) GetEthashNoProof() bool {
+	return false
+}
+
+func (c *BesuChainConfig) SetEthashNoProof(b bool) error {
+	if !b {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *BesuChainConfig) GetCliquePeriod() uint64 {
+	if c.Clique == nil {
+		return 0
+	}
+	return c.Clique.BlockPeriodSeconds
+}
+
+func (c *BesuChainConfig) SetCliquePeriod(n uint64) error {
+	if c.Clique == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Clique.BlockPeriodSeconds = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetCliqueEpoch() uint64 {
+	if c.Clique == nil {
+		return 0
+	}
+	return c.Clique.EpochLength
+}
+
+func (c *BesuChainConfig) SetCliqueEpoch(n uint64) error {
+	if c.Clique == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.Clique.EpochLength = n
+	return nil
+}
+
+func (c *BesuChainConfig) GetExtraFields() map[string]json.RawMessage {
+	return c.Extra
+}
+
+func (c *BesuChainConfig) SetExtraFields(m map[string]json.RawMessage) error {
+	c.Extra = m
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package besu
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnknownFieldsPreserved(t *testing.T) {
+	input := `{"chainId":2018,"homesteadBlock":0,"evmStackSize":2048,"ibft2":{"blockperiodseconds":2,"epochlength":30000},"discovery":{"bootnodes":["enode://x"]}}`
+	var c BesuChainConfig
+	if err := json.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Extra) != 1 {
+		t.Errorf("got %d extra fields, want 1: %v", len(c.Extra), c.Extra)
+	}
+	if got := c.GetBootnodes(); len(got) != 1 || got[0] != "enode://x" {
+		t.Errorf("got bootnodes %v, want [enode://x]", got)
+	}
+	if c.GetConsensusEngineType().IsEthash() {
+		t.Error("ibft2 configuration read as ethash")
+	}
+	b, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want, got map[string]interface{}
+	json.Unmarshal([]byte(input), &want)
+	json.Unmarshal(b, &got)
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			t.Errorf("field %s not preserved: %s", k, b)
+		}
+	}
+}
+
+func TestClassicForkFields(t *testing.T) {
+	input := `{"chainId":61,"homesteadBlock":1150000,"eip150Block":2500000,"dieHardBlock":3000000,"gothamBlock":5000000,"ecip1041Block":5900000,"atlantisBlock":8772000,"ecip1017EraRounds":5000000,"ethash":{}}`
+	var c BesuChainConfig
+	if err := json.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		got  *uint64
+		want uint64
+	}{
+		"EIP155":        {c.GetEIP155Transition(), 3000000},
+		"EIP160":        {c.GetEIP160Transition(), 3000000},
+		"EIP161abc":     {c.GetEIP161abcTransition(), 8772000},
+		"EIP140":        {c.GetEIP140Transition(), 8772000},
+		"ECIP1010Pause": {c.GetEthashECIP1010PauseTransition(), 3000000},
+		"ECIP1017":      {c.GetEthashECIP1017Transition(), 5000000},
+		"ECIP1017Eras":  {c.GetEthashECIP1017EraRounds(), 5000000},
+		"ECIP1041":      {c.GetEthashECIP1041Transition(), 5900000},
+	} {
+		if tc.got == nil || *tc.got != tc.want {
+			t.Errorf("%s: got %v, want %d", name, tc.got, tc.want)
+		}
+	}
+	if c.GetEthashEIP649Transition() != nil {
+		t.Error("EIP649 should not be activated by Atlantis")
+	}
+}
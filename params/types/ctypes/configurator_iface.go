@@ -62,6 +62,8 @@ type CatHerder interface {
 	SetNetworkID(n *uint64) error
 	GetChainID() *big.Int
 	SetChainID(i *big.Int) error
+	GetBootnodes() []string
+	SetBootnodes(b []string) error
 	GetMaxCodeSize() *uint64
 	SetMaxCodeSize(n *uint64) error
 	GetEIP7Transition() *uint64
@@ -120,6 +122,59 @@ type CatHerder interface {
 	SetECIP1080Transition(n *uint64) error
 	GetEIP1706Transition() *uint64
 	SetEIP1706Transition(n *uint64) error
+	GetEIP2718Transition() *uint64
+	SetEIP2718Transition(n *uint64) error
+	GetEIP2929Transition() *uint64
+	SetEIP2929Transition(n *uint64) error
+	GetEIP2930Transition() *uint64
+	SetEIP2930Transition(n *uint64) error
+	GetEIP2565Transition() *uint64
+	SetEIP2565Transition(n *uint64) error
+	GetEIP1559Transition() *uint64
+	SetEIP1559Transition(n *uint64) error
+	GetEIP1559BaseFeeChangeDenominator() *uint64
+	SetEIP1559BaseFeeChangeDenominator(n *uint64) error
+	GetEIP1559ElasticityMultiplier() *uint64
+	SetEIP1559ElasticityMultiplier(n *uint64) error
+	GetEIP3529Transition() *uint64
+	SetEIP3529Transition(n *uint64) error
+	GetEIP3541Transition() *uint64
+	SetEIP3541Transition(n *uint64) error
+
+	// EWASM is the experimental eWASM (WebAssembly) execution engine, enabled on some test chains.
+	GetEWASMTransition() *uint64
+	SetEWASMTransition(n *uint64) error
+
+	// Shanghai EIPs are activated by block timestamp, rather than block number.
+	GetEIP3651TransitionTime() *uint64
+	SetEIP3651TransitionTime(n *uint64) error
+	GetEIP3855TransitionTime() *uint64
+	SetEIP3855TransitionTime(n *uint64) error
+	GetEIP3860TransitionTime() *uint64
+	SetEIP3860TransitionTime(n *uint64) error
+	GetEIP4895TransitionTime() *uint64
+	SetEIP4895TransitionTime(n *uint64) error
+
+	// Cancun EIPs are activated by block timestamp.
+	// EIP-4844 blob transactions have parameters which some formats allow to be configured.
+	GetEIP1153TransitionTime() *uint64
+	SetEIP1153TransitionTime(n *uint64) error
+	GetEIP4788TransitionTime() *uint64
+	SetEIP4788TransitionTime(n *uint64) error
+	GetEIP4844TransitionTime() *uint64
+	SetEIP4844TransitionTime(n *uint64) error
+	GetEIP5656TransitionTime() *uint64
+	SetEIP5656TransitionTime(n *uint64) error
+	GetEIP6780TransitionTime() *uint64
+	SetEIP6780TransitionTime(n *uint64) error
+	GetEIP7516TransitionTime() *uint64
+	SetEIP7516TransitionTime(n *uint64) error
+	GetEIP4844TargetBlobsPerBlock() *uint64
+	SetEIP4844TargetBlobsPerBlock(n *uint64) error
+	GetEIP4844MaxBlobsPerBlock() *uint64
+	SetEIP4844MaxBlobsPerBlock(n *uint64) error
+	GetEIP4844BlobBaseFeeUpdateFraction() *uint64
+	SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error
 }
 
 type Forker interface {
@@ -159,12 +214,22 @@ type EthashConfigurator interface {
 
 	// SetEthashEIP779Transition should turn DAO support on (nonnil) or off (nil).
 	SetEthashEIP779Transition(n *uint64) error
+
+	// GetEthashDAOForkBeneficiary and GetEthashDAOForkAccounts return the refund contract
+	// and the drained accounts of the DAO hard fork, or nil if the node does not want the fork.
+	// Formats which cannot configure them return those of the Ethereum mainnet DAO fork.
+	GetEthashDAOForkBeneficiary() *common.Address
+	SetEthashDAOForkBeneficiary(a *common.Address) error
+	GetEthashDAOForkAccounts() []common.Address
+	SetEthashDAOForkAccounts(addrs []common.Address) error
 	GetEthashEIP649Transition() *uint64
 	SetEthashEIP649Transition(n *uint64) error
 	GetEthashEIP1234Transition() *uint64
 	SetEthashEIP1234Transition(n *uint64) error
 	GetEthashEIP2384Transition() *uint64
 	SetEthashEIP2384Transition(n *uint64) error
+	GetEthashEIP3554Transition() *uint64
+	SetEthashEIP3554Transition(n *uint64) error
 	GetEthashECIP1010PauseTransition() *uint64
 	SetEthashECIP1010PauseTransition(n *uint64) error
 	GetEthashECIP1010ContinueTransition() *uint64
@@ -182,6 +247,12 @@ type EthashConfigurator interface {
 	SetEthashDifficultyBombDelaySchedule(m Uint64BigMapEncodesHex) error
 	GetEthashBlockRewardSchedule() Uint64BigMapEncodesHex
 	SetEthashBlockRewardSchedule(m Uint64BigMapEncodesHex) error
+
+	// GetEthashNoProof returns true if ethash rules apply, but the proof of work of blocks is not verified,
+	// as for test chains (eg. Aleth's NoProof seal engine).
+	// Formats which cannot configure it should return false, and refuse to set it.
+	GetEthashNoProof() bool
+	SetEthashNoProof(b bool) error
 }
 
 type CliqueConfigurator interface {
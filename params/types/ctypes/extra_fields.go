@@ -0,0 +1,85 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+package ctypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ExtraFieldsConfigurator is implemented by chain configuration data types which preserve
+// the fields they do not know (eg. those of a newer client version), so that they survive
+// a round trip through the same format.
+// Extra fields are not converted to other formats.
+type ExtraFieldsConfigurator interface {
+	GetExtraFields() map[string]json.RawMessage
+	SetExtraFields(m map[string]json.RawMessage) error
+}
+
+// JSONFieldKeys returns the (lowercased) JSON keys of a struct type's fields.
+// Keys are lowercased since encoding/json matches them case-insensitively.
+func JSONFieldKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		keys[strings.ToLower(name)] = true
+	}
+	return keys
+}
+
+// UnmarshalExtraFields returns the fields of a JSON object whose keys are not known,
+// or nil if there are none.
+func UnmarshalExtraFields(input []byte, known map[string]bool) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(input, &all); err != nil {
+		return nil, err
+	}
+	for k := range all {
+		if known[strings.ToLower(k)] {
+			delete(all, k)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// MarshalExtraFields adds extra fields to a marshaled JSON object.
+// Fields of the object take precedence over extra fields of the same key.
+func MarshalExtraFields(b []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return b, nil
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		if _, ok := all[k]; !ok {
+			all[k] = v
+		}
+	}
+	return json.Marshal(all)
+}
@@ -17,6 +17,7 @@
 package ctypes
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -24,6 +25,7 @@ import (
 	"math/big"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -62,6 +64,45 @@ func UnsupportedConfigError(err error, method string, value interface{}) ErrUnsu
 	}
 }
 
+// ParityNeverTransition is the transition value conventionally used by Parity chain specs
+// for a transition which never occurs. Specs may also use greater values to the same effect.
+const ParityNeverTransition = 0x7fffffffffffff
+
+// ErrDuplicateAccount is returned for genesis accounts which have more than one entry for the same address,
+// eg. keys differing only in case or 0x prefix.
+var ErrDuplicateAccount = errors.New("duplicate genesis account")
+
+// CheckAccountKeys returns an error wrapping ErrDuplicateAccount if the JSON object of accounts by address
+// has more than one key for the same address. Addresses are compared in their canonical lowercase
+// 0x-prefixed form. Keys which are not addresses, and values which are not objects, are ignored.
+func CheckAccountKeys(input []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(input))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+	seen := make(map[string]string)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		key, _ := t.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil
+		}
+		if !common.IsHexAddress(key) {
+			continue
+		}
+		addr := strings.ToLower(common.HexToAddress(key).Hex())
+		if prev, ok := seen[addr]; ok {
+			return fmt.Errorf("%w: %s (as %q and %q)", ErrDuplicateAccount, addr, prev, key)
+		}
+		seen[addr] = key
+	}
+	return nil
+}
+
 // Uint64BigValOrMapHex is an encoding type for Parity's chain config,
 // used for their 'blockReward' field.
 // When only an initial value, eg 0:0x42 is set, the type is a hex-encoded string.
@@ -70,6 +111,9 @@ type Uint64BigValOrMapHex map[uint64]*big.Int
 
 // UnmarshalJSON implements the json Unmarshaler interface.
 func (m *Uint64BigValOrMapHex) UnmarshalJSON(input []byte) error {
+	if string(input) == "null" {
+		return nil
+	}
 	mm := make(map[math.HexOrDecimal64]math.HexOrDecimal256)
 	err := json.Unmarshal(input, &mm)
 	if err == nil {
@@ -97,6 +141,9 @@ func (m *Uint64BigValOrMapHex) UnmarshalJSON(input []byte) error {
 
 // MarshalJSON implements the json Marshaler interface.
 func (m Uint64BigValOrMapHex) MarshalJSON() (output []byte, err error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
 	mm := make(map[math.HexOrDecimal64]*math.HexOrDecimal256)
 	for k, v := range m {
 		if v == nil {
@@ -137,11 +184,11 @@ func (bb *Uint64BigMapEncodesHex) UnmarshalJSON(input []byte) error {
 		case float64:
 			i, err := strconv.ParseUint(fmt.Sprintf("%.0f", v), 10, 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			vv = big.NewInt(int64(i))
 		default:
-			panic(fmt.Sprintf("unknown type: %t %v", v, v))
+			return fmt.Errorf("invalid value for block %d: %v", k, v)
 		}
 		if vv != nil {
 			b[uint64(k)] = vv
@@ -164,6 +211,11 @@ func (b Uint64BigMapEncodesHex) MarshalJSON() ([]byte, error) {
 	return json.Marshal(mm)
 }
 
+// SetValueTotalForHeight sets the values of the map so that their sum
+// at height n (ie. the cumulative difficulty bomb delay) is at least val.
+// Totals never decrease with height, as each delay fork (eg. EIP649, EIP1234, EIP2384)
+// replaces the total delay with a greater one. So a total which would be
+// less than that of an earlier height is subsumed by it, and its value is dropped.
 func (b Uint64BigMapEncodesHex) SetValueTotalForHeight(n *uint64, val *big.Int) {
 	if n == nil || val == nil {
 		return
@@ -191,6 +243,10 @@ func (b Uint64BigMapEncodesHex) SetValueTotalForHeight(n *uint64, val *big.Int)
 	})
 	for _, s := range sl {
 		d := new(big.Int).Sub(sums[s], sumR)
+		if d.Sign() <= 0 {
+			delete(b, s)
+			continue
+		}
 		b[s] = d
 		sumR.Add(sumR, d)
 	}
@@ -219,7 +275,8 @@ func (b Uint64BigMapEncodesHex) SumValues(n *uint64) uint64 {
 	return sumB.Uint64()
 }
 
-// MapMeetsSpecification returns the block number at which a difficulty/+reward map meet specifications, eg. EIP649 and/or EIP1234, or EIP2384.
+// MapMeetsSpecification returns the block number at which a difficulty/+reward map meet specifications, eg. EIP649 and/or EIP1234, or EIP2384,
+// ie. the first block at which the cumulative delay is at least difficultySum, and the block reward in effect is at most wantedReward.
 // This is a reverse lookup to extract EIP-spec'd parameters from difficulty and reward maps implementations.
 func MapMeetsSpecification(difficulties Uint64BigMapEncodesHex, rewards Uint64BigMapEncodesHex, difficultySum, wantedReward *big.Int) *uint64 {
 	var diffN *uint64
@@ -240,7 +297,9 @@ func MapMeetsSpecification(difficulties Uint64BigMapEncodesHex, rewards Uint64Bi
 			panic(fmt.Sprintf("dnil difficulties: %v, sl: %v", difficulties, sl))
 		}
 		total.Add(total, d)
-		if total.Cmp(difficultySum) == 0 {
+		// Delay forks may coincide (eg. EIP649 and EIP1234 at the same block),
+		// in which case only the greater total is configured.
+		if total.Cmp(difficultySum) >= 0 {
 			diffN = &s
 			break
 		}
@@ -255,11 +314,15 @@ func MapMeetsSpecification(difficulties Uint64BigMapEncodesHex, rewards Uint64Bi
 		return diffN
 	}
 
-	reward, ok := rewards[*diffN]
-	if !ok {
-		return nil
+	// Likewise, the reward in effect may have been reduced further by a coinciding fork.
+	var reward *big.Int
+	var rewardN uint64
+	for k, r := range rewards {
+		if k <= *diffN && (reward == nil || k >= rewardN) {
+			reward, rewardN = r, k
+		}
 	}
-	if reward.Cmp(wantedReward) != 0 {
+	if reward == nil || reward.Cmp(wantedReward) > 0 {
 		return nil
 	}
 
@@ -373,3 +436,16 @@ type CliqueConfig struct {
 func (c *CliqueConfig) String() string {
 	return "clique"
 }
+
+// BlobScheduleConfig is the EIP-4844 blob parameters of each fork which sets them,
+// as configured by go-ethereum and Besu ('blobSchedule').
+type BlobScheduleConfig struct {
+	Cancun *BlobConfig `json:"cancun,omitempty"`
+}
+
+// BlobConfig is the EIP-4844 blob parameters of a fork.
+type BlobConfig struct {
+	Target         uint64 `json:"target"`                // Target number of blobs per block
+	Max            uint64 `json:"max"`                   // Maximum number of blobs per block
+	UpdateFraction uint64 `json:"baseFeeUpdateFraction"` // Blob base fee update fraction
+}
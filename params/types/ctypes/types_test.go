@@ -246,4 +246,37 @@ func TestUint64BigMapEncodesHex_SetValueTotalForHeight(t *testing.T) {
 	mgTestlike.SetValueTotalForHeight(&zero, vars.EIP649DifficultyBombDelay)
 	mgTestlike.SetValueTotalForHeight(&five, vars.EIP1234DifficultyBombDelay)
 	check(mgTestlike, mgTestlike.SumValues(&zero), vars.EIP649DifficultyBombDelay.Uint64())
+
+	// Test a later fork with a lesser total, which is subsumed by the earlier.
+	mgSubsumed := newMG()
+	ten := uint64(10)
+	mgSubsumed.SetValueTotalForHeight(&five, vars.EIP2384DifficultyBombDelay)
+	mgSubsumed.SetValueTotalForHeight(&ten, vars.EIP1234DifficultyBombDelay)
+	check(mgSubsumed, mgSubsumed.SumValues(&max), vars.EIP2384DifficultyBombDelay.Uint64())
+	if _, ok := mgSubsumed[ten]; ok {
+		t.Errorf("got value at subsumed height %d: %v", ten, mgSubsumed)
+	}
+}
+
+func TestMapMeetsSpecification_Coinciding(t *testing.T) {
+	zero, five := uint64(0), uint64(5)
+	delays := Uint64BigMapEncodesHex{}
+	delays.SetValueTotalForHeight(&zero, vars.EIP1234DifficultyBombDelay)
+	delays.SetValueTotalForHeight(&zero, vars.EIP649DifficultyBombDelay)
+	delays.SetValueTotalForHeight(&five, vars.EIP2384DifficultyBombDelay)
+	rewards := Uint64BigMapEncodesHex{zero: vars.EIP1234FBlockReward}
+
+	for _, c := range []struct {
+		sum, reward *big.Int
+		want        uint64
+	}{
+		{vars.EIP649DifficultyBombDelay, vars.EIP649FBlockReward, zero},
+		{vars.EIP1234DifficultyBombDelay, vars.EIP1234FBlockReward, zero},
+		{vars.EIP2384DifficultyBombDelay, nil, five},
+	} {
+		got := MapMeetsSpecification(delays, rewards, c.sum, c.reward)
+		if got == nil || *got != c.want {
+			t.Errorf("delay %v: got %v, want %d", c.sum, got, c.want)
+		}
+	}
 }
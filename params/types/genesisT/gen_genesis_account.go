@@ -35,13 +35,16 @@ var _ = (*genesisAccountMarshaling)(nil)
 func (g GenesisAccount) MarshalJSON() ([]byte, error) {
 	type GenesisAccount struct {
 		Code       hexutil.Bytes               `json:"code,omitempty"`
+		CodeHash   *common.Hash                `json:"codeHash,omitempty"`
 		Storage    map[storageJSON]storageJSON `json:"storage,omitempty"`
 		Balance    *math.HexOrDecimal256       `json:"balance" gencodec:"required"`
 		Nonce      math.HexOrDecimal64         `json:"nonce,omitempty"`
 		PrivateKey hexutil.Bytes               `json:"secretKey,omitempty"`
+		Builtin    json.RawMessage             `json:"builtin,omitempty"`
 	}
 	var enc GenesisAccount
 	enc.Code = g.Code
+	enc.CodeHash = g.CodeHash
 	if g.Storage != nil {
 		enc.Storage = make(map[storageJSON]storageJSON, len(g.Storage))
 		for k, v := range g.Storage {
@@ -51,6 +54,7 @@ func (g GenesisAccount) MarshalJSON() ([]byte, error) {
 	enc.Balance = (*math.HexOrDecimal256)(g.Balance)
 	enc.Nonce = math.HexOrDecimal64(g.Nonce)
 	enc.PrivateKey = g.PrivateKey
+	enc.Builtin = g.Builtin
 	return json.Marshal(&enc)
 }
 
@@ -58,10 +62,12 @@ func (g GenesisAccount) MarshalJSON() ([]byte, error) {
 func (g *GenesisAccount) UnmarshalJSON(input []byte) error {
 	type GenesisAccount struct {
 		Code       *hexutil.Bytes              `json:"code,omitempty"`
+		CodeHash   *common.Hash                `json:"codeHash,omitempty"`
 		Storage    map[storageJSON]storageJSON `json:"storage,omitempty"`
 		Balance    *math.HexOrDecimal256       `json:"balance" gencodec:"required"`
 		Nonce      *math.HexOrDecimal64        `json:"nonce,omitempty"`
 		PrivateKey *hexutil.Bytes              `json:"secretKey,omitempty"`
+		Builtin    json.RawMessage             `json:"builtin,omitempty"`
 	}
 	var dec GenesisAccount
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -70,6 +76,9 @@ func (g *GenesisAccount) UnmarshalJSON(input []byte) error {
 	if dec.Code != nil {
 		g.Code = *dec.Code
 	}
+	if dec.CodeHash != nil {
+		g.CodeHash = dec.CodeHash
+	}
 	if dec.Storage != nil {
 		g.Storage = make(map[common.Hash]common.Hash, len(dec.Storage))
 		for k, v := range dec.Storage {
@@ -86,5 +95,8 @@ func (g *GenesisAccount) UnmarshalJSON(input []byte) error {
 	if dec.PrivateKey != nil {
 		g.PrivateKey = *dec.PrivateKey
 	}
+	if dec.Builtin != nil {
+		g.Builtin = dec.Builtin
+	}
 	return nil
 }
@@ -54,8 +54,13 @@ type Genesis struct {
 	ParentHash common.Hash `json:"parentHash"`
 }
 
+// ForEachAccount calls fn for each genesis account, except those which only declare a builtin;
+// see GetAccountBuiltins.
 func (g *Genesis) ForEachAccount(fn func(address common.Address, bal *big.Int, nonce uint64, code []byte, storage map[common.Hash]common.Hash) error) error {
 	for k, v := range g.Alloc {
+		if v.BuiltinOnly() {
+			continue
+		}
 		if err := fn(k, v.Balance, v.Nonce, v.Code, v.Storage); err != nil {
 			return err
 		}
@@ -82,11 +87,58 @@ func (g *Genesis) UpdateAccount(address common.Address, bal *big.Int, nonce uint
 	return nil
 }
 
+// GetAccountCodeHashes returns the code hashes declared by genesis accounts, by address.
+func (g *Genesis) GetAccountCodeHashes() map[common.Address]common.Hash {
+	hashes := make(map[common.Address]common.Hash)
+	for k, v := range g.Alloc {
+		if v.CodeHash != nil {
+			hashes[k] = *v.CodeHash
+		}
+	}
+	return hashes
+}
+
+// accountBuiltinKeeper is implemented by chain configurations whose format keeps the builtins of
+// genesis accounts (multigeth). Other formats read by Genesis (eg. go-ethereum) would make an
+// account which only declares a builtin part of the genesis state, so do not keep them.
+type accountBuiltinKeeper interface {
+	KeepsAccountBuiltins() bool
+}
+
+// GetAccountBuiltins returns the builtins declared by genesis accounts, by address.
+func (g *Genesis) GetAccountBuiltins() map[common.Address]json.RawMessage {
+	builtins := make(map[common.Address]json.RawMessage)
+	for k, v := range g.Alloc {
+		if v.Builtin != nil {
+			builtins[k] = v.Builtin
+		}
+	}
+	return builtins
+}
+
+// SetAccountBuiltin sets the builtin declared by a genesis account, adding the account if there is none.
+// Configurations whose format does not keep builtins cannot set them.
+func (g *Genesis) SetAccountBuiltin(address common.Address, builtin json.RawMessage) error {
+	if k, ok := g.Config.(accountBuiltinKeeper); !ok || !k.KeepsAccountBuiltins() {
+		return ctypes.ErrUnsupportedConfigNoop
+	}
+	if g.Alloc == nil {
+		g.Alloc = GenesisAlloc{}
+	}
+	acc, ok := g.Alloc[address]
+	if !ok {
+		acc.Balance = new(big.Int)
+	}
+	acc.Builtin = builtin
+	g.Alloc[address] = acc
+	return nil
+}
+
 // GenesisAlloc specifies the initial state that is part of the genesis block.
 type GenesisAlloc map[common.Address]GenesisAccount
 
 func (ga *GenesisAlloc) UnmarshalJSON(data []byte) error {
-	m := make(map[common.UnprefixedAddress]GenesisAccount)
+	var m genesisAllocJSON
 	if err := json.Unmarshal(data, &m); err != nil {
 		return err
 	}
@@ -97,13 +149,42 @@ func (ga *GenesisAlloc) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// genesisAllocJSON is the JSON encoding of GenesisAlloc, keyed by unprefixed address.
+// Keys of the same address (eg. differing in case or 0x prefix) would be merged by decoding,
+// so the raw object is checked for them first.
+type genesisAllocJSON map[common.UnprefixedAddress]GenesisAccount
+
+func (a *genesisAllocJSON) UnmarshalJSON(data []byte) error {
+	if err := ctypes.CheckAccountKeys(data); err != nil {
+		return err
+	}
+	m := make(map[common.UnprefixedAddress]GenesisAccount)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*a = m
+	return nil
+}
+
 // GenesisAccount is an account in the state of the genesis block.
 type GenesisAccount struct {
 	Code       []byte                      `json:"code,omitempty"`
+	CodeHash   *common.Hash                `json:"codeHash,omitempty"` // declared keccak256 of Code, if any
 	Storage    map[common.Hash]common.Hash `json:"storage,omitempty"`
 	Balance    *big.Int                    `json:"balance" gencodec:"required"`
 	Nonce      uint64                      `json:"nonce,omitempty"`
 	PrivateKey []byte                      `json:"secretKey,omitempty"` // for tests
+
+	// Builtin is a precompiled contract definition of another format (Parity's 'builtin' object)
+	// which configurations do not model, eg. a chain's custom precompile. It is kept so that it is
+	// not lost by conversion; go-ethereum ignores it. See BuiltinOnly.
+	Builtin json.RawMessage `json:"builtin,omitempty"`
+}
+
+// BuiltinOnly returns true if the account only declares a builtin, so is not part of the genesis state,
+// as Parity's accounts which only declare a builtin are not.
+func (a GenesisAccount) BuiltinOnly() bool {
+	return a.Builtin != nil && (a.Balance == nil || a.Balance.Sign() == 0) && a.Nonce == 0 && len(a.Code) == 0 && len(a.Storage) == 0
 }
 
 // field type overrides for gencodec
@@ -115,7 +196,7 @@ type genesisSpecMarshaling struct {
 	GasUsed    math.HexOrDecimal64
 	Number     math.HexOrDecimal64
 	Difficulty *math.HexOrDecimal256
-	Alloc      map[common.UnprefixedAddress]GenesisAccount
+	Alloc      genesisAllocJSON
 }
 
 type genesisAccountMarshaling struct {
@@ -304,6 +385,14 @@ func (g *Genesis) SetChainID(i *big.Int) error {
 	return g.Config.SetChainID(i)
 }
 
+func (g *Genesis) GetBootnodes() []string {
+	return g.Config.GetBootnodes()
+}
+
+func (g *Genesis) SetBootnodes(b []string) error {
+	return g.Config.SetBootnodes(b)
+}
+
 func (g *Genesis) GetMaxCodeSize() *uint64 {
 	return g.Config.GetMaxCodeSize()
 }
@@ -536,6 +625,190 @@ func (g Genesis) SetEIP1706Transition(n *uint64) error {
 	return g.Config.SetEIP1706Transition(n)
 }
 
+func (g *Genesis) GetEIP2718Transition() *uint64 {
+	return g.Config.GetEIP2718Transition()
+}
+
+func (g *Genesis) SetEIP2718Transition(n *uint64) error {
+	return g.Config.SetEIP2718Transition(n)
+}
+
+func (g *Genesis) GetEIP2929Transition() *uint64 {
+	return g.Config.GetEIP2929Transition()
+}
+
+func (g *Genesis) SetEIP2929Transition(n *uint64) error {
+	return g.Config.SetEIP2929Transition(n)
+}
+
+func (g *Genesis) GetEIP2930Transition() *uint64 {
+	return g.Config.GetEIP2930Transition()
+}
+
+func (g *Genesis) SetEIP2930Transition(n *uint64) error {
+	return g.Config.SetEIP2930Transition(n)
+}
+
+func (g Genesis) GetEIP2565Transition() *uint64 {
+	return g.Config.GetEIP2565Transition()
+}
+
+func (g Genesis) SetEIP2565Transition(n *uint64) error {
+	return g.Config.SetEIP2565Transition(n)
+}
+
+func (g Genesis) GetEIP1559Transition() *uint64 {
+	return g.Config.GetEIP1559Transition()
+}
+
+func (g Genesis) SetEIP1559Transition(n *uint64) error {
+	return g.Config.SetEIP1559Transition(n)
+}
+
+func (g Genesis) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	return g.Config.GetEIP1559BaseFeeChangeDenominator()
+}
+
+func (g Genesis) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	return g.Config.SetEIP1559BaseFeeChangeDenominator(n)
+}
+
+func (g Genesis) GetEIP1559ElasticityMultiplier() *uint64 {
+	return g.Config.GetEIP1559ElasticityMultiplier()
+}
+
+func (g Genesis) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	return g.Config.SetEIP1559ElasticityMultiplier(n)
+}
+
+func (g Genesis) GetEIP3529Transition() *uint64 {
+	return g.Config.GetEIP3529Transition()
+}
+
+func (g Genesis) SetEIP3529Transition(n *uint64) error {
+	return g.Config.SetEIP3529Transition(n)
+}
+
+func (g Genesis) GetEIP3541Transition() *uint64 {
+	return g.Config.GetEIP3541Transition()
+}
+
+func (g Genesis) SetEIP3541Transition(n *uint64) error {
+	return g.Config.SetEIP3541Transition(n)
+}
+
+func (g Genesis) GetEWASMTransition() *uint64 {
+	return g.Config.GetEWASMTransition()
+}
+
+func (g Genesis) SetEWASMTransition(n *uint64) error {
+	return g.Config.SetEWASMTransition(n)
+}
+
+func (g Genesis) GetEIP3651TransitionTime() *uint64 {
+	return g.Config.GetEIP3651TransitionTime()
+}
+
+func (g Genesis) SetEIP3651TransitionTime(n *uint64) error {
+	return g.Config.SetEIP3651TransitionTime(n)
+}
+
+func (g Genesis) GetEIP3855TransitionTime() *uint64 {
+	return g.Config.GetEIP3855TransitionTime()
+}
+
+func (g Genesis) SetEIP3855TransitionTime(n *uint64) error {
+	return g.Config.SetEIP3855TransitionTime(n)
+}
+
+func (g Genesis) GetEIP3860TransitionTime() *uint64 {
+	return g.Config.GetEIP3860TransitionTime()
+}
+
+func (g Genesis) SetEIP3860TransitionTime(n *uint64) error {
+	return g.Config.SetEIP3860TransitionTime(n)
+}
+
+func (g Genesis) GetEIP4895TransitionTime() *uint64 {
+	return g.Config.GetEIP4895TransitionTime()
+}
+
+func (g Genesis) SetEIP4895TransitionTime(n *uint64) error {
+	return g.Config.SetEIP4895TransitionTime(n)
+}
+
+func (g Genesis) GetEIP1153TransitionTime() *uint64 {
+	return g.Config.GetEIP1153TransitionTime()
+}
+
+func (g Genesis) SetEIP1153TransitionTime(n *uint64) error {
+	return g.Config.SetEIP1153TransitionTime(n)
+}
+
+func (g Genesis) GetEIP4788TransitionTime() *uint64 {
+	return g.Config.GetEIP4788TransitionTime()
+}
+
+func (g Genesis) SetEIP4788TransitionTime(n *uint64) error {
+	return g.Config.SetEIP4788TransitionTime(n)
+}
+
+func (g Genesis) GetEIP4844TransitionTime() *uint64 {
+	return g.Config.GetEIP4844TransitionTime()
+}
+
+func (g Genesis) SetEIP4844TransitionTime(n *uint64) error {
+	return g.Config.SetEIP4844TransitionTime(n)
+}
+
+func (g Genesis) GetEIP5656TransitionTime() *uint64 {
+	return g.Config.GetEIP5656TransitionTime()
+}
+
+func (g Genesis) SetEIP5656TransitionTime(n *uint64) error {
+	return g.Config.SetEIP5656TransitionTime(n)
+}
+
+func (g Genesis) GetEIP6780TransitionTime() *uint64 {
+	return g.Config.GetEIP6780TransitionTime()
+}
+
+func (g Genesis) SetEIP6780TransitionTime(n *uint64) error {
+	return g.Config.SetEIP6780TransitionTime(n)
+}
+
+func (g Genesis) GetEIP7516TransitionTime() *uint64 {
+	return g.Config.GetEIP7516TransitionTime()
+}
+
+func (g Genesis) SetEIP7516TransitionTime(n *uint64) error {
+	return g.Config.SetEIP7516TransitionTime(n)
+}
+
+func (g Genesis) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	return g.Config.GetEIP4844TargetBlobsPerBlock()
+}
+
+func (g Genesis) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	return g.Config.SetEIP4844TargetBlobsPerBlock(n)
+}
+
+func (g Genesis) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	return g.Config.GetEIP4844MaxBlobsPerBlock()
+}
+
+func (g Genesis) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	return g.Config.SetEIP4844MaxBlobsPerBlock(n)
+}
+
+func (g Genesis) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	return g.Config.GetEIP4844BlobBaseFeeUpdateFraction()
+}
+
+func (g Genesis) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	return g.Config.SetEIP4844BlobBaseFeeUpdateFraction(n)
+}
+
 func (g *Genesis) IsForked(fn func() *uint64, n *big.Int) bool {
 	return g.Config.IsForked(fn, n)
 }
@@ -608,6 +881,22 @@ func (g *Genesis) SetEthashEIP779Transition(n *uint64) error {
 	return g.Config.SetEthashEIP779Transition(n)
 }
 
+func (g *Genesis) GetEthashDAOForkBeneficiary() *common.Address {
+	return g.Config.GetEthashDAOForkBeneficiary()
+}
+
+func (g *Genesis) SetEthashDAOForkBeneficiary(a *common.Address) error {
+	return g.Config.SetEthashDAOForkBeneficiary(a)
+}
+
+func (g *Genesis) GetEthashDAOForkAccounts() []common.Address {
+	return g.Config.GetEthashDAOForkAccounts()
+}
+
+func (g *Genesis) SetEthashDAOForkAccounts(addrs []common.Address) error {
+	return g.Config.SetEthashDAOForkAccounts(addrs)
+}
+
 func (g *Genesis) GetEthashEIP649Transition() *uint64 {
 	return g.Config.GetEthashEIP649Transition()
 }
@@ -632,6 +921,14 @@ func (g *Genesis) SetEthashEIP2384Transition(n *uint64) error {
 	return g.Config.SetEthashEIP2384Transition(n)
 }
 
+func (g *Genesis) GetEthashEIP3554Transition() *uint64 {
+	return g.Config.GetEthashEIP3554Transition()
+}
+
+func (g *Genesis) SetEthashEIP3554Transition(n *uint64) error {
+	return g.Config.SetEthashEIP3554Transition(n)
+}
+
 func (g *Genesis) GetEthashECIP1010PauseTransition() *uint64 {
 	return g.Config.GetEthashECIP1010PauseTransition()
 }
@@ -696,6 +993,32 @@ func (g *Genesis) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesHex)
 	return g.Config.SetEthashBlockRewardSchedule(m)
 }
 
+func (g *Genesis) GetEthashNoProof() bool {
+	return g.Config.GetEthashNoProof()
+}
+
+func (g *Genesis) SetEthashNoProof(b bool) error {
+	return g.Config.SetEthashNoProof(b)
+}
+
+// GetExtraFields returns the extra fields of the genesis config, if its data type preserves them.
+func (g *Genesis) GetExtraFields() map[string]json.RawMessage {
+	if c, ok := g.Config.(ctypes.ExtraFieldsConfigurator); ok {
+		return c.GetExtraFields()
+	}
+	return nil
+}
+
+func (g *Genesis) SetExtraFields(m map[string]json.RawMessage) error {
+	if c, ok := g.Config.(ctypes.ExtraFieldsConfigurator); ok {
+		return c.SetExtraFields(m)
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
 func (g *Genesis) GetCliquePeriod() uint64 {
 	return g.Config.GetCliquePeriod()
 }
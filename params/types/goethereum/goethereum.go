@@ -17,8 +17,10 @@
 package goethereum
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
@@ -56,6 +58,20 @@ type ChainConfig struct {
 	IstanbulBlock    *big.Int `json:"istanbulBlock,omitempty"`    // Istanbul switch block (nil = no fork, 0 = already on istanbul)
 	MuirGlacierBlock *big.Int `json:"muirGlacierBlock,omitempty"` // Eip-2384 (bomb delay) switch block (nil = no fork, 0 = already activated)
 
+	// HF: Berlin
+	BerlinBlock *big.Int `json:"berlinBlock,omitempty"` // Berlin switch block (nil = no fork, 0 = already on berlin)
+
+	// HF: London
+	LondonBlock *big.Int `json:"londonBlock,omitempty"` // London switch block (nil = no fork, 0 = already on london)
+
+	// HF: Shanghai
+	ShanghaiTime *uint64 `json:"shanghaiTime,omitempty"` // Shanghai switch time (nil = no fork, 0 = already on shanghai)
+
+	// HF: Cancun
+	CancunTime *uint64 `json:"cancunTime,omitempty"` // Cancun switch time (nil = no fork, 0 = already on cancun)
+
+	BlobScheduleConfig *ctypes.BlobScheduleConfig `json:"blobSchedule,omitempty"` // EIP-4844 blob parameters by fork
+
 	EWASMBlock *big.Int `json:"ewasmBlock,omitempty"` // EWASM switch block (nil = no fork, 0 = already activated)
 
 	// Various consensus engines
@@ -68,6 +84,37 @@ type ChainConfig struct {
 
 	EIP1706Transition  *big.Int `json:"-"`
 	ECIP1080Transition *big.Int `json:"-"`
+
+	// Extra holds any other fields (eg. of a newer go-ethereum version), so that they are
+	// preserved when a configuration is read and written again.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// knownKeys are the (lowercased) JSON keys of the ChainConfig fields.
+var knownKeys = ctypes.JSONFieldKeys(reflect.TypeOf(ChainConfig{}))
+
+func (c *ChainConfig) UnmarshalJSON(input []byte) error {
+	type config ChainConfig
+	var dec config
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	extra, err := ctypes.UnmarshalExtraFields(input, knownKeys)
+	if err != nil {
+		return err
+	}
+	dec.Extra = extra
+	*c = ChainConfig(dec)
+	return nil
+}
+
+func (c *ChainConfig) MarshalJSON() ([]byte, error) {
+	type config ChainConfig
+	b, err := json.Marshal((*config)(c))
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.MarshalExtraFields(b, c.Extra)
 }
 
 // String implements the fmt.Stringer interface.
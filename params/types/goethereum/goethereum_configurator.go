@@ -17,6 +17,7 @@
 package goethereum
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -120,6 +121,14 @@ func (c *ChainConfig) SetChainID(n *big.Int) error {
 	return nil
 }
 
+func (c *ChainConfig) GetBootnodes() []string {
+	return nil
+}
+
+func (c *ChainConfig) SetBootnodes(b []string) error {
+	return ctypes.ErrUnsupportedConfigNoop
+}
+
 func (c *ChainConfig) GetMaxCodeSize() *uint64 {
 	return internal.GlobalConfigurator().GetMaxCodeSize()
 }
@@ -382,6 +391,260 @@ func (c *ChainConfig) SetEIP1706Transition(n *uint64) error {
 	return nil
 }
 
+func (c *ChainConfig) GetEIP2718Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *ChainConfig) SetEIP2718Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP2929Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *ChainConfig) SetEIP2929Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP2930Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *ChainConfig) SetEIP2930Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP2565Transition() *uint64 {
+	return bigNewU64(c.BerlinBlock)
+}
+
+func (c *ChainConfig) SetEIP2565Transition(n *uint64) error {
+	c.BerlinBlock = setBig(c.BerlinBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP1559Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *ChainConfig) SetEIP1559Transition(n *uint64) error {
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+// Go-ethereum does not allow the EIP-1559 parameters to be configured.
+
+func (c *ChainConfig) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	return internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator()
+}
+
+func (c *ChainConfig) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP1559BaseFeeChangeDenominator(n)
+}
+
+func (c *ChainConfig) GetEIP1559ElasticityMultiplier() *uint64 {
+	return internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier()
+}
+
+func (c *ChainConfig) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP1559ElasticityMultiplier(n)
+}
+
+func (c *ChainConfig) GetEIP3529Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *ChainConfig) SetEIP3529Transition(n *uint64) error {
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP3541Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *ChainConfig) SetEIP3541Transition(n *uint64) error {
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEWASMTransition() *uint64 {
+	return bigNewU64(c.EWASMBlock)
+}
+
+func (c *ChainConfig) SetEWASMTransition(n *uint64) error {
+	c.EWASMBlock = setBig(c.EWASMBlock, n)
+	return nil
+}
+
+func (c *ChainConfig) GetEIP3651TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *ChainConfig) SetEIP3651TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP3855TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *ChainConfig) SetEIP3855TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP3860TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *ChainConfig) SetEIP3860TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP4895TransitionTime() *uint64 {
+	return c.ShanghaiTime
+}
+
+func (c *ChainConfig) SetEIP4895TransitionTime(n *uint64) error {
+	c.ShanghaiTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP1153TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *ChainConfig) SetEIP1153TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP4788TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *ChainConfig) SetEIP4788TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP4844TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+// SetEIP4844TransitionTime also writes the (default) Cancun blob parameters, if they are not configured,
+// since a Cancun configuration must have them.
+func (c *ChainConfig) SetEIP4844TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	if n != nil {
+		c.cancunBlobConfig()
+	}
+	return nil
+}
+
+func (c *ChainConfig) GetEIP5656TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *ChainConfig) SetEIP5656TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP6780TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *ChainConfig) SetEIP6780TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP7516TransitionTime() *uint64 {
+	return c.CancunTime
+}
+
+func (c *ChainConfig) SetEIP7516TransitionTime(n *uint64) error {
+	c.CancunTime = n
+	return nil
+}
+
+// hasCancunBlobConfig returns true if the Cancun blob parameters are configured.
+func (c *ChainConfig) hasCancunBlobConfig() bool {
+	return c.BlobScheduleConfig != nil && c.BlobScheduleConfig.Cancun != nil
+}
+
+// cancunBlobConfig returns the Cancun blob parameters, adding them (with the default values)
+// if they are not configured.
+func (c *ChainConfig) cancunBlobConfig() *ctypes.BlobConfig {
+	if c.BlobScheduleConfig == nil {
+		c.BlobScheduleConfig = &ctypes.BlobScheduleConfig{}
+	}
+	if c.BlobScheduleConfig.Cancun == nil {
+		c.BlobScheduleConfig.Cancun = &ctypes.BlobConfig{
+			Target:         *internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock(),
+			Max:            *internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock(),
+			UpdateFraction: *internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction(),
+		}
+	}
+	return c.BlobScheduleConfig.Cancun
+}
+
+func (c *ChainConfig) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	if c.hasCancunBlobConfig() {
+		n := c.BlobScheduleConfig.Cancun.Target
+		return &n
+	}
+	return internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock()
+}
+
+func (c *ChainConfig) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	if n == nil || !c.hasCancunBlobConfig() && *n == *internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock() {
+		return nil
+	}
+	c.cancunBlobConfig().Target = *n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	if c.hasCancunBlobConfig() {
+		n := c.BlobScheduleConfig.Cancun.Max
+		return &n
+	}
+	return internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock()
+}
+
+func (c *ChainConfig) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	if n == nil || !c.hasCancunBlobConfig() && *n == *internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock() {
+		return nil
+	}
+	c.cancunBlobConfig().Max = *n
+	return nil
+}
+
+func (c *ChainConfig) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	if c.hasCancunBlobConfig() {
+		n := c.BlobScheduleConfig.Cancun.UpdateFraction
+		return &n
+	}
+	return internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction()
+}
+
+func (c *ChainConfig) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	if n == nil || !c.hasCancunBlobConfig() && *n == *internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction() {
+		return nil
+	}
+	c.cancunBlobConfig().UpdateFraction = *n
+	return nil
+}
+
 func (c *ChainConfig) IsForked(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -425,9 +688,11 @@ func (c *ChainConfig) MustSetConsensusEngineType(t ctypes.ConsensusEngineT) erro
 	switch t {
 	case ctypes.ConsensusEngineT_Ethash:
 		c.Ethash = new(ctypes.EthashConfig)
+		c.Clique = nil
 		return nil
 	case ctypes.ConsensusEngineT_Clique:
 		c.Clique = new(ctypes.CliqueConfig)
+		c.Ethash = nil
 		return nil
 	default:
 		return ctypes.ErrUnsupportedConfigFatal
@@ -506,6 +771,24 @@ func (c *ChainConfig) SetEthashEIP779Transition(n *uint64) error {
 	return nil
 }
 
+// The DAO hard fork refund is that of the Ethereum mainnet DAO fork.
+
+func (c *ChainConfig) GetEthashDAOForkBeneficiary() *common.Address {
+	return internal.DAOForkBeneficiary(c.GetEthashEIP779Transition())
+}
+
+func (c *ChainConfig) SetEthashDAOForkBeneficiary(a *common.Address) error {
+	return internal.SetDAOForkBeneficiary(a)
+}
+
+func (c *ChainConfig) GetEthashDAOForkAccounts() []common.Address {
+	return internal.DAOForkAccounts(c.GetEthashEIP779Transition())
+}
+
+func (c *ChainConfig) SetEthashDAOForkAccounts(addrs []common.Address) error {
+	return internal.SetDAOForkAccounts(addrs)
+}
+
 func (c *ChainConfig) GetEthashEIP649Transition() *uint64 {
 	return bigNewU64(c.ByzantiumBlock)
 }
@@ -542,6 +825,18 @@ func (c *ChainConfig) SetEthashEIP2384Transition(n *uint64) error {
 	return nil
 }
 
+func (c *ChainConfig) GetEthashEIP3554Transition() *uint64 {
+	return bigNewU64(c.LondonBlock)
+}
+
+func (c *ChainConfig) SetEthashEIP3554Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.LondonBlock = setBig(c.LondonBlock, n)
+	return nil
+}
+
 func (c *ChainConfig) GetEthashECIP1010PauseTransition() *uint64 {
 	return nil
 }
@@ -625,6 +920,17 @@ func (c *ChainConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMapEncodesH
 	return ctypes.ErrUnsupportedConfigNoop
 }
 
+func (c *ChainConfig) GetEthashNoProof() bool {
+	return false
+}
+
+func (c *ChainConfig) SetEthashNoProof(b bool) error {
+	if !b {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (c *ChainConfig) GetCliquePeriod() uint64 {
 	if c.Clique == nil {
 		return 0
@@ -654,3 +960,12 @@ func (c *ChainConfig) SetCliqueEpoch(n uint64) error {
 	c.Clique.Epoch = n
 	return nil
 }
+
+func (c *ChainConfig) GetExtraFields() map[string]json.RawMessage {
+	return c.Extra
+}
+
+func (c *ChainConfig) SetExtraFields(m map[string]json.RawMessage) error {
+	c.Extra = m
+	return nil
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/vars"
 )
@@ -73,6 +74,65 @@ func (_ GlobalVarsConfigurator) SetMaxCodeSize(n *uint64) error {
 	return nil
 }
 
+// The EIP-1559 parameters are only read, since overriding them is a per-chain configuration.
+
+func (_ GlobalVarsConfigurator) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	return newU64(vars.BaseFeeChangeDenominator)
+}
+
+func (_ GlobalVarsConfigurator) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	if n == nil || *n == vars.BaseFeeChangeDenominator {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (_ GlobalVarsConfigurator) GetEIP1559ElasticityMultiplier() *uint64 {
+	return newU64(vars.ElasticityMultiplier)
+}
+
+func (_ GlobalVarsConfigurator) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	if n == nil || *n == vars.ElasticityMultiplier {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+// The EIP-4844 blob parameters are only read, since overriding them is a per-chain configuration.
+
+func (_ GlobalVarsConfigurator) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	return newU64(vars.TargetBlobsPerBlock)
+}
+
+func (_ GlobalVarsConfigurator) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	if n == nil || *n == vars.TargetBlobsPerBlock {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (_ GlobalVarsConfigurator) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	return newU64(vars.MaxBlobsPerBlock)
+}
+
+func (_ GlobalVarsConfigurator) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	if n == nil || *n == vars.MaxBlobsPerBlock {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (_ GlobalVarsConfigurator) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	return newU64(vars.BlobBaseFeeUpdateFraction)
+}
+
+func (_ GlobalVarsConfigurator) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	if n == nil || *n == vars.BlobBaseFeeUpdateFraction {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (_ GlobalVarsConfigurator) GetEthashMinimumDifficulty() *big.Int {
 	return vars.MinimumDifficulty
 }
@@ -107,3 +167,54 @@ func (_ GlobalVarsConfigurator) SetEthashDurationLimit(i *big.Int) error {
 	vars.DurationLimit = i
 	return nil
 }
+
+// DAOForkBeneficiary returns the Ethereum mainnet DAO hard fork refund contract
+// if the fork is configured (n is not nil), for formats which cannot configure it.
+func DAOForkBeneficiary(n *uint64) *common.Address {
+	if n == nil {
+		return nil
+	}
+	a := vars.DAORefundContract
+	return &a
+}
+
+// SetDAOForkBeneficiary accepts only the Ethereum mainnet DAO hard fork refund contract (or nil),
+// for formats which cannot configure it.
+func SetDAOForkBeneficiary(a *common.Address) error {
+	if a == nil || *a == vars.DAORefundContract {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+// DAOForkAccounts returns the Ethereum mainnet DAO hard fork drained accounts
+// if the fork is configured (n is not nil), for formats which cannot configure them.
+func DAOForkAccounts(n *uint64) []common.Address {
+	if n == nil {
+		return nil
+	}
+	return vars.DAODrainList()
+}
+
+// SetDAOForkAccounts accepts only the Ethereum mainnet DAO hard fork drained accounts (or nil),
+// for formats which cannot configure them.
+func SetDAOForkAccounts(addrs []common.Address) error {
+	if addrs == nil || IsDAODrainList(addrs) {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+// IsDAODrainList reports whether addrs are the Ethereum mainnet DAO hard fork drained accounts, in order.
+func IsDAODrainList(addrs []common.Address) bool {
+	drain := vars.DAODrainList()
+	if len(addrs) != len(drain) {
+		return false
+	}
+	for i := range addrs {
+		if addrs[i] != drain[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -17,8 +17,10 @@
 package multigeth
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"reflect"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -39,6 +41,19 @@ type MultiGethChainConfig struct {
 	NetworkID uint64   `json:"networkId"`
 	ChainID   *big.Int `json:"chainId"` // chainId identifies the current chain and is used for replay protection
 
+	// Bootnodes are the enode URLs of the network's bootstrap nodes.
+	Bootnodes []string `json:"bootnodes,omitempty"`
+
+	// AccountStartNonce is the nonce of newly created accounts, when it is not 0 (eg. 2^20 on Morden).
+	// It is carried for conversion with formats which configure it (eg. Parity).
+	AccountStartNonce *uint64 `json:"accountStartNonce,omitempty"`
+
+	// Block validity parameters, when they are not the go-ethereum defaults (see params/vars).
+	// They are carried for conversion with formats which configure them (eg. Parity).
+	MaximumExtraDataSize *uint64 `json:"maximumExtraDataSize,omitempty"`
+	MinGasLimit          *uint64 `json:"minGasLimit,omitempty"`
+	GasLimitBoundDivisor *uint64 `json:"gasLimitBoundDivisor,omitempty"`
+
 	// HF: Homestead
 	//HomesteadBlock *big.Int `json:"homesteadBlock,omitempty"` // Homestead switch block (nil = no fork, 0 = already homestead)
 	// "Homestead Hard-fork Changes"
@@ -52,6 +67,9 @@ type MultiGethChainConfig struct {
 	// HF: DAO
 	DAOForkBlock *big.Int `json:"daoForkBlock,omitempty"` // TheDAO hard-fork switch block (nil = no fork)
 	//DAOForkSupport bool     `json:"daoForkSupport,omitempty"` // Whether the nodes supports or opposes the DAO hard-fork
+	// DAO hard-fork refund contract and drained accounts, if not those of the Ethereum mainnet DAO fork (nil = mainnet's)
+	DAOForkBeneficiary *common.Address  `json:"daoForkBeneficiary,omitempty"`
+	DAOForkAccounts    []common.Address `json:"daoForkAccounts,omitempty"`
 
 	// HF: Tangerine Whistle
 	// EIP150 implements the Gas price changes (https://github.com/ethereum/EIPs/issues/150)
@@ -158,11 +176,87 @@ type MultiGethChainConfig struct {
 	eip2384Inferred bool
 	EIP2384FBlock   *big.Int `json:"eip2384FBlock,omitempty"`
 
+	// EIP-3554: Difficulty Bomb Delay to December 2021 (London)
+	eip3554Inferred bool
+	EIP3554FBlock   *big.Int `json:"eip3554FBlock,omitempty"`
+
 	// EIP-1706: Resolves reentrancy attack vector enabled with EIP1283.
 	// https://eips.ethereum.org/EIPS/eip-1706
 	EIP1706FBlock *big.Int `json:"eip1706FBlock,omitempty"`
 
-	//EWASMBlock *big.Int `json:"ewasmBlock,omitempty"` // EWASM switch block (nil = no fork, 0 = already activated)
+	// EIP-2718: Typed transaction envelope
+	// https://eips.ethereum.org/EIPS/eip-2718
+	EIP2718FBlock *big.Int `json:"eip2718FBlock,omitempty"`
+
+	// EIP-2929: Gas cost increases for state access opcodes
+	// https://eips.ethereum.org/EIPS/eip-2929
+	EIP2929FBlock *big.Int `json:"eip2929FBlock,omitempty"`
+
+	// EIP-2930: Optional access lists
+	// https://eips.ethereum.org/EIPS/eip-2930
+	EIP2930FBlock *big.Int `json:"eip2930FBlock,omitempty"`
+
+	// EIP-2565: ModExp gas cost
+	// https://eips.ethereum.org/EIPS/eip-2565
+	EIP2565FBlock *big.Int `json:"eip2565FBlock,omitempty"`
+
+	// EIP-1559: Fee market change for ETH 1.0 chain
+	// https://eips.ethereum.org/EIPS/eip-1559
+	// The base fee parameters default to the EIP's values when unset.
+	EIP1559FBlock            *big.Int `json:"eip1559FBlock,omitempty"`
+	BaseFeeChangeDenominator *uint64  `json:"baseFeeChangeDenominator,omitempty"`
+	ElasticityMultiplier     *uint64  `json:"elasticityMultiplier,omitempty"`
+
+	// EIP-3529: Reduction in refunds
+	// https://eips.ethereum.org/EIPS/eip-3529
+	// EIP-3541: Reject new contracts starting with the 0xEF byte
+	// https://eips.ethereum.org/EIPS/eip-3541
+	// They default to the EIP1559 block (London) when unset.
+	EIP3529FBlock *big.Int `json:"eip3529FBlock,omitempty"`
+	EIP3541FBlock *big.Int `json:"eip3541FBlock,omitempty"`
+
+	// Shanghai EIPs are activated by block timestamp, rather than block number.
+	// EIP-3651: Warm COINBASE
+	// https://eips.ethereum.org/EIPS/eip-3651
+	// EIP-3855: PUSH0 instruction
+	// https://eips.ethereum.org/EIPS/eip-3855
+	// EIP-3860: Limit and meter initcode
+	// https://eips.ethereum.org/EIPS/eip-3860
+	// EIP-4895: Beacon chain push withdrawals as operations
+	// https://eips.ethereum.org/EIPS/eip-4895
+	// EIP3651, EIP3855, and EIP3860 default to the EIP4895 time (Shanghai) when unset.
+	EIP3651FTime *uint64 `json:"eip3651FTime,omitempty"`
+	EIP3855FTime *uint64 `json:"eip3855FTime,omitempty"`
+	EIP3860FTime *uint64 `json:"eip3860FTime,omitempty"`
+	EIP4895FTime *uint64 `json:"eip4895FTime,omitempty"`
+
+	// Cancun EIPs are activated by block timestamp, rather than block number.
+	// EIP-1153: Transient storage opcodes
+	// https://eips.ethereum.org/EIPS/eip-1153
+	// EIP-4788: Beacon block root in the EVM
+	// https://eips.ethereum.org/EIPS/eip-4788
+	// EIP-4844: Shard blob transactions
+	// https://eips.ethereum.org/EIPS/eip-4844
+	// EIP-5656: MCOPY - Memory copying instruction
+	// https://eips.ethereum.org/EIPS/eip-5656
+	// EIP-6780: SELFDESTRUCT only in same transaction
+	// https://eips.ethereum.org/EIPS/eip-6780
+	// EIP-7516: BLOBBASEFEE instruction
+	// https://eips.ethereum.org/EIPS/eip-7516
+	// EIP1153, EIP4788, EIP5656, EIP6780, and EIP7516 default to the EIP4844 time (Cancun) when unset.
+	EIP1153FTime *uint64 `json:"eip1153FTime,omitempty"`
+	EIP4788FTime *uint64 `json:"eip4788FTime,omitempty"`
+	EIP4844FTime *uint64 `json:"eip4844FTime,omitempty"`
+	EIP5656FTime *uint64 `json:"eip5656FTime,omitempty"`
+	EIP6780FTime *uint64 `json:"eip6780FTime,omitempty"`
+	EIP7516FTime *uint64 `json:"eip7516FTime,omitempty"`
+
+	// EIP-4844 blob parameters. Unset values are the defaults (3, 6, and 3338477).
+	TargetBlobsPerBlock       *uint64 `json:"targetBlobsPerBlock,omitempty"`
+	MaxBlobsPerBlock          *uint64 `json:"maxBlobsPerBlock,omitempty"`
+	BlobBaseFeeUpdateFraction *uint64 `json:"blobBaseFeeUpdateFraction,omitempty"`
+
+	EWASMBlock *big.Int `json:"ewasmBlock,omitempty"` // EWASM switch block (nil = no fork, 0 = already activated)
 
 	ECIP1010PauseBlock *big.Int `json:"ecip1010PauseBlock,omitempty"` // ECIP1010 pause HF block
 	ECIP1010Length     *big.Int `json:"ecip1010Length,omitempty"`     // ECIP1010 length
@@ -177,6 +271,10 @@ type MultiGethChainConfig struct {
 	Ethash *ctypes.EthashConfig `json:"ethash,omitempty"`
 	Clique *ctypes.CliqueConfig `json:"clique,omitempty"`
 
+	// EthashNoProof configures ethash not to verify the proof of work of blocks, as for test chains
+	// (eg. those of ethereum/tests, configured with Aleth's NoProof seal engine).
+	EthashNoProof bool `json:"ethashNoProof,omitempty"`
+
 	TrustedCheckpoint       *ctypes.TrustedCheckpoint      `json:"trustedCheckpoint,omitempty"`
 	TrustedCheckpointOracle *ctypes.CheckpointOracleConfig `json:"trustedCheckpointOracle,omitempty"`
 
@@ -184,9 +282,46 @@ type MultiGethChainConfig struct {
 	BlockRewardSchedule         ctypes.Uint64BigMapEncodesHex `json:"blockReward,omitempty"`          // JSON tag matches Parity's
 
 	RequireBlockHashes map[uint64]common.Hash `json:"requireBlockHashes"`
+
+	// Extra holds any other fields (eg. of a newer multi-geth version), so that they are
+	// preserved when a configuration is read and written again.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// knownKeys are the (lowercased) JSON keys of the MultiGethChainConfig fields.
+var knownKeys = ctypes.JSONFieldKeys(reflect.TypeOf(MultiGethChainConfig{}))
+
+func (c *MultiGethChainConfig) UnmarshalJSON(input []byte) error {
+	type config MultiGethChainConfig
+	var dec config
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	extra, err := ctypes.UnmarshalExtraFields(input, knownKeys)
+	if err != nil {
+		return err
+	}
+	dec.Extra = extra
+	*c = MultiGethChainConfig(dec)
+	return nil
+}
+
+func (c *MultiGethChainConfig) MarshalJSON() ([]byte, error) {
+	type config MultiGethChainConfig
+	b, err := json.Marshal((*config)(c))
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.MarshalExtraFields(b, c.Extra)
 }
 
 // String implements the fmt.Stringer interface.
+// KeepsAccountBuiltins returns true, since multigeth keeps the builtins (precompiled contract
+// definitions of other formats) of genesis accounts; see genesisT.GenesisAccount.Builtin.
+func (c *MultiGethChainConfig) KeepsAccountBuiltins() bool {
+	return true
+}
+
 func (c *MultiGethChainConfig) String() string {
 	var engine interface{}
 	switch {
@@ -27,6 +27,7 @@ method SetValueForHeight which is used for this.
 package multigeth
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -68,28 +69,56 @@ func (c *MultiGethChainConfig) ensureExistingDifficultySchedule() {
 }
 
 func (c *MultiGethChainConfig) GetAccountStartNonce() *uint64 {
-	return internal.GlobalConfigurator().GetAccountStartNonce()
+	if c.AccountStartNonce == nil {
+		return internal.GlobalConfigurator().GetAccountStartNonce()
+	}
+	return c.AccountStartNonce
 }
 func (c *MultiGethChainConfig) SetAccountStartNonce(n *uint64) error {
-	return internal.GlobalConfigurator().SetAccountStartNonce(n)
+	if n != nil && *n == *internal.GlobalConfigurator().GetAccountStartNonce() {
+		n = nil
+	}
+	c.AccountStartNonce = n
+	return nil
 }
 func (c *MultiGethChainConfig) GetMaximumExtraDataSize() *uint64 {
-	return internal.GlobalConfigurator().GetMaximumExtraDataSize()
+	if c.MaximumExtraDataSize == nil {
+		return internal.GlobalConfigurator().GetMaximumExtraDataSize()
+	}
+	return c.MaximumExtraDataSize
 }
 func (c *MultiGethChainConfig) SetMaximumExtraDataSize(n *uint64) error {
-	return internal.GlobalConfigurator().SetMaximumExtraDataSize(n)
+	if n != nil && *n == *internal.GlobalConfigurator().GetMaximumExtraDataSize() {
+		n = nil
+	}
+	c.MaximumExtraDataSize = n
+	return nil
 }
 func (c *MultiGethChainConfig) GetMinGasLimit() *uint64 {
-	return internal.GlobalConfigurator().GetMinGasLimit()
+	if c.MinGasLimit == nil {
+		return internal.GlobalConfigurator().GetMinGasLimit()
+	}
+	return c.MinGasLimit
 }
 func (c *MultiGethChainConfig) SetMinGasLimit(n *uint64) error {
-	return internal.GlobalConfigurator().SetMinGasLimit(n)
+	if n != nil && *n == *internal.GlobalConfigurator().GetMinGasLimit() {
+		n = nil
+	}
+	c.MinGasLimit = n
+	return nil
 }
 func (c *MultiGethChainConfig) GetGasLimitBoundDivisor() *uint64 {
-	return internal.GlobalConfigurator().GetGasLimitBoundDivisor()
+	if c.GasLimitBoundDivisor == nil {
+		return internal.GlobalConfigurator().GetGasLimitBoundDivisor()
+	}
+	return c.GasLimitBoundDivisor
 }
 func (c *MultiGethChainConfig) SetGasLimitBoundDivisor(n *uint64) error {
-	return internal.GlobalConfigurator().SetGasLimitBoundDivisor(n)
+	if n != nil && *n == *internal.GlobalConfigurator().GetGasLimitBoundDivisor() {
+		n = nil
+	}
+	c.GasLimitBoundDivisor = n
+	return nil
 }
 
 func (c *MultiGethChainConfig) GetNetworkID() *uint64 {
@@ -113,6 +142,15 @@ func (c *MultiGethChainConfig) SetChainID(n *big.Int) error {
 	return nil
 }
 
+func (c *MultiGethChainConfig) GetBootnodes() []string {
+	return c.Bootnodes
+}
+
+func (c *MultiGethChainConfig) SetBootnodes(b []string) error {
+	c.Bootnodes = b
+	return nil
+}
+
 func (c *MultiGethChainConfig) GetMaxCodeSize() *uint64 {
 	return internal.GlobalConfigurator().GetMaxCodeSize()
 }
@@ -372,6 +410,273 @@ func (c *MultiGethChainConfig) SetEIP1706Transition(n *uint64) error {
 	return nil
 }
 
+func (c *MultiGethChainConfig) GetEIP2718Transition() *uint64 {
+	return bigNewU64(c.EIP2718FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP2718Transition(n *uint64) error {
+	c.EIP2718FBlock = setBig(c.EIP2718FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP2929Transition() *uint64 {
+	return bigNewU64(c.EIP2929FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP2929Transition(n *uint64) error {
+	c.EIP2929FBlock = setBig(c.EIP2929FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP2930Transition() *uint64 {
+	return bigNewU64(c.EIP2930FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP2930Transition(n *uint64) error {
+	c.EIP2930FBlock = setBig(c.EIP2930FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP2565Transition() *uint64 {
+	return bigNewU64(c.EIP2565FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP2565Transition(n *uint64) error {
+	c.EIP2565FBlock = setBig(c.EIP2565FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP1559Transition() *uint64 {
+	return bigNewU64(c.EIP1559FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP1559Transition(n *uint64) error {
+	c.EIP1559FBlock = setBig(c.EIP1559FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	if c.BaseFeeChangeDenominator != nil {
+		return c.BaseFeeChangeDenominator
+	}
+	return internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator()
+}
+
+func (c *MultiGethChainConfig) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator() {
+		n = nil
+	}
+	c.BaseFeeChangeDenominator = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP1559ElasticityMultiplier() *uint64 {
+	if c.ElasticityMultiplier != nil {
+		return c.ElasticityMultiplier
+	}
+	return internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier()
+}
+
+func (c *MultiGethChainConfig) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier() {
+		n = nil
+	}
+	c.ElasticityMultiplier = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP3529Transition() *uint64 {
+	if c.EIP3529FBlock == nil {
+		return c.GetEIP1559Transition()
+	}
+	return bigNewU64(c.EIP3529FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP3529Transition(n *uint64) error {
+	c.EIP3529FBlock = setBig(c.EIP3529FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP3541Transition() *uint64 {
+	if c.EIP3541FBlock == nil {
+		return c.GetEIP1559Transition()
+	}
+	return bigNewU64(c.EIP3541FBlock)
+}
+
+func (c *MultiGethChainConfig) SetEIP3541Transition(n *uint64) error {
+	c.EIP3541FBlock = setBig(c.EIP3541FBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEWASMTransition() *uint64 {
+	return bigNewU64(c.EWASMBlock)
+}
+
+func (c *MultiGethChainConfig) SetEWASMTransition(n *uint64) error {
+	c.EWASMBlock = setBig(c.EWASMBlock, n)
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP3651TransitionTime() *uint64 {
+	if c.EIP3651FTime == nil {
+		return c.EIP4895FTime
+	}
+	return c.EIP3651FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP3651TransitionTime(n *uint64) error {
+	c.EIP3651FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP3855TransitionTime() *uint64 {
+	if c.EIP3855FTime == nil {
+		return c.EIP4895FTime
+	}
+	return c.EIP3855FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP3855TransitionTime(n *uint64) error {
+	c.EIP3855FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP3860TransitionTime() *uint64 {
+	if c.EIP3860FTime == nil {
+		return c.EIP4895FTime
+	}
+	return c.EIP3860FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP3860TransitionTime(n *uint64) error {
+	c.EIP3860FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP4895TransitionTime() *uint64 {
+	return c.EIP4895FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP4895TransitionTime(n *uint64) error {
+	c.EIP4895FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP1153TransitionTime() *uint64 {
+	if c.EIP1153FTime == nil {
+		return c.EIP4844FTime
+	}
+	return c.EIP1153FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP1153TransitionTime(n *uint64) error {
+	c.EIP1153FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP4788TransitionTime() *uint64 {
+	if c.EIP4788FTime == nil {
+		return c.EIP4844FTime
+	}
+	return c.EIP4788FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP4788TransitionTime(n *uint64) error {
+	c.EIP4788FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP4844TransitionTime() *uint64 {
+	return c.EIP4844FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP4844TransitionTime(n *uint64) error {
+	c.EIP4844FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP5656TransitionTime() *uint64 {
+	if c.EIP5656FTime == nil {
+		return c.EIP4844FTime
+	}
+	return c.EIP5656FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP5656TransitionTime(n *uint64) error {
+	c.EIP5656FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP6780TransitionTime() *uint64 {
+	if c.EIP6780FTime == nil {
+		return c.EIP4844FTime
+	}
+	return c.EIP6780FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP6780TransitionTime(n *uint64) error {
+	c.EIP6780FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP7516TransitionTime() *uint64 {
+	if c.EIP7516FTime == nil {
+		return c.EIP4844FTime
+	}
+	return c.EIP7516FTime
+}
+
+func (c *MultiGethChainConfig) SetEIP7516TransitionTime(n *uint64) error {
+	c.EIP7516FTime = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	if c.TargetBlobsPerBlock != nil {
+		return c.TargetBlobsPerBlock
+	}
+	return internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock()
+}
+
+func (c *MultiGethChainConfig) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock() {
+		n = nil
+	}
+	c.TargetBlobsPerBlock = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	if c.MaxBlobsPerBlock != nil {
+		return c.MaxBlobsPerBlock
+	}
+	return internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock()
+}
+
+func (c *MultiGethChainConfig) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock() {
+		n = nil
+	}
+	c.MaxBlobsPerBlock = n
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	if c.BlobBaseFeeUpdateFraction != nil {
+		return c.BlobBaseFeeUpdateFraction
+	}
+	return internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction()
+}
+
+func (c *MultiGethChainConfig) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction() {
+		n = nil
+	}
+	c.BlobBaseFeeUpdateFraction = n
+	return nil
+}
+
 func (c *MultiGethChainConfig) IsForked(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -418,9 +723,12 @@ func (c *MultiGethChainConfig) MustSetConsensusEngineType(t ctypes.ConsensusEngi
 	switch t {
 	case ctypes.ConsensusEngineT_Ethash:
 		c.Ethash = new(ctypes.EthashConfig)
+		c.Clique = nil
 		return nil
 	case ctypes.ConsensusEngineT_Clique:
 		c.Clique = new(ctypes.CliqueConfig)
+		c.Ethash = nil
+		c.EthashNoProof = false
 		return nil
 	default:
 		return ctypes.ErrUnsupportedConfigFatal
@@ -484,6 +792,39 @@ func (c *MultiGethChainConfig) SetEthashEIP779Transition(n *uint64) error {
 	return nil
 }
 
+// The DAO hard fork refund defaults to that of the Ethereum mainnet DAO fork,
+// which is not stored.
+
+func (c *MultiGethChainConfig) GetEthashDAOForkBeneficiary() *common.Address {
+	if c.DAOForkBeneficiary != nil {
+		return c.DAOForkBeneficiary
+	}
+	return internal.DAOForkBeneficiary(c.GetEthashEIP779Transition())
+}
+
+func (c *MultiGethChainConfig) SetEthashDAOForkBeneficiary(a *common.Address) error {
+	if a != nil && *a == vars.DAORefundContract {
+		a = nil
+	}
+	c.DAOForkBeneficiary = a
+	return nil
+}
+
+func (c *MultiGethChainConfig) GetEthashDAOForkAccounts() []common.Address {
+	if c.DAOForkAccounts != nil {
+		return c.DAOForkAccounts
+	}
+	return internal.DAOForkAccounts(c.GetEthashEIP779Transition())
+}
+
+func (c *MultiGethChainConfig) SetEthashDAOForkAccounts(addrs []common.Address) error {
+	if internal.IsDAODrainList(addrs) {
+		addrs = nil
+	}
+	c.DAOForkAccounts = addrs
+	return nil
+}
+
 func (c *MultiGethChainConfig) GetEthashEIP649Transition() *uint64 {
 	if c.eip649FInferred {
 		return bigNewU64(c.EIP649FBlock)
@@ -517,8 +858,11 @@ func (c *MultiGethChainConfig) SetEthashEIP649Transition(n *uint64) error {
 		return nil
 	}
 
+	// EIP1234 may coincide, reducing the block reward further.
 	c.ensureExistingRewardSchedule()
-	c.BlockRewardSchedule[*n] = vars.EIP649FBlockReward
+	if r, ok := c.BlockRewardSchedule[*n]; !ok || r.Cmp(vars.EIP649FBlockReward) > 0 {
+		c.BlockRewardSchedule[*n] = vars.EIP649FBlockReward
+	}
 
 	c.ensureExistingDifficultySchedule()
 	c.DifficultyBombDelaySchedule.SetValueTotalForHeight(n, vars.EIP649DifficultyBombDelay)
@@ -603,6 +947,46 @@ func (c *MultiGethChainConfig) SetEthashEIP2384Transition(n *uint64) error {
 	return nil
 }
 
+func (c *MultiGethChainConfig) GetEthashEIP3554Transition() *uint64 {
+	if c.eip3554Inferred {
+		return bigNewU64(c.EIP3554FBlock)
+	}
+
+	var diffN *uint64
+	defer func() {
+		c.EIP3554FBlock = setBig(c.EIP3554FBlock, diffN)
+		c.eip3554Inferred = true
+	}()
+
+	// Without a schedule, the delay defaults to the EIP1559 block (London).
+	if len(c.DifficultyBombDelaySchedule) == 0 {
+		diffN = c.GetEIP1559Transition()
+		return diffN
+	}
+
+	// Get block number (key) from map where EIP3554 criteria is met.
+	diffN = ctypes.MapMeetsSpecification(c.DifficultyBombDelaySchedule, nil, vars.EIP3554DifficultyBombDelay, nil)
+	return diffN
+}
+
+func (c *MultiGethChainConfig) SetEthashEIP3554Transition(n *uint64) error {
+	if c.Ethash == nil {
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+
+	c.EIP3554FBlock = setBig(c.EIP3554FBlock, n)
+	c.eip3554Inferred = true
+
+	if n == nil {
+		return nil
+	}
+
+	c.ensureExistingDifficultySchedule()
+	c.DifficultyBombDelaySchedule.SetValueTotalForHeight(n, vars.EIP3554DifficultyBombDelay)
+
+	return nil
+}
+
 func (c *MultiGethChainConfig) GetEthashECIP1010PauseTransition() *uint64 {
 	return bigNewU64(c.ECIP1010PauseBlock)
 }
@@ -611,7 +995,7 @@ func (c *MultiGethChainConfig) SetEthashECIP1010PauseTransition(n *uint64) error
 	if c.Ethash == nil {
 		return ctypes.ErrUnsupportedConfigFatal
 	}
-	if c.ECIP1010PauseBlock == nil && c.ECIP1010Length != nil {
+	if c.ECIP1010PauseBlock == nil && c.ECIP1010Length != nil && n != nil {
 		c.ECIP1010PauseBlock = setBig(c.ECIP1010PauseBlock, n)
 		c.ECIP1010Length = c.ECIP1010Length.Sub(c.ECIP1010Length, c.ECIP1010PauseBlock)
 		return nil
@@ -637,7 +1021,8 @@ func (c *MultiGethChainConfig) SetEthashECIP1010ContinueTransition(n *uint64) er
 	}
 	// length = continue - pause
 	if n == nil {
-		return ctypes.ErrUnsupportedConfigNoop
+		c.ECIP1010Length = nil
+		return nil
 	}
 	if c.ECIP1010PauseBlock == nil {
 		c.ECIP1010Length = new(big.Int).SetUint64(*n)
@@ -719,6 +1104,21 @@ func (c *MultiGethChainConfig) SetEthashBlockRewardSchedule(m ctypes.Uint64BigMa
 	return nil
 }
 
+func (c *MultiGethChainConfig) GetEthashNoProof() bool {
+	return c.Ethash != nil && c.EthashNoProof
+}
+
+func (c *MultiGethChainConfig) SetEthashNoProof(b bool) error {
+	if c.Ethash == nil {
+		if !b {
+			return nil
+		}
+		return ctypes.ErrUnsupportedConfigFatal
+	}
+	c.EthashNoProof = b
+	return nil
+}
+
 func (c *MultiGethChainConfig) GetCliquePeriod() uint64 {
 	if c.Clique == nil {
 		return 0
@@ -748,3 +1148,12 @@ func (c *MultiGethChainConfig) SetCliqueEpoch(n uint64) error {
 	c.Clique.Epoch = n
 	return nil
 }
+
+func (c *MultiGethChainConfig) GetExtraFields() map[string]json.RawMessage {
+	return c.Extra
+}
+
+func (c *MultiGethChainConfig) SetExtraFields(m map[string]json.RawMessage) error {
+	c.Extra = m
+	return nil
+}
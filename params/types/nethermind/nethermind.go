@@ -0,0 +1,244 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package nethermind implements the chain specification format used by Nethermind.
+// Nethermind reads Parity's chain specification format, with some additional
+// fields, so its implementation is Parity's, except for those fields.
+package nethermind
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/parity"
+)
+
+// NethermindChainSpec is the chain specification format used by Nethermind.
+//
+// Unlike Parity, Nethermind configures EIP-1706 (eip1706Transition), and
+// EIP-2200 by its own transition (eip2200Transition), rather than by
+// Parity's EIP-1283 reenable transition. Both are written, so that either is read.
+// Nethermind also configures the Shanghai and Cancun EIPs, by timestamp (eip<N>TransitionTimestamp).
+// EIP-7516 is activated with EIP-4844, and the EIP-4844 blob parameters are Parity's (the defaults).
+// Account addresses are written 0x-prefixed, as in Nethermind's chain specifications.
+type NethermindChainSpec struct {
+	parity.ParityChainSpec
+
+	// Timestamps are read from and written to params, with the other fields Parity does not know.
+	Timestamps TimestampTransitions `json:"-"`
+}
+
+// nethermindParams are the params fields which Nethermind reads, but Parity does not.
+type nethermindParams struct {
+	EIP1706Transition *parity.ParityU64 `json:"eip1706Transition,omitempty"`
+	EIP2200Transition *parity.ParityU64 `json:"eip2200Transition,omitempty"`
+
+	TimestampTransitions
+}
+
+// TimestampTransitions are the Shanghai and Cancun EIP transitions, which Nethermind activates by timestamp.
+type TimestampTransitions struct {
+	EIP3651TransitionTimestamp *parity.ParityU64 `json:"eip3651TransitionTimestamp,omitempty"`
+	EIP3855TransitionTimestamp *parity.ParityU64 `json:"eip3855TransitionTimestamp,omitempty"`
+	EIP3860TransitionTimestamp *parity.ParityU64 `json:"eip3860TransitionTimestamp,omitempty"`
+	EIP4895TransitionTimestamp *parity.ParityU64 `json:"eip4895TransitionTimestamp,omitempty"`
+	EIP1153TransitionTimestamp *parity.ParityU64 `json:"eip1153TransitionTimestamp,omitempty"`
+	EIP4788TransitionTimestamp *parity.ParityU64 `json:"eip4788TransitionTimestamp,omitempty"`
+	EIP4844TransitionTimestamp *parity.ParityU64 `json:"eip4844TransitionTimestamp,omitempty"`
+	EIP5656TransitionTimestamp *parity.ParityU64 `json:"eip5656TransitionTimestamp,omitempty"`
+	EIP6780TransitionTimestamp *parity.ParityU64 `json:"eip6780TransitionTimestamp,omitempty"`
+}
+
+func (spec *NethermindChainSpec) UnmarshalJSON(input []byte) error {
+	if err := json.Unmarshal(input, &spec.ParityChainSpec); err != nil {
+		return err
+	}
+	var dec struct {
+		Params nethermindParams `json:"params"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Params.EIP1706Transition != nil {
+		spec.Params.EIP1706Transition = dec.Params.EIP1706Transition
+	}
+	if dec.Params.EIP2200Transition != nil {
+		spec.Params.EIP1283ReenableTransition = dec.Params.EIP2200Transition
+	}
+	spec.Timestamps = dec.Params.TimestampTransitions
+	return nil
+}
+
+// ExtraJSONFields returns a value declaring the params fields which Nethermind
+// reads in addition to Parity's, so that strict readers know them.
+func (spec *NethermindChainSpec) ExtraJSONFields() interface{} {
+	return struct {
+		Params nethermindParams `json:"params"`
+	}{}
+}
+
+func (spec NethermindChainSpec) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(&spec.ParityChainSpec)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(all["params"], &params); err != nil {
+		return nil, err
+	}
+	extra, err := json.Marshal(nethermindParams{
+		EIP1706Transition: spec.Params.EIP1706Transition,
+		EIP2200Transition: spec.Params.EIP1283ReenableTransition,
+
+		TimestampTransitions: spec.Timestamps,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(extra, &params); err != nil {
+		return nil, err
+	}
+	if all["params"], err = json.Marshal(params); err != nil {
+		return nil, err
+	}
+	if spec.Accounts != nil {
+		accounts := make(map[common.Address]*parity.ParityChainSpecAccount, len(spec.Accounts))
+		for k, v := range spec.Accounts {
+			accounts[common.Address(k)] = v
+		}
+		if all["accounts"], err = json.Marshal(accounts); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(all)
+}
+
+// Nethermind does not implement ECIP-1080.
+
+func (spec *NethermindChainSpec) GetECIP1080Transition() *uint64 {
+	return nil
+}
+
+func (spec *NethermindChainSpec) SetECIP1080Transition(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+// Nethermind activates the Shanghai EIPs by timestamp.
+
+func (spec *NethermindChainSpec) GetEIP3651TransitionTime() *uint64 {
+	return spec.Timestamps.EIP3651TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP3651TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP3651TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP3855TransitionTime() *uint64 {
+	return spec.Timestamps.EIP3855TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP3855TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP3855TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP3860TransitionTime() *uint64 {
+	return spec.Timestamps.EIP3860TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP3860TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP3860TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP4895TransitionTime() *uint64 {
+	return spec.Timestamps.EIP4895TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP4895TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP4895TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+// Nethermind activates the Cancun EIPs by timestamp.
+
+func (spec *NethermindChainSpec) GetEIP1153TransitionTime() *uint64 {
+	return spec.Timestamps.EIP1153TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP1153TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP1153TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP4788TransitionTime() *uint64 {
+	return spec.Timestamps.EIP4788TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP4788TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP4788TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP4844TransitionTime() *uint64 {
+	return spec.Timestamps.EIP4844TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP4844TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP4844TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP5656TransitionTime() *uint64 {
+	return spec.Timestamps.EIP5656TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP5656TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP5656TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP6780TransitionTime() *uint64 {
+	return spec.Timestamps.EIP6780TransitionTimestamp.Uint64P()
+}
+
+func (spec *NethermindChainSpec) SetEIP6780TransitionTime(n *uint64) error {
+	spec.Timestamps.EIP6780TransitionTimestamp = new(parity.ParityU64).SetUint64(n)
+	return nil
+}
+
+func (spec *NethermindChainSpec) GetEIP7516TransitionTime() *uint64 {
+	return spec.GetEIP4844TransitionTime()
+}
+
+func (spec *NethermindChainSpec) SetEIP7516TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	if m := spec.GetEIP4844TransitionTime(); m != nil && *m == *n {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package nethermind
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNethermindParams(t *testing.T) {
+	input := `{"params":{"eip1706Transition":"0x2a","eip2200Transition":"0x2b","eip4844TransitionTimestamp":"0x65"}}`
+	spec := &NethermindChainSpec{}
+	if err := json.Unmarshal([]byte(input), spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.GetEIP1706Transition(); got == nil || *got != 42 {
+		t.Errorf("EIP1706: got %v, want 42", got)
+	}
+	if got := spec.GetEIP2200Transition(); got == nil || *got != 43 {
+		t.Errorf("EIP2200: got %v, want 43", got)
+	}
+	if got := spec.GetEIP4844TransitionTime(); got == nil || *got != 101 {
+		t.Errorf("EIP4844Time: got %v, want 101", got)
+	}
+
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	if err := spec.UpdateAccount(addr, big.NewInt(1), 0, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec struct {
+		Params   map[string]json.RawMessage         `json:"params"`
+		Accounts map[common.Address]json.RawMessage `json:"accounts"`
+	}
+	if err := json.Unmarshal(b, &dec); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"eip1706Transition", "eip2200Transition", "eip1283ReenableTransition", "eip4844TransitionTimestamp"} {
+		if _, ok := dec.Params[k]; !ok {
+			t.Errorf("missing param %s", k)
+		}
+	}
+	if _, ok := dec.Accounts[addr]; !ok {
+		t.Errorf("missing account %s", addr.Hex())
+	}
+}
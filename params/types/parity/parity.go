@@ -17,8 +17,11 @@
 package parity
 
 import (
+	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
 	"sort"
@@ -53,13 +56,13 @@ type ParityChainSpec struct {
 				eip1234Transition *ParityU64
 				eip2384Inferred   bool
 				eip2384Transition *ParityU64
+				eip3554Inferred   bool
+				eip3554Transition *ParityU64
 
 				HomesteadTransition *ParityU64 `json:"homesteadTransition"`
 				EIP100bTransition   *ParityU64 `json:"eip100bTransition"`
 
-				// Note: DAO fields will NOT be written to Parity configs from multi-geth.
-				// The chains with DAO settings are already canonical and have existing chainspecs.
-				// There is no need to replicate this information.
+				// DAO hard fork transition, refund contract, and drained accounts.
 				DaoHardforkTransition  *ParityU64       `json:"daoHardforkTransition,omitempty"`
 				DaoHardforkBeneficiary *common.Address  `json:"daoHardforkBeneficiary,omitempty"`
 				DaoHardforkAccounts    []common.Address `json:"daoHardforkAccounts,omitempty"`
@@ -107,7 +110,17 @@ type ParityChainSpec struct {
 		EIP1884Transition         *ParityU64 `json:"eip1884Transition,omitempty"`
 		EIP2028Transition         *ParityU64 `json:"eip2028Transition,omitempty"`
 		EIP1706Transition         *ParityU64 `json:"-"` // FIXME, when and if i'm implemented in Parity
-		ECIP1080Transition        *ParityU64 `json:"-"` // FIXME, when and if i'm implemented in Parity
+		EIP2718Transition         *ParityU64 `json:"eip2718Transition,omitempty"`
+		EIP2929Transition         *ParityU64 `json:"eip2929Transition,omitempty"`
+		EIP2930Transition         *ParityU64 `json:"eip2930Transition,omitempty"`
+		EIP1559Transition         *ParityU64 `json:"eip1559Transition,omitempty"`
+		EIP3529Transition         *ParityU64 `json:"eip3529Transition,omitempty"`
+		EIP3541Transition         *ParityU64 `json:"eip3541Transition,omitempty"`
+		WASMActivationTransition  *ParityU64 `json:"wasmActivationTransition,omitempty"`
+
+		EIP1559BaseFeeMaxChangeDenominator *ParityU64 `json:"eip1559BaseFeeMaxChangeDenominator,omitempty"`
+		EIP1559ElasticityMultiplier        *ParityU64 `json:"eip1559ElasticityMultiplier,omitempty"`
+		ECIP1080Transition                 *ParityU64 `json:"-"` // FIXME, when and if i'm implemented in Parity
 
 		ForkBlock     *ParityU64   `json:"forkBlock,omitempty"`
 		ForkCanonHash *common.Hash `json:"forkCanonHash,omitempty"`
@@ -133,6 +146,21 @@ type ParityChainSpec struct {
 	Accounts map[common.UnprefixedAddress]*ParityChainSpecAccount `json:"accounts"`
 }
 
+// UnmarshalJSON reads a chain spec, rejecting accounts which have more than one entry for the same address.
+func (spec *ParityChainSpec) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Accounts json.RawMessage `json:"accounts"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if err := ctypes.CheckAccountKeys(dec.Accounts); err != nil {
+		return err
+	}
+	type parityChainSpec ParityChainSpec
+	return json.Unmarshal(input, (*parityChainSpec)(spec))
+}
+
 func (c *ParityChainSpec) String() string {
 	cc := &ParityChainSpec{}
 	*cc = *c
@@ -238,11 +266,85 @@ func (i *ParityU64) SetUint64(n *uint64) *ParityU64 {
 // ParityChainSpecAccount is the prefunded genesis account and/or precompiled
 // contract definition.
 type ParityChainSpecAccount struct {
-	Balance math.HexOrDecimal256        `json:"balance"`
-	Nonce   math.HexOrDecimal64         `json:"nonce,omitempty"`
-	Code    hexutil.Bytes               `json:"code,omitempty"`
-	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
-	Builtin *ParityChainSpecBuiltin     `json:"builtin,omitempty"`
+	Balance math.HexOrDecimal256    `json:"balance"`
+	Nonce   math.HexOrDecimal64     `json:"nonce,omitempty"`
+	Code    hexutil.Bytes           `json:"code,omitempty"`
+	Storage ParityChainSpecStorage  `json:"storage,omitempty"`
+	Builtin *ParityChainSpecBuiltin `json:"builtin,omitempty"`
+}
+
+// UnmarshalJSON reads an account, whose balance and nonce may be given as
+// hex or decimal strings, or as JSON numbers, as Parity reads them.
+func (a *ParityChainSpecAccount) UnmarshalJSON(input []byte) error {
+	type account ParityChainSpecAccount
+	var dec struct {
+		account
+		Balance json.RawMessage `json:"balance"`
+		Nonce   json.RawMessage `json:"nonce,omitempty"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*a = ParityChainSpecAccount(dec.account)
+	if dec.Balance != nil {
+		if err := a.Balance.UnmarshalText(parityNumberText(dec.Balance)); err != nil {
+			return fmt.Errorf("invalid account balance: %v", err)
+		}
+	}
+	if dec.Nonce != nil {
+		if err := a.Nonce.UnmarshalText(parityNumberText(dec.Nonce)); err != nil {
+			return fmt.Errorf("invalid account nonce: %v", err)
+		}
+	}
+	return nil
+}
+
+// parityNumberText returns the text of a JSON number, or of a JSON string.
+func parityNumberText(input json.RawMessage) []byte {
+	if s, err := strconv.Unquote(string(input)); err == nil {
+		return []byte(s)
+	}
+	return input
+}
+
+// ParityChainSpecStorage is a genesis account's storage.
+// Keys and values may be given as hex of less than 32 bytes, eg. "0x01",
+// which is left-padded, as Parity does.
+type ParityChainSpecStorage map[common.Hash]common.Hash
+
+func (s *ParityChainSpecStorage) UnmarshalJSON(input []byte) error {
+	var dec map[parityStorageWord]parityStorageWord
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec == nil {
+		*s = nil
+		return nil
+	}
+	*s = make(ParityChainSpecStorage, len(dec))
+	for k, v := range dec {
+		(*s)[common.Hash(k)] = common.Hash(v)
+	}
+	return nil
+}
+
+// parityStorageWord is a 256 bit storage key or value, which may be
+// unmarshaled from hex of fewer than 64 characters.
+type parityStorageWord common.Hash
+
+func (w *parityStorageWord) UnmarshalText(text []byte) error {
+	text = bytes.TrimPrefix(text, []byte("0x"))
+	if len(text) > 64 {
+		return fmt.Errorf("too many hex characters in storage key/value %q", text)
+	}
+	if len(text)%2 == 1 {
+		text = append([]byte{'0'}, text...)
+	}
+	offset := len(w) - len(text)/2 // pad on the left
+	if _, err := hex.Decode(w[offset:], text); err != nil {
+		return fmt.Errorf("invalid hex storage key/value %q", text)
+	}
+	return nil
 }
 
 // ParityChainSpecBuiltin is the precompiled contract definition.
@@ -253,6 +355,32 @@ type ParityChainSpecBuiltin struct {
 	EIP1108Transition *ParityU64                   `json:"eip1108_transition,omitempty"` // EIP1108Transition can't be omitted if empty, default means no fork
 }
 
+// UnmarshalJSON accepts each of the builtin activation forms:
+// a top-level 'activate_at' (as hex or decimal string, or integer),
+// an 'activate_at' nested in the (single) pricing object,
+// and a pricing map keyed on activation blocks.
+// Nested activations are normalized to the top-level field.
+func (b *ParityChainSpecBuiltin) UnmarshalJSON(input []byte) error {
+	type builtin ParityChainSpecBuiltin
+	var dec builtin
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.ActivateAt == nil && dec.Pricing != nil && dec.Pricing.Pricing != nil {
+		var nested struct {
+			Pricing struct {
+				ActivateAt *ParityU64 `json:"activate_at"`
+			} `json:"pricing"`
+		}
+		if err := json.Unmarshal(input, &nested); err != nil {
+			return err
+		}
+		dec.ActivateAt = nested.Pricing.ActivateAt
+	}
+	*b = ParityChainSpecBuiltin(dec)
+	return nil
+}
+
 type ParityChainSpecPricingMaybe struct {
 	Map     map[*math.HexOrDecimal256]ParityChainSpecPricingPrice
 	Pricing *ParityChainSpecPricing
@@ -288,10 +416,14 @@ func (p *ParityChainSpecPricingMaybe) UnmarshalJSON(input []byte) error {
 	sort.Strings(sl)
 	p.Map = make(map[*math.HexOrDecimal256]ParityChainSpecPricingPrice)
 	for _, s := range sl {
-		p.Map[(*math.HexOrDecimal256)(math.MustParseBig256(s))] = mm[s]
+		n, ok := math.ParseBig256(s)
+		if !ok {
+			return fmt.Errorf("invalid builtin pricing activation block %q", s)
+		}
+		p.Map[(*math.HexOrDecimal256)(n)] = mm[s]
 	}
 	if len(p.Map) == 0 {
-		panic("0map")
+		return fmt.Errorf("invalid builtin pricing: %s", input)
 	}
 	return nil
 }
@@ -308,6 +440,7 @@ func (p ParityChainSpecPricingMaybe) MarshalJSON() ([]byte, error) {
 type ParityChainSpecPricing struct {
 	Linear              *ParityChainSpecLinearPricing              `json:"linear,omitempty"`
 	ModExp              *ParityChainSpecModExpPricing              `json:"modexp,omitempty"`
+	ModExp2565          *ParityChainSpecModExp2565Pricing          `json:"modexp2565,omitempty"`
 	AltBnPairing        *ParityChainSpecAltBnPairingPricing        `json:"alt_bn128_pairing,omitempty"`
 	AltBnConstOperation *ParityChainSpecAltBnConstOperationPricing `json:"alt_bn128_const_operations,omitempty"`
 
@@ -324,6 +457,9 @@ type ParityChainSpecModExpPricing struct {
 	Divisor uint64 `json:"divisor"`
 }
 
+// ParityChainSpecModExp2565Pricing is the EIP-2565 modexp pricing, which has no parameters.
+type ParityChainSpecModExp2565Pricing struct{}
+
 type ParityChainSpecAltBnConstOperationPricing struct {
 	Price                  uint64 `json:"price"`
 	EIP1108TransitionPrice uint64 `json:"eip1108_transition_price,omitempty"` // Before Istanbul fork, this field is nil
@@ -340,6 +476,55 @@ type ParityChainSpecBlakePricing struct {
 	GasPerRound uint64 `json:"gas_per_round"`
 }
 
+// StandardBuiltins are the builtins which configurations model as precompile transitions, by address.
+// Builtins of other names or addresses (eg. a chain's custom precompiles) are not modeled,
+// so are kept by conversion as the builtins of genesis accounts where the target allows it;
+// see GetAccountBuiltins.
+var StandardBuiltins = map[common.Address]string{
+	common.BytesToAddress([]byte{1}): "ecrecover",
+	common.BytesToAddress([]byte{2}): "sha256",
+	common.BytesToAddress([]byte{3}): "ripemd160",
+	common.BytesToAddress([]byte{4}): "identity",
+	common.BytesToAddress([]byte{5}): "modexp",
+	common.BytesToAddress([]byte{6}): "alt_bn128_add",
+	common.BytesToAddress([]byte{7}): "alt_bn128_mul",
+	common.BytesToAddress([]byte{8}): "alt_bn128_pairing",
+	common.BytesToAddress([]byte{9}): "blake2_f",
+}
+
+// GetAccountBuiltins returns the builtins which are not standard builtins (see StandardBuiltins), by address.
+func (spec *ParityChainSpec) GetAccountBuiltins() map[common.Address]json.RawMessage {
+	builtins := make(map[common.Address]json.RawMessage)
+	for k, v := range spec.Accounts {
+		if v == nil || v.Builtin == nil || StandardBuiltins[common.Address(k)] == v.Builtin.Name {
+			continue
+		}
+		b, err := json.Marshal(v.Builtin)
+		if err != nil {
+			continue
+		}
+		builtins[common.Address(k)] = b
+	}
+	return builtins
+}
+
+// SetAccountBuiltin sets the builtin of an account, adding the account if there is none.
+func (spec *ParityChainSpec) SetAccountBuiltin(address common.Address, builtin json.RawMessage) error {
+	var bin ParityChainSpecBuiltin
+	if err := json.Unmarshal(builtin, &bin); err != nil {
+		return fmt.Errorf("invalid builtin: %v", err)
+	}
+	if spec.Accounts == nil {
+		spec.Accounts = make(map[common.UnprefixedAddress]*ParityChainSpecAccount)
+	}
+	a := common.UnprefixedAddress(address)
+	if _, exist := spec.Accounts[a]; !exist {
+		spec.Accounts[a] = &ParityChainSpecAccount{}
+	}
+	spec.Accounts[a].Builtin = &bin
+	return nil
+}
+
 func (spec *ParityChainSpec) GetPrecompile(address common.Address, pricing ParityChainSpecPricing) *ParityU64 {
 	if spec.Accounts == nil {
 		return nil
@@ -358,6 +543,11 @@ func (spec *ParityChainSpec) GetPrecompile(address common.Address, pricing Parit
 		return nil
 	}
 	if reflect.DeepEqual(acc.Builtin.Pricing.Pricing, &pricing) {
+		if acc.Builtin.ActivateAt == nil {
+			// Builtins without an activation are active from genesis.
+			activation := ParityU64(0)
+			return &activation
+		}
 		return acc.Builtin.ActivateAt
 	}
 	return nil
@@ -401,6 +591,21 @@ func (spec *ParityChainSpec) SetPrecompile2(address common.Address, name string,
 			Pricing: nil,
 		}
 	}
+	if bin.Pricing.Map == nil {
+		// Move a single pricing to the map, at its activation, so that another may be added.
+		bin.Pricing.Map = make(map[*math.HexOrDecimal256]ParityChainSpecPricingPrice)
+		if bin.Pricing.Pricing != nil {
+			activation := int64(0)
+			if bin.ActivateAt != nil {
+				activation = int64(*bin.ActivateAt)
+			}
+			bin.Pricing.Map[math.NewHexOrDecimal256(activation)] = ParityChainSpecPricingPrice{
+				ParityChainSpecPricing: *bin.Pricing.Pricing,
+			}
+		}
+		bin.Pricing.Pricing = nil
+		bin.ActivateAt = nil
+	}
 
 	// Always write in activation-map format.
 	bin.Pricing.Map[math.NewHexOrDecimal256(int64(*activationBlock))] = ParityChainSpecPricingPrice{
@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/internal"
 	"github.com/ethereum/go-ethereum/params/vars"
 )
 
@@ -106,6 +107,15 @@ func (spec *ParityChainSpec) SetChainID(i *big.Int) error {
 	return nil
 }
 
+func (spec *ParityChainSpec) GetBootnodes() []string {
+	return spec.Nodes
+}
+
+func (spec *ParityChainSpec) SetBootnodes(b []string) error {
+	spec.Nodes = b
+	return nil
+}
+
 func (spec *ParityChainSpec) GetEIP7Transition() *uint64 {
 	return spec.Engine.Ethash.Params.HomesteadTransition.Uint64P()
 }
@@ -151,11 +161,34 @@ func (spec *ParityChainSpec) SetEIP161abcTransition(i *uint64) error {
 	return nil
 }
 
+// parityNeverTransition is the transition value written to disable features
+// which Parity enables from genesis when their transition is not given.
+const parityNeverTransition = math.MaxInt64
+
+// isParityNeverTransition reports whether a transition value is one of
+// the conventional values used by Parity specs for a transition which never occurs.
+func isParityNeverTransition(n uint64) bool {
+	return n >= ctypes.ParityNeverTransition
+}
+
+// GetEIP155Transition returns the EIP155 transition, which is independent of the chain ID.
+// Parity enables EIP155 from genesis if no transition is given.
 func (spec *ParityChainSpec) GetEIP155Transition() *uint64 {
+	if spec.Params.EIP155Transition == nil {
+		zero := uint64(0)
+		return &zero
+	}
+	if isParityNeverTransition(uint64(*spec.Params.EIP155Transition)) {
+		return nil
+	}
 	return spec.Params.EIP155Transition.Uint64P()
 }
 
 func (spec *ParityChainSpec) SetEIP155Transition(i *uint64) error {
+	if i == nil {
+		never := uint64(parityNeverTransition)
+		i = &never
+	}
 	spec.Params.EIP155Transition = new(ParityU64).SetUint64(i)
 	return nil
 }
@@ -340,6 +373,21 @@ func (spec *ParityChainSpec) SetEIP198Transition(n *uint64) error {
 	return nil
 }
 
+// EIP-2565 reprices the modexp builtin, which is configured by a second pricing entry.
+
+func (spec *ParityChainSpec) GetEIP2565Transition() *uint64 {
+	return spec.GetPrecompile(common.BytesToAddress([]byte{5}), ParityChainSpecPricing{
+		ModExp2565: &ParityChainSpecModExp2565Pricing{},
+	}).Uint64P()
+}
+
+func (spec *ParityChainSpec) SetEIP2565Transition(n *uint64) error {
+	spec.SetPrecompile2(common.BytesToAddress([]byte{5}), "modexp", n, ParityChainSpecPricing{
+		ModExp2565: &ParityChainSpecModExp2565Pricing{},
+	})
+	return nil
+}
+
 func (spec *ParityChainSpec) GetEIP212Transition() *uint64 {
 	f212 := spec.GetPrecompile(common.BytesToAddress([]byte{8}),
 		ParityChainSpecPricing{
@@ -477,6 +525,244 @@ func (c *ParityChainSpec) SetEIP1706Transition(n *uint64) error {
 	return nil
 }
 
+func (c *ParityChainSpec) GetEIP2718Transition() *uint64 {
+	return c.Params.EIP2718Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP2718Transition(n *uint64) error {
+	c.Params.EIP2718Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP2929Transition() *uint64 {
+	return c.Params.EIP2929Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP2929Transition(n *uint64) error {
+	c.Params.EIP2929Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP2930Transition() *uint64 {
+	return c.Params.EIP2930Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP2930Transition(n *uint64) error {
+	c.Params.EIP2930Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP1559Transition() *uint64 {
+	return c.Params.EIP1559Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP1559Transition(n *uint64) error {
+	c.Params.EIP1559Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP1559BaseFeeChangeDenominator() *uint64 {
+	if n := c.Params.EIP1559BaseFeeMaxChangeDenominator.Uint64P(); n != nil {
+		return n
+	}
+	return internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator()
+}
+
+func (c *ParityChainSpec) SetEIP1559BaseFeeChangeDenominator(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP1559BaseFeeChangeDenominator() {
+		n = nil
+	}
+	c.Params.EIP1559BaseFeeMaxChangeDenominator = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP1559ElasticityMultiplier() *uint64 {
+	if n := c.Params.EIP1559ElasticityMultiplier.Uint64P(); n != nil {
+		return n
+	}
+	return internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier()
+}
+
+func (c *ParityChainSpec) SetEIP1559ElasticityMultiplier(n *uint64) error {
+	if n != nil && *n == *internal.GlobalConfigurator().GetEIP1559ElasticityMultiplier() {
+		n = nil
+	}
+	c.Params.EIP1559ElasticityMultiplier = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+// The London EIPs default to the EIP1559 transition when unset.
+
+func (c *ParityChainSpec) GetEIP3529Transition() *uint64 {
+	if c.Params.EIP3529Transition == nil {
+		return c.GetEIP1559Transition()
+	}
+	return c.Params.EIP3529Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP3529Transition(n *uint64) error {
+	c.Params.EIP3529Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP3541Transition() *uint64 {
+	if c.Params.EIP3541Transition == nil {
+		return c.GetEIP1559Transition()
+	}
+	return c.Params.EIP3541Transition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEIP3541Transition(n *uint64) error {
+	c.Params.EIP3541Transition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEWASMTransition() *uint64 {
+	if c.Params.WASMActivationTransition == nil {
+		return nil
+	}
+	return c.Params.WASMActivationTransition.Uint64P()
+}
+
+func (c *ParityChainSpec) SetEWASMTransition(n *uint64) error {
+	c.Params.WASMActivationTransition = new(ParityU64).SetUint64(n)
+	return nil
+}
+
+func (c *ParityChainSpec) GetEIP3651TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP3651TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP3855TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP3855TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP3860TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP3860TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP4895TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP4895TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP1153TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP1153TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP4788TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP4788TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP4844TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP4844TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP5656TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP5656TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP6780TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP6780TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP7516TransitionTime() *uint64 {
+	return nil
+}
+
+func (c *ParityChainSpec) SetEIP7516TransitionTime(n *uint64) error {
+	if n == nil {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
+func (c *ParityChainSpec) GetEIP4844TargetBlobsPerBlock() *uint64 {
+	return internal.GlobalConfigurator().GetEIP4844TargetBlobsPerBlock()
+}
+
+func (c *ParityChainSpec) SetEIP4844TargetBlobsPerBlock(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP4844TargetBlobsPerBlock(n)
+}
+
+func (c *ParityChainSpec) GetEIP4844MaxBlobsPerBlock() *uint64 {
+	return internal.GlobalConfigurator().GetEIP4844MaxBlobsPerBlock()
+}
+
+func (c *ParityChainSpec) SetEIP4844MaxBlobsPerBlock(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP4844MaxBlobsPerBlock(n)
+}
+
+func (c *ParityChainSpec) GetEIP4844BlobBaseFeeUpdateFraction() *uint64 {
+	return internal.GlobalConfigurator().GetEIP4844BlobBaseFeeUpdateFraction()
+}
+
+func (c *ParityChainSpec) SetEIP4844BlobBaseFeeUpdateFraction(n *uint64) error {
+	return internal.GlobalConfigurator().SetEIP4844BlobBaseFeeUpdateFraction(n)
+}
+
 func (spec *ParityChainSpec) IsForked(fn func() *uint64, n *big.Int) bool {
 	f := fn()
 	if f == nil || n == nil {
@@ -521,10 +807,14 @@ func (spec *ParityChainSpec) GetConsensusEngineType() ctypes.ConsensusEngineT {
 }
 
 func (spec *ParityChainSpec) MustSetConsensusEngineType(t ctypes.ConsensusEngineT) error {
+	// Engine types are exclusive; reset the fields of the other engine.
+	var empty ParityChainSpec
 	switch t {
 	case ctypes.ConsensusEngineT_Ethash:
+		spec.Engine.Clique = empty.Engine.Clique
 		return nil
 	case ctypes.ConsensusEngineT_Clique:
+		spec.Engine.Ethash = empty.Engine.Ethash
 		return nil
 	default:
 		return ctypes.ErrUnsupportedConfigFatal
@@ -589,10 +879,38 @@ func (spec *ParityChainSpec) GetEthashEIP779Transition() *uint64 {
 	return spec.Engine.Ethash.Params.DaoHardforkTransition.Uint64P()
 }
 
+// SetEthashEIP779Transition sets the DAO hard fork transition. Parity requires the refund contract
+// and drained accounts of the fork, so those of the Ethereum mainnet DAO fork are set if none are.
 func (spec *ParityChainSpec) SetEthashEIP779Transition(n *uint64) error {
 	spec.Engine.Ethash.Params.DaoHardforkTransition = new(ParityU64).SetUint64(n)
-	spec.Engine.Ethash.Params.DaoHardforkBeneficiary = &vars.DAORefundContract
-	spec.Engine.Ethash.Params.DaoHardforkAccounts = vars.DAODrainList()
+	if n == nil {
+		return nil
+	}
+	if spec.Engine.Ethash.Params.DaoHardforkBeneficiary == nil {
+		a := vars.DAORefundContract
+		spec.Engine.Ethash.Params.DaoHardforkBeneficiary = &a
+	}
+	if spec.Engine.Ethash.Params.DaoHardforkAccounts == nil {
+		spec.Engine.Ethash.Params.DaoHardforkAccounts = vars.DAODrainList()
+	}
+	return nil
+}
+
+func (spec *ParityChainSpec) GetEthashDAOForkBeneficiary() *common.Address {
+	return spec.Engine.Ethash.Params.DaoHardforkBeneficiary
+}
+
+func (spec *ParityChainSpec) SetEthashDAOForkBeneficiary(a *common.Address) error {
+	spec.Engine.Ethash.Params.DaoHardforkBeneficiary = a
+	return nil
+}
+
+func (spec *ParityChainSpec) GetEthashDAOForkAccounts() []common.Address {
+	return spec.Engine.Ethash.Params.DaoHardforkAccounts
+}
+
+func (spec *ParityChainSpec) SetEthashDAOForkAccounts(addrs []common.Address) error {
+	spec.Engine.Ethash.Params.DaoHardforkAccounts = addrs
 	return nil
 }
 
@@ -626,8 +944,11 @@ func (spec *ParityChainSpec) SetEthashEIP649Transition(n *uint64) error {
 		spec.Engine.Ethash.Params.DifficultyBombDelays = ctypes.Uint64BigMapEncodesHex{}
 	}
 
+	// EIP1234 may coincide, reducing the block reward further.
 	spec.ensureExistingRewardSchedule()
-	spec.Engine.Ethash.Params.BlockReward[*n] = vars.EIP649FBlockReward
+	if r, ok := spec.Engine.Ethash.Params.BlockReward[*n]; !ok || r.Cmp(vars.EIP649FBlockReward) > 0 {
+		spec.Engine.Ethash.Params.BlockReward[*n] = vars.EIP649FBlockReward
+	}
 
 	spec.ensureExistingDifficultyDelaySchedule()
 	spec.Engine.Ethash.Params.DifficultyBombDelays.SetValueTotalForHeight(n, vars.EIP649DifficultyBombDelay)
@@ -702,6 +1023,42 @@ func (spec *ParityChainSpec) SetEthashEIP2384Transition(n *uint64) error {
 	return nil
 }
 
+func (spec *ParityChainSpec) GetEthashEIP3554Transition() *uint64 {
+	if spec.Engine.Ethash.Params.eip3554Inferred {
+		return spec.Engine.Ethash.Params.eip3554Transition.Uint64P()
+	}
+
+	var diffN *uint64
+	defer func() {
+		spec.Engine.Ethash.Params.eip3554Transition = new(ParityU64).SetUint64(diffN)
+		spec.Engine.Ethash.Params.eip3554Inferred = true
+	}()
+
+	// Without a schedule, the delay defaults to the EIP1559 transition (London).
+	if len(spec.Engine.Ethash.Params.DifficultyBombDelays) == 0 {
+		diffN = spec.GetEIP1559Transition()
+		return diffN
+	}
+
+	// Get block number (key) from map where EIP3554 criteria is met.
+	diffN = ctypes.MapMeetsSpecification(spec.Engine.Ethash.Params.DifficultyBombDelays, nil, vars.EIP3554DifficultyBombDelay, nil)
+	return diffN
+}
+
+func (spec *ParityChainSpec) SetEthashEIP3554Transition(n *uint64) error {
+	spec.Engine.Ethash.Params.eip3554Transition = new(ParityU64).SetUint64(n)
+	spec.Engine.Ethash.Params.eip3554Inferred = true
+
+	if n == nil {
+		return nil
+	}
+
+	spec.ensureExistingDifficultyDelaySchedule()
+	spec.Engine.Ethash.Params.DifficultyBombDelays.SetValueTotalForHeight(n, vars.EIP3554DifficultyBombDelay)
+
+	return nil
+}
+
 func (spec *ParityChainSpec) GetEthashECIP1010PauseTransition() *uint64 {
 	return spec.Engine.Ethash.Params.ECIP1010PauseTransition.Uint64P()
 }
@@ -785,6 +1142,17 @@ func (spec *ParityChainSpec) SetEthashBlockRewardSchedule(input ctypes.Uint64Big
 	return nil
 }
 
+func (spec *ParityChainSpec) GetEthashNoProof() bool {
+	return false
+}
+
+func (spec *ParityChainSpec) SetEthashNoProof(b bool) error {
+	if !b {
+		return nil
+	}
+	return ctypes.ErrUnsupportedConfigFatal
+}
+
 func (spec *ParityChainSpec) GetCliquePeriod() uint64 {
 	p := spec.Engine.Clique.Params.Period.Uint64P()
 	if p == nil {
@@ -908,7 +1276,7 @@ func (spec *ParityChainSpec) ForEachAccount(fn func(address common.Address, bal
 		if v.Builtin != nil && (v.Balance.ToInt() == nil || v.Balance.ToInt().Cmp(new(big.Int)) == 0) {
 			continue
 		}
-		err = fn(common.Address(k), v.Balance.ToInt(), uint64(v.Nonce), v.Code, v.Storage)
+		err = fn(common.Address(k), v.Balance.ToInt(), uint64(v.Nonce), v.Code, map[common.Hash]common.Hash(v.Storage))
 		if err != nil {
 			return err
 		}
@@ -925,8 +1293,13 @@ func (spec *ParityChainSpec) UpdateAccount(address common.Address, bal *big.Int,
 	if !ok {
 		spec.Accounts[addr] = &ParityChainSpecAccount{}
 	}
-	spec.Accounts[addr].Balance = *math.NewHexOrDecimal256(bal.Int64())
+	if bal == nil {
+		bal = new(big.Int)
+	}
+	spec.Accounts[addr].Balance = math.HexOrDecimal256(*new(big.Int).Set(bal))
 	spec.Accounts[addr].Nonce = math.HexOrDecimal64(nonce)
+	spec.Accounts[addr].Code = code
+	spec.Accounts[addr].Storage = storage
 
 	zero := uint64(0)
 	switch address {
@@ -0,0 +1,120 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package retesteth implements the chain configuration format read by the
+// ethereum/tests retesteth tool (test_setChainParams).
+// The format is that of Aleth's genesis specification, since retesteth was
+// written against Aleth, but with 0x-prefixed account addresses.
+package retesteth
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/aleth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// Seal engines known to retesteth.
+// NoProof and NoReward follow ethash rules, but do not verify the proof of work;
+// NoReward additionally pays no block rewards.
+const (
+	SealEngineEthash   = "Ethash"
+	SealEngineNoProof  = "NoProof"
+	SealEngineNoReward = "NoReward"
+)
+
+// RetestethGenesisSpec is the chain configuration retesteth sets for a test.
+// Its Configurator implementation is Aleth's, except for the seal engine.
+type RetestethGenesisSpec struct {
+	aleth.AlethGenesisSpec
+}
+
+// MarshalJSON writes the spec with 0x-prefixed account addresses.
+// A zero DAO fork block is omitted, since retesteth reads any value as the fork block.
+func (spec RetestethGenesisSpec) MarshalJSON() ([]byte, error) {
+	params, err := json.Marshal(spec.Params)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Params.DaoHardforkBlock == 0 {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(params, &m); err != nil {
+			return nil, err
+		}
+		delete(m, "daoHardforkBlock")
+		if params, err = json.Marshal(m); err != nil {
+			return nil, err
+		}
+	}
+	var accounts map[common.Address]*aleth.AlethGenesisSpecAccount
+	if spec.Accounts != nil {
+		accounts = make(map[common.Address]*aleth.AlethGenesisSpecAccount, len(spec.Accounts))
+		for k, v := range spec.Accounts {
+			accounts[common.Address(k)] = v
+		}
+	}
+	return json.Marshal(&struct {
+		SealEngine string                                            `json:"sealEngine"`
+		Params     json.RawMessage                                   `json:"params"`
+		Genesis    interface{}                                       `json:"genesis"`
+		Accounts   map[common.Address]*aleth.AlethGenesisSpecAccount `json:"accounts"`
+	}{
+		SealEngine: spec.SealEngine,
+		Params:     params,
+		Genesis:    spec.Genesis,
+		Accounts:   accounts,
+	})
+}
+
+func (spec *RetestethGenesisSpec) GetConsensusEngineType() ctypes.ConsensusEngineT {
+	if spec.SealEngine == SealEngineNoReward {
+		return ctypes.ConsensusEngineT_Ethash
+	}
+	return spec.AlethGenesisSpec.GetConsensusEngineType()
+}
+
+// MustSetConsensusEngineType sets the seal engine.
+// Since test chains' blocks are not mined, ethash is configured as NoProof,
+// unless the spec already uses the Ethash or NoReward seal engine.
+func (spec *RetestethGenesisSpec) MustSetConsensusEngineType(t ctypes.ConsensusEngineT) error {
+	seal := spec.SealEngine
+	if err := spec.AlethGenesisSpec.MustSetConsensusEngineType(t); err != nil {
+		return err
+	}
+	switch seal {
+	case SealEngineEthash, SealEngineNoReward:
+		spec.SealEngine = seal
+	default:
+		spec.SealEngine = SealEngineNoProof
+	}
+	return nil
+}
+
+// GetEthashNoProof returns false, since NoProof is retesteth's default seal engine,
+// rather than a configuration of the test chain.
+func (spec *RetestethGenesisSpec) GetEthashNoProof() bool {
+	return false
+}
+
+// SetEthashNoProof sets the NoProof seal engine, or otherwise leaves the seal engine to
+// MustSetConsensusEngineType.
+func (spec *RetestethGenesisSpec) SetEthashNoProof(b bool) error {
+	if b {
+		spec.SealEngine = SealEngineNoProof
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 The multi-geth Authors
+// This file is part of the multi-geth library.
+//
+// The multi-geth library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The multi-geth library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the multi-geth library. If not, see <http://www.gnu.org/licenses/>.
+
+package retesteth
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// TestMarshalJSON tests that the spec is written in the shape retesteth reads:
+// 0x-prefixed account addresses, and no DAO fork block unless configured.
+func TestMarshalJSON(t *testing.T) {
+	spec := &RetestethGenesisSpec{}
+	if err := spec.MustSetConsensusEngineType(ctypes.ConsensusEngineT_Ethash); err != nil {
+		t.Fatal(err)
+	}
+	addr := common.HexToAddress("0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b")
+	if err := spec.UpdateAccount(addr, big.NewInt(1), 0, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec struct {
+		SealEngine string                             `json:"sealEngine"`
+		Params     map[string]json.RawMessage         `json:"params"`
+		Accounts   map[common.Address]json.RawMessage `json:"accounts"`
+	}
+	if err := json.Unmarshal(b, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.SealEngine != SealEngineNoProof {
+		t.Errorf("seal engine: want: %s, got: %s", SealEngineNoProof, dec.SealEngine)
+	}
+	if _, ok := dec.Params["daoHardforkBlock"]; ok {
+		t.Error("unset DAO fork block written")
+	}
+	if _, ok := dec.Accounts[addr]; !ok {
+		t.Errorf("missing account %s", addr.Hex())
+	}
+}
+
+func TestSealEngine(t *testing.T) {
+	for _, seal := range []string{SealEngineEthash, SealEngineNoReward} {
+		spec := &RetestethGenesisSpec{}
+		spec.SealEngine = seal
+		if !spec.GetConsensusEngineType().IsEthash() {
+			t.Errorf("%s: not read as ethash", seal)
+		}
+		if err := spec.MustSetConsensusEngineType(ctypes.ConsensusEngineT_Ethash); err != nil {
+			t.Fatal(err)
+		}
+		if spec.SealEngine != seal {
+			t.Errorf("seal engine: want: %s, got: %s", seal, spec.SealEngine)
+		}
+	}
+}
@@ -30,6 +30,8 @@ var (
 	EIP1234DifficultyBombDelay = big.NewInt(5000000)
 
 	EIP2384DifficultyBombDelay = big.NewInt(9000000)
+	// https://github.com/ethereum/EIPs/blob/master/EIPS/eip-3554.md
+	EIP3554DifficultyBombDelay = big.NewInt(9700000)
 )
 
 var (
@@ -125,6 +127,13 @@ var (
 
 	MaxCodeSize uint64 = 24576 // Maximum bytecode to permit for a contract
 
+	BaseFeeChangeDenominator uint64 = 8 // Bounds the amount the base fee can change between blocks (EIP-1559)
+	ElasticityMultiplier     uint64 = 2 // Bounds the maximum gas limit an EIP-1559 block may have
+
+	TargetBlobsPerBlock       uint64 = 3       // Target number of blobs per block (EIP-4844)
+	MaxBlobsPerBlock          uint64 = 6       // Maximum number of blobs per block (EIP-4844)
+	BlobBaseFeeUpdateFraction uint64 = 3338477 // Bounds the amount the blob base fee can change between blocks (EIP-4844)
+
 	// Precompiled contract gas prices
 
 	EcrecoverGas        uint64 = 3000 // Elliptic curve sender recovery gas price